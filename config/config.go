@@ -7,11 +7,21 @@ import (
 )
 
 type ServiceConfiguration struct {
-	PostgresDB `yaml:"postgres_db" json:"postgresDB"`
-	RedisDB    `yaml:"redis_db" json:"redisDB"`
-	Api        `yaml:"api" json:"api"`
-	User       `yaml:"user" json:"user"`
-	Email      `json:"email"`
+	PostgresDB     `yaml:"postgres_db" json:"postgresDB"`
+	RedisDB        `yaml:"redis_db" json:"redisDB"`
+	Api            `yaml:"api" json:"api"`
+	User           `yaml:"user" json:"user"`
+	Email          `yaml:"email" json:"email"`
+	RateLimits     `yaml:"rate_limits" json:"rateLimits"`
+	BlobStore      `yaml:"blob_store" json:"blobStore"`
+	Profile        `yaml:"profile" json:"profile"`
+	DataExport     `yaml:"data_export" json:"dataExport"`
+	Idempotency    `yaml:"idempotency" json:"idempotency"`
+	Reconciliation `yaml:"reconciliation" json:"reconciliation"`
+	Store          `yaml:"store" json:"store"`
+	Marketplace    `yaml:"marketplace" json:"marketplace"`
+	Trades         `yaml:"trades" json:"trades"`
+	Crafting       `yaml:"crafting" json:"crafting"`
 }
 
 type Api struct {
@@ -21,8 +31,102 @@ type Api struct {
 
 type User struct {
 	SigningKey           string
+	SigningKeyID         string
+	SigningAlgorithm     string `yaml:"signing_algorithm"`
+	PreviousSigningKeys  string
 	AccessTokenLifetime  int `yaml:"access_token_lifetime"`
 	RefreshTokenLifetime int `yaml:"refresh_token_lifetime"`
+	// SessionPurgeInterval в секундах - как часто удаляются истекшие сессии
+	SessionPurgeInterval int `yaml:"session_purge_interval"`
+}
+
+// RateLimit - не более Limit запросов за Window секунд
+type RateLimit struct {
+	Limit  int `yaml:"limit"`
+	Window int `yaml:"window"`
+}
+
+type RateLimits struct {
+	SignIn                   RateLimit `yaml:"sign_in"`
+	TwoFactorVerify          RateLimit `yaml:"two_factor_verify"`
+	SendCode                 RateLimit `yaml:"send_code"`
+	ForgotPassword           RateLimit `yaml:"forgot_password"`
+	FailedSignIn             RateLimit `yaml:"failed_sign_in"`
+	TwoFactor                RateLimit `yaml:"two_factor"`
+	FailedTwoFactor          RateLimit `yaml:"failed_two_factor"`
+	VerificationCodeCooldown int       `yaml:"verification_code_cooldown"`
+}
+
+type BlobStore struct {
+	LocalDir  string `yaml:"local_dir"`
+	PublicURL string `yaml:"public_url"`
+}
+
+type Profile struct {
+	// NicknameChangeCooldown в часах
+	NicknameChangeCooldown int   `yaml:"nickname_change_cooldown"`
+	AvatarMaxSize          int64 `yaml:"avatar_max_size"`
+	AvatarSizes            []int `yaml:"avatar_sizes"`
+	// EmailChangeUndoTTL в часах - сколько действует ссылка отмены смены email
+	EmailChangeUndoTTL int `yaml:"email_change_undo_ttl"`
+	// DeletionGracePeriod в днях - сколько удаленный аккаунт можно восстановить входом
+	DeletionGracePeriod int `yaml:"deletion_grace_period"`
+	// PurgeInterval в секундах - как часто запускается окончательное удаление
+	PurgeInterval int `yaml:"purge_interval"`
+}
+
+type DataExport struct {
+	// Interval в секундах
+	Interval int `yaml:"interval"`
+	// Retention в часах - сколько хранится готовый архив
+	Retention int `yaml:"retention"`
+	// LinkTTL в минутах - сколько действует выданная ссылка на скачивание
+	LinkTTL     int    `yaml:"link_ttl"`
+	DownloadURL string `yaml:"download_url"`
+	Secret      string
+}
+
+type Idempotency struct {
+	// TTL в часах - сколько хранится сохраненный ответ
+	TTL int `yaml:"ttl"`
+	// LockTTL в секундах - сколько ключ занят выполняющимся запросом
+	LockTTL int `yaml:"lock_ttl"`
+}
+
+type Reconciliation struct {
+	// Hour - час по локальному времени, в который ежедневно запускается сверка балансов
+	Hour int `yaml:"hour"`
+}
+
+type Store struct {
+	PhotoMaxSize int64 `yaml:"photo_max_size"`
+}
+
+type Marketplace struct {
+	// FeePercent - комиссия площадки в процентах от цены, удерживается из суммы продавцу
+	FeePercent int `yaml:"fee_percent"`
+}
+
+type Trades struct {
+	// OfferTTL в часах - сколько предложение обмена ждет ответа
+	OfferTTL int `yaml:"offer_ttl"`
+	// ExpireInterval в секундах - как часто закрываются просроченные предложения
+	ExpireInterval int `yaml:"expire_interval"`
+}
+
+// RarityPrices - количество пыли для каждой редкости карточки
+type RarityPrices struct {
+	Silver int `yaml:"silver"`
+	Gold   int `yaml:"gold"`
+}
+
+type Crafting struct {
+	// UpgradeCards - сколько серебряных карточек одного игрока объединяются в одну золотую
+	UpgradeCards int `yaml:"upgrade_cards"`
+	// DismantleDust - сколько пыли дает разбор карточки
+	DismantleDust RarityPrices `yaml:"dismantle_dust"`
+	// CraftPrice - сколько пыли стоит создание карточки выбранного игрока
+	CraftPrice RarityPrices `yaml:"craft_price"`
 }
 
 type PostgresDB struct {
@@ -43,6 +147,23 @@ type RedisDB struct {
 type Email struct {
 	Login    string
 	Password string
+	Driver   string      `yaml:"driver"`
+	SMTPHost string      `yaml:"smtp_host"`
+	SMTPPort int         `yaml:"smtp_port"`
+	From     string      `yaml:"from"`
+	BaseURL  string      `yaml:"base_url"`
+	Language string      `yaml:"language"`
+	FileDir  string      `yaml:"file_dir"`
+	Outbox   EmailOutbox `yaml:"outbox"`
+	// UnsubscribeURL - адрес API, по которому работает ссылка отписки из писем
+	UnsubscribeURL    string `yaml:"unsubscribe_url"`
+	UnsubscribeSecret string
+}
+
+type EmailOutbox struct {
+	Interval    int `yaml:"interval"`
+	BatchSize   int `yaml:"batch_size"`
+	MaxAttempts int `yaml:"max_attempts"`
 }
 
 func (api *Api) GetAddr() string {
@@ -68,6 +189,8 @@ func Load() ServiceConfiguration {
 		panic(err)
 	}
 	cfg.User.SigningKey = getEnv("SIGNING_KEY")
+	cfg.User.SigningKeyID = getEnvDefault("SIGNING_KEY_ID", "default")
+	cfg.User.PreviousSigningKeys = getEnvDefault("SIGNING_KEYS_PREVIOUS", "")
 	cfg.PostgresDB.Password = getEnv("POSTGRES_PASSWORD")
 	cfg.PostgresDB.DBName = getEnv("POSTGRES_DB")
 	cfg.PostgresDB.Username = getEnv("POSTGRES_USER")
@@ -78,6 +201,9 @@ func Load() ServiceConfiguration {
 	cfg.Api.HOST = getEnv("API_HOST")
 	cfg.Email.Login = getEnv("EMAIL_LOGIN")
 	cfg.Email.Password = getEnv("EMAIL_PASSWORD")
+	cfg.Email.BaseURL = getEnvDefault("EMAIL_BASE_URL", cfg.Email.BaseURL)
+	cfg.Email.UnsubscribeSecret = getEnv("UNSUBSCRIBE_SECRET")
+	cfg.DataExport.Secret = getEnv("DATA_EXPORT_SECRET")
 
 	return cfg
 }
@@ -89,3 +215,11 @@ func getEnv(key string) string {
 	}
 	return val
 }
+
+func getEnvDefault(key string, defaultValue string) string {
+	val, exists := os.LookupEnv(key)
+	if !exists {
+		return defaultValue
+	}
+	return val
+}
@@ -15,35 +15,38 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/email/send-code": {
-            "post": {
-                "description": "Отправка письма с кодом для подтверждения email пользователя",
-                "consumes": [
-                    "application/json"
+        "/admin/coins/adjustments": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Изменения баланса пользователя и изменения, сделанные им как администратором, от новых к старым",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Отправка кода подтверждения",
+                "summary": "Журнал ручных изменений баланса",
                 "parameters": [
                     {
-                        "description": "Входные параметры",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.EmailInput"
-                        }
+                        "type": "string",
+                        "description": "ID пользователя",
+                        "name": "profileID",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/pkg_api.StatusResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.CoinAdjustment"
+                            }
                         }
                     },
                     "400": {
@@ -52,6 +55,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/pkg_api.Error"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -61,9 +76,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/logout": {
+        "/admin/coins/deduct": {
             "post": {
-                "description": "Выход пользователя из системы",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Ручное списание монет с обязательной причиной. Доступно только администраторам, попадает в журнал изменений",
                 "consumes": [
                     "application/json"
                 ],
@@ -71,9 +91,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Выход из системы",
+                "summary": "Списание монет у пользователя",
                 "parameters": [
                     {
                         "description": "Входные параметры",
@@ -81,15 +101,21 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.RefreshInput"
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.CoinAdjustmentInput"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор с тем же ключом вернет сохраненный ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/pkg_api.StatusResponse"
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.CoinAdjustment"
                         }
                     },
                     "400": {
@@ -98,6 +124,30 @@ const docTemplate = `{
                             "$ref": "#/definitions/pkg_api.Error"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -107,9 +157,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/refresh-tokens": {
+        "/admin/coins/grant": {
             "post": {
-                "description": "Обновление access и refresh токенов",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Ручное начисление монет с обязательной причиной. Доступно только администраторам, попадает в журнал изменений",
                 "consumes": [
                     "application/json"
                 ],
@@ -117,9 +172,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Обновление токенов",
+                "summary": "Начисление монет пользователю",
                 "parameters": [
                     {
                         "description": "Входные параметры",
@@ -127,15 +182,21 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.RefreshInput"
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.CoinAdjustmentInput"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор с тем же ключом вернет сохраненный ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.Tokens"
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.CoinAdjustment"
                         }
                     },
                     "400": {
@@ -144,6 +205,30 @@ const docTemplate = `{
                             "$ref": "#/definitions/pkg_api.Error"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -153,39 +238,39 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/sign-in": {
-            "post": {
-                "description": "Авторизация пользователя в системе",
-                "consumes": [
-                    "application/json"
+        "/admin/reconciliation/runs": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Последние 30 запусков ежедневной сверки балансов с главной книгой, от новых к старым. Доступно только администраторам",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
-                ],
-                "summary": "Авторизация",
-                "parameters": [
-                    {
-                        "description": "Входные параметры",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.SignInInput"
-                        }
-                    }
+                    "admin"
                 ],
+                "summary": "Запуски сверки балансов",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.Tokens"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.ReconciliationRun"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/pkg_api.Error"
                         }
@@ -197,41 +282,36 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/auth/sign-up": {
+            },
             "post": {
-                "description": "Регистрация нового пользователя в системе",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Запускает сверку балансов сразу, не дожидаясь ежедневного запуска. Доступно только администраторам",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
-                ],
-                "summary": "Регистрация",
-                "parameters": [
-                    {
-                        "description": "Входные параметры",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.SignUpInput"
-                        }
-                    }
+                    "admin"
                 ],
+                "summary": "Внеплановая сверка балансов",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/pkg_api.StatusResponse"
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.ReconciliationRun"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/pkg_api.Error"
                         }
@@ -245,61 +325,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/players/cards": {
+        "/admin/reconciliation/runs/{id}": {
             "get": {
-                "description": "Получение списка карточек игроков",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Найденные при запуске сверки расхождения. Доступно только администраторам",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "players"
+                    "admin"
                 ],
-                "summary": "Получение списка карточек игроков",
+                "summary": "Отчет сверки балансов",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "profileID",
-                        "name": "profileID",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "Silver",
-                            "Gold"
-                        ],
-                        "type": "string",
-                        "description": "rarity",
-                        "name": "rarity",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "NHL",
-                            "KHL"
-                        ],
-                        "type": "string",
-                        "description": "league",
-                        "name": "league",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "unpacked",
-                        "name": "unpacked",
-                        "in": "query"
+                        "type": "integer",
+                        "description": "ID запуска сверки",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.PlayerCardResponse"
-                            }
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.ReconciliationReport"
                         }
                     },
                     "400": {
@@ -308,6 +362,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/pkg_api.Error"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -317,14 +389,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/players/cards/unpack": {
+        "/admin/roles/grant": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Распаковка карточки игрока",
+                "description": "Выдача роли пользователю. Доступно только администраторам. Роль появится в токене пользователя после обновления токенов",
                 "consumes": [
                     "application/json"
                 ],
@@ -332,17 +404,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "players"
+                    "admin"
                 ],
-                "summary": "Распаковка карточки игрока",
+                "summary": "Выдача роли пользователю",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "example": 1,
-                        "description": "id карточки",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
+                        "description": "Входные параметры",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.RoleInput"
+                        }
                     }
                 ],
                 "responses": {
@@ -364,6 +437,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/pkg_api.Error"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -373,68 +452,40 @@ const docTemplate = `{
                 }
             }
         },
-        "/players/info": {
-            "get": {
-                "description": "Получение списка игроков",
-                "consumes": [
-                    "application/json"
+        "/admin/roles/revoke": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Отзыв роли у пользователя. Доступно только администраторам",
+                "consumes": [
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "players"
+                    "admin"
                 ],
-                "summary": "Получение списка игроков",
+                "summary": "Отзыв роли у пользователя",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "profileID",
-                        "name": "profileID",
-                        "in": "query"
-                    },
-                    {
-                        "type": "array",
-                        "description": "players",
-                        "name": "id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "array",
-                        "description": "teams",
-                        "name": "teams",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "G",
-                            "D",
-                            "F"
-                        ],
-                        "type": "string",
-                        "description": "position",
-                        "name": "position",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "NHL",
-                            "KHL"
-                        ],
-                        "type": "string",
-                        "description": "league",
-                        "name": "league",
-                        "in": "query"
+                        "description": "Входные параметры",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.RoleInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.PlayerResponse"
-                            }
+                            "$ref": "#/definitions/pkg_api.StatusResponse"
                         }
                     },
                     "400": {
@@ -443,6 +494,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/pkg_api.Error"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -452,14 +515,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/players/khl/create": {
-            "post": {
+        "/admin/store/products": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Добавление игроков КХЛ в базу данных",
+                "description": "Список товаров со служебными полями, включая неактивные и вне окна продажи. Доступно только администраторам",
                 "consumes": [
                     "application/json"
                 ],
@@ -467,14 +530,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "players"
+                    "admin"
+                ],
+                "summary": "Получение всех товаров магазина",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Показывать архивные товары",
+                        "name": "includeArchived",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Добавление игроков КХЛ",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/pkg_api.StatusResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.AdminProduct"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
                         }
                     },
                     "401": {
@@ -483,6 +563,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/pkg_api.Error"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -490,16 +576,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/players/nhl/create": {
+            },
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Добавление игроков НХЛ в базу данных",
+                "description": "Создание товара в магазине. Если sortOrder не указан, товар встает в конец витрины. Доступно только администраторам",
                 "consumes": [
                     "application/json"
                 ],
@@ -507,14 +591,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "players"
+                    "admin"
+                ],
+                "summary": "Создание товара",
+                "parameters": [
+                    {
+                        "description": "Входные параметры",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.ProductInput"
+                        }
+                    }
                 ],
-                "summary": "Добавление игроков НХЛ",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/pkg_api.StatusResponse"
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.AdminProduct"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
                         }
                     },
                     "401": {
@@ -523,6 +624,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/pkg_api.Error"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -532,14 +639,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/players/statistic_player/{player_id}": {
-            "get": {
+        "/admin/store/products/order": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращается все поля статистики за всё время",
+                "description": "Задает порядок показа товаров на витрине по позиции id в списке. Доступно только администраторам",
                 "consumes": [
                     "application/json"
                 ],
@@ -547,26 +654,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "players"
+                    "admin"
                 ],
-                "summary": "Получение полной статистики по id игрока",
+                "summary": "Изменение порядка товаров",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "id игрока",
-                        "name": "player_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Входные параметры",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.ReorderProductsInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.PlayersStatisticDB"
-                            }
+                            "$ref": "#/definitions/pkg_api.StatusResponse"
                         }
                     },
                     "400": {
@@ -581,36 +687,16 @@ const docTemplate = `{
                             "$ref": "#/definitions/pkg_api.Error"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/pkg_api.Error"
                         }
-                    }
-                }
-            }
-        },
-        "/store/products": {
-            "get": {
-                "description": "Получение списка товаров из fantasy магазина",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "store"
-                ],
-                "summary": "Получение товаров из fantasy магазина",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.Product"
-                            }
+                            "$ref": "#/definitions/pkg_api.Error"
                         }
                     },
                     "500": {
@@ -622,14 +708,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/store/products/buy": {
-            "post": {
+        "/admin/store/products/{id}": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Покупка товара в магазине по id товара",
+                "description": "Изменение товара в магазине. Архивный товар изменить нельзя. Доступно только администраторам",
                 "consumes": [
                     "application/json"
                 ],
@@ -637,24 +723,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "store"
+                    "admin"
                 ],
-                "summary": "Покупка товара в магазине",
+                "summary": "Изменение товара",
                 "parameters": [
                     {
                         "type": "integer",
-                        "example": 1,
                         "description": "id товара",
                         "name": "id",
-                        "in": "query",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Входные параметры",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.ProductInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/pkg_api.StatusResponse"
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.AdminProduct"
                         }
                     },
                     "400": {
@@ -663,6 +757,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/pkg_api.Error"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -672,24 +784,39 @@ const docTemplate = `{
                 }
             }
         },
-        "/tournament/create_team_khl": {
-            "get": {
+        "/admin/store/products/{id}/archive": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Добавлят информацию о команде KHL",
+                "description": "Снимает товар с продажи без удаления, чтобы история покупок оставалась целой. Доступно только администраторам",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tournament"
+                    "admin"
+                ],
+                "summary": "Архивирование товара",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id товара",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Создание команд KHL",
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.StatusResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -702,75 +829,69 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/pkg_api.Error"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
                     }
                 }
             }
         },
-        "/tournament/create_team_nhl": {
-            "get": {
+        "/admin/store/products/{id}/drop-table": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Добавлят информацию о команде NHL",
+                "description": "Задает веса редкостей, гарантированные слоты, квоты позиций и шанс звездного игрока. Доступно только администраторам",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tournament"
+                    "admin"
                 ],
-                "summary": "Создание команд NHL",
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/pkg_api.Error"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/pkg_api.Error"
-                        }
-                    }
-                }
-            }
-        },
-        "/tournament/get_matches/{league}": {
-            "get": {
-                "description": "Дата берётся автоматически",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "tournament"
-                ],
-                "summary": "Получение матчей на следующий день",
+                "summary": "Изменение таблицы выпадения набора",
                 "parameters": [
                     {
-                        "enum": [
-                            "NHL",
-                            "KHL"
-                        ],
-                        "type": "string",
-                        "description": "league",
-                        "name": "league",
+                        "type": "integer",
+                        "description": "id товара",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Входные параметры",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.DropTable"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_tournaments.Matches"
-                            }
+                            "$ref": "#/definitions/pkg_api.StatusResponse"
                         }
                     },
                     "400": {
@@ -779,55 +900,72 @@ const docTemplate = `{
                             "$ref": "#/definitions/pkg_api.Error"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/pkg_api.Error"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
                     }
                 }
             }
         },
-        "/tournament/get_tournaments/{league}": {
-            "get": {
+        "/admin/store/products/{id}/photo": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Дата берётся автоматически",
+                "description": "Загрузка изображения товара (JPEG, PNG или GIF), ссылка сохраняется в photoLink. Доступно только администраторам",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tournament"
+                    "admin"
                 ],
-                "summary": "Получение турниров на ближайшие 2 дня",
+                "summary": "Загрузка изображения товара",
                 "parameters": [
                     {
-                        "enum": [
-                            "NHL",
-                            "KHL",
-                            "Both"
-                        ],
-                        "type": "string",
-                        "description": "league",
-                        "name": "league",
+                        "type": "integer",
+                        "description": "id товара",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Изображение",
+                        "name": "photo",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_tournaments.Tournament"
-                            }
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.AdminProduct"
                         }
                     },
                     "400": {
@@ -842,23 +980,30 @@ const docTemplate = `{
                             "$ref": "#/definitions/pkg_api.Error"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/pkg_api.Error"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
                     }
                 }
             }
         },
-        "/tournament/matches_by_tournament_id/{tournament_id}": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Возвращается вся необходимая информация о матчах",
+        "/auth/2fa/verify": {
+            "post": {
+                "description": "Обмен challenge, полученного при входе, и TOTP кода (или кода восстановления) на токены",
                 "consumes": [
                     "application/json"
                 ],
@@ -866,26 +1011,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tournament"
+                    "auth"
                 ],
-                "summary": "Получение матчей по id турнира",
+                "summary": "Подтверждение входа кодом 2FA",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "id турнира",
-                        "name": "tournament_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Входные параметры",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.TwoFactorVerifyInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_tournaments.GetMatchesByTourId"
-                            }
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.Tokens"
                         }
                     },
                     "400": {
@@ -894,14 +1038,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/pkg_api.Error"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
                             "$ref": "#/definitions/pkg_api.Error"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/pkg_api.Error"
                         }
@@ -909,14 +1053,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/tournament/results": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Получение результатов турнира",
+        "/auth/email/send-code": {
+            "post": {
+                "description": "Отправка письма с кодом для подтверждения email пользователя",
                 "consumes": [
                     "application/json"
                 ],
@@ -924,26 +1063,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tournament"
+                    "auth"
                 ],
-                "summary": "Получение результатов турнира",
+                "summary": "Отправка кода подтверждения",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "tournamentID",
-                        "name": "tournamentID",
-                        "in": "query",
-                        "required": true
+                        "description": "Входные параметры",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.EmailInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.TournamentResults"
-                            }
+                            "$ref": "#/definitions/pkg_api.StatusResponse"
                         }
                     },
                     "400": {
@@ -952,8 +1090,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/pkg_api.Error"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
                             "$ref": "#/definitions/pkg_api.Error"
                         }
@@ -967,14 +1105,29 @@ const docTemplate = `{
                 }
             }
         },
-        "/tournament/roster": {
+        "/auth/jwks.json": {
             "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
+                "description": "Набор публичных ключей (JWKS) для проверки access токенов другими сервисами. HS256 ключи не публикуются",
+                "produces": [
+                    "application/json"
                 ],
-                "description": "Получение составов на турнир",
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Публичные ключи подписи токенов",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_service.JWKSet"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/logout": {
+            "post": {
+                "description": "Выход пользователя из системы",
                 "consumes": [
                     "application/json"
                 ],
@@ -982,23 +1135,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tournament"
+                    "auth"
                 ],
-                "summary": "Получение составов на турнир",
+                "summary": "Выход из системы",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "tournamentID",
-                        "name": "tournamentID",
-                        "in": "query",
-                        "required": true
+                        "description": "Входные параметры",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.RefreshInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.TournamentRosterResponse"
+                            "$ref": "#/definitions/pkg_api.StatusResponse"
                         }
                     },
                     "400": {
@@ -1007,12 +1162,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/pkg_api.Error"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/pkg_api.Error"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1022,14 +1171,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/tournament/team": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Получение команды пользователя в турнире",
+        "/auth/refresh-tokens": {
+            "post": {
+                "description": "Обновление access и refresh токенов",
                 "consumes": [
                     "application/json"
                 ],
@@ -1037,23 +1181,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tournament"
+                    "auth"
                 ],
-                "summary": "Получение команды пользователя в турнире",
+                "summary": "Обновление токенов",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "tournamentID",
-                        "name": "tournamentID",
-                        "in": "query",
-                        "required": true
+                        "description": "Входные параметры",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.RefreshInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.UserTeamResponse"
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.Tokens"
                         }
                     },
                     "400": {
@@ -1062,12 +1208,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/pkg_api.Error"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/pkg_api.Error"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1077,14 +1217,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/tournament/team/create": {
+        "/auth/sign-in": {
             "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Создание команды пользователя в турнире",
+                "description": "Авторизация пользователя в системе. Если у пользователя включена 2FA, вместо токенов возвращается challenge для /auth/2fa/verify",
                 "consumes": [
                     "application/json"
                 ],
@@ -1092,24 +1227,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tournament"
+                    "auth"
                 ],
-                "summary": "Создание команды пользователя в турнире",
+                "summary": "Авторизация",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "tournamentID",
-                        "name": "tournamentID",
-                        "in": "query",
-                        "required": true
-                    },
                     {
                         "description": "Входные параметры",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_tournaments.UserTeamInput"
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.SignInInput"
                         }
                     }
                 ],
@@ -1117,7 +1245,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/pkg_api.StatusResponse"
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.Tokens"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.TwoFactorChallenge"
                         }
                     },
                     "400": {
@@ -1126,8 +1260,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/pkg_api.Error"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
                             "$ref": "#/definitions/pkg_api.Error"
                         }
@@ -1141,14 +1275,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/tournament/team/edit": {
-            "put": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Редактирование команды пользователя в турнире",
+        "/auth/sign-up": {
+            "post": {
+                "description": "Регистрация нового пользователя в системе",
                 "consumes": [
                     "application/json"
                 ],
@@ -1156,24 +1285,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tournament"
+                    "auth"
                 ],
-                "summary": "Редактирование команды пользователя в турнире",
+                "summary": "Регистрация",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "tournamentID",
-                        "name": "tournamentID",
-                        "in": "query",
-                        "required": true
-                    },
                     {
                         "description": "Входные параметры",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_tournaments.UserTeamInput"
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.SignUpInput"
                         }
                     }
                 ],
@@ -1190,12 +1312,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/pkg_api.Error"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/pkg_api.Error"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1205,14 +1321,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/tournaments": {
+        "/crafting": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Получение турниров",
+                "description": "Сколько пыли у пользователя, сколько дает разбор и стоит создание карточки каждой редкости",
                 "consumes": [
                     "application/json"
                 ],
@@ -1220,58 +1336,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tournament"
-                ],
-                "summary": "Получение турниров",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "tournamentID",
-                        "name": "tournamentID",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "NHL",
-                            "KHL"
-                        ],
-                        "type": "string",
-                        "description": "league",
-                        "name": "league",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "not_yet_started",
-                            "started",
-                            "finished",
-                            "active"
-                        ],
-                        "type": "string",
-                        "description": "status",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "all",
-                            "personal"
-                        ],
-                        "type": "string",
-                        "description": "type",
-                        "name": "type",
-                        "in": "query",
-                        "required": true
-                    }
+                    "crafting"
                 ],
+                "summary": "Баланс пыли и цены крафта",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_tournaments.Tournament"
-                            }
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.CraftingInfo"
                         }
                     },
                     "400": {
@@ -1295,14 +1367,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/delete": {
-            "delete": {
+        "/crafting/craft": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Удаление профиля пользователя",
+                "description": "Создает распакованную карточку выбранного игрока. Цена зависит от редкости",
                 "consumes": [
                     "application/json"
                 ],
@@ -1310,14 +1382,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "crafting"
+                ],
+                "summary": "Создание карточки за пыль",
+                "parameters": [
+                    {
+                        "description": "Входные параметры",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.CraftCardInput"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор с тем же ключом вернет сохраненный ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
                 ],
-                "summary": "Удаление профиля",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/pkg_api.StatusResponse"
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.CardConversion"
                         }
                     },
                     "400": {
@@ -1332,6 +1421,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/pkg_api.Error"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1341,9 +1442,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/exists": {
-            "get": {
-                "description": "Существует ли уже пользователь с таким email или nickname. Код 200: пользователь с такими данными уже существует, код 404: пользователь с такими данными не найден.",
+        "/crafting/dismantle": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Разобрать можно свои распакованные карточки вне состава незавершенного турнира и не выставленные на рынок",
                 "consumes": [
                     "application/json"
                 ],
@@ -1351,30 +1457,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "crafting"
                 ],
-                "summary": "Существует ли пользователь с указанными параметрами",
+                "summary": "Разбор карточек на пыль",
                 "parameters": [
                     {
-                        "type": "string",
-                        "example": "test@test.test",
-                        "description": "Email пользователя",
-                        "name": "email",
-                        "in": "query"
+                        "description": "Входные параметры",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.DismantleCardsInput"
+                        }
                     },
                     {
                         "type": "string",
-                        "example": "Qwerty1",
-                        "description": "Nickname пользователя",
-                        "name": "nickname",
-                        "in": "query"
+                        "description": "Ключ идемпотентности: повтор с тем же ключом вернет сохраненный ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/pkg_api.StatusResponse"
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.CardConversion"
                         }
                     },
                     "400": {
@@ -1383,8 +1490,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/pkg_api.Error"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/pkg_api.Error"
                         }
@@ -1398,14 +1517,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/info": {
+        "/crafting/history": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Получение пользовательской информации по access токену",
+                "description": "Все разборы, улучшения и созданные карточки с изменением баланса пыли, от новых к старым. Для следующей страницы передайте в before id последней записи",
                 "consumes": [
                     "application/json"
                 ],
@@ -1413,14 +1532,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "crafting"
+                ],
+                "summary": "Журнал крафта",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id последней записи предыдущей страницы",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Размер страницы, по умолчанию 20, максимум 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Получение информации о пользователе",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.UserInfoModel"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.CardConversion"
+                            }
                         }
                     },
                     "400": {
@@ -1444,14 +1580,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/password/change": {
-            "patch": {
+        "/crafting/upgrade": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Смена пароля",
+                "description": "Объединяет серебряные карточки одного игрока в одну золотую. Нужное количество карточек - upgradeCards из GET /crafting",
                 "consumes": [
                     "application/json"
                 ],
@@ -1459,9 +1595,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "crafting"
                 ],
-                "summary": "Смена пароля",
+                "summary": "Улучшение карточек",
                 "parameters": [
                     {
                         "description": "Входные параметры",
@@ -1469,15 +1605,21 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.ChangePasswordInput"
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.UpgradeCardsInput"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор с тем же ключом вернет сохраненный ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/pkg_api.StatusResponse"
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.CardConversion"
                         }
                     },
                     "400": {
@@ -1492,6 +1634,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/pkg_api.Error"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1501,35 +1655,30 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/password/forgot": {
-            "post": {
-                "description": "Отправка письма с информацией о сбросе пароля",
-                "consumes": [
-                    "application/json"
-                ],
+        "/files/avatars/{name}": {
+            "get": {
+                "description": "Отдает файл аватара по ссылке из photoLink",
                 "produces": [
-                    "application/json"
+                    "image/png"
                 ],
                 "tags": [
                     "user"
                 ],
-                "summary": "Забыли пароль",
+                "summary": "Получение аватара",
                 "parameters": [
                     {
-                        "description": "Входные параметры",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.EmailInput"
-                        }
+                        "type": "string",
+                        "description": "Имя файла",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/pkg_api.StatusResponse"
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -1538,6 +1687,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/pkg_api.Error"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1547,35 +1702,32 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/password/reset": {
-            "patch": {
-                "description": "Восстановление пароля",
-                "consumes": [
-                    "application/json"
-                ],
+        "/files/products/{name}": {
+            "get": {
+                "description": "Отдает файл изображения товара по ссылке из photoLink",
                 "produces": [
-                    "application/json"
+                    "image/png",
+                    "image/jpeg",
+                    "image/gif"
                 ],
                 "tags": [
-                    "user"
+                    "store"
                 ],
-                "summary": "Восстановление пароля",
+                "summary": "Получение изображения товара",
                 "parameters": [
                     {
-                        "description": "Входные параметры",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.ResetPasswordInput"
-                        }
+                        "type": "string",
+                        "description": "Имя файла",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/pkg_api.StatusResponse"
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -1584,8 +1736,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/pkg_api.Error"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/pkg_api.Error"
                         }
@@ -1593,14 +1751,101 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/transactions": {
+        "/marketplace/listings": {
             "get": {
+                "description": "Активные объявления от новых к старым. Для следующей страницы передайте в before id последнего объявления",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "marketplace"
+                ],
+                "summary": "Объявления рынка карточек",
+                "parameters": [
+                    {
+                        "enum": [
+                            1,
+                            2
+                        ],
+                        "type": "integer",
+                        "description": "Лига",
+                        "name": "league",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            1,
+                            2,
+                            3
+                        ],
+                        "type": "integer",
+                        "description": "Позиция",
+                        "name": "position",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            1,
+                            2
+                        ],
+                        "type": "integer",
+                        "description": "Редкость",
+                        "name": "rarity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "id команды",
+                        "name": "team",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "id последнего объявления предыдущей страницы",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Размер страницы, по умолчанию 20, максимум 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.CardListingResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Получение истории транзакций пользователя по access токену",
+                "description": "Выставить можно только свою распакованную карточку, игрок которой не стоит в составе незавершенного турнира",
                 "consumes": [
                     "application/json"
                 ],
@@ -1608,17 +1853,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "marketplace"
+                ],
+                "summary": "Выставление карточки на продажу",
+                "parameters": [
+                    {
+                        "description": "Входные параметры",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.CreateListingInput"
+                        }
+                    }
                 ],
-                "summary": "Получение истории транзакций пользователя",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.CoinTransactionsModel"
-                            }
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.CardListing"
                         }
                     },
                     "400": {
@@ -1633,6 +1886,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/pkg_api.Error"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1641,83 +1900,4230 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.FullPlayerStatInfo": {
-            "type": "object",
-            "properties": {
-                "assists": {
-                    "type": "integer"
-                },
-                "fantasyPoint": {
-                    "type": "number"
-                },
-                "gameDate": {
-                    "type": "string"
-                },
-                "goals": {
-                    "type": "integer"
-                },
-                "hits": {
-                    "type": "integer"
-                },
-                "missedGoals": {
-                    "type": "integer"
-                },
-                "name": {
-                    "type": "string"
+        },
+        "/marketplace/listings/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Снятие своего активного объявления",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "marketplace"
+                ],
+                "summary": "Снятие карточки с продажи",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id объявления",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.StatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/marketplace/listings/{id}/buy": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Покупатель платит цену объявления, продавец получает ее за вычетом комиссии площадки, карточка переходит покупателю",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "marketplace"
+                ],
+                "summary": "Покупка карточки на рынке",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id объявления",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор с тем же ключом вернет сохраненный ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.CardListing"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/players/cards": {
+            "get": {
+                "description": "Получение списка карточек игроков",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "players"
+                ],
+                "summary": "Получение списка карточек игроков",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "profileID",
+                        "name": "profileID",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "Silver",
+                            "Gold"
+                        ],
+                        "type": "string",
+                        "description": "rarity",
+                        "name": "rarity",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "NHL",
+                            "KHL"
+                        ],
+                        "type": "string",
+                        "description": "league",
+                        "name": "league",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "unpacked",
+                        "name": "unpacked",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.PlayerCardResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/players/cards/unpack": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Распаковка карточки игрока",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "players"
+                ],
+                "summary": "Распаковка карточки игрока",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "id карточки",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.StatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/players/info": {
+            "get": {
+                "description": "Получение списка игроков",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "players"
+                ],
+                "summary": "Получение списка игроков",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "profileID",
+                        "name": "profileID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "description": "players",
+                        "name": "id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "description": "teams",
+                        "name": "teams",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "G",
+                            "D",
+                            "F"
+                        ],
+                        "type": "string",
+                        "description": "position",
+                        "name": "position",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "NHL",
+                            "KHL"
+                        ],
+                        "type": "string",
+                        "description": "league",
+                        "name": "league",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.PlayerResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/players/khl/create": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Добавление игроков КХЛ в базу данных",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "players"
+                ],
+                "summary": "Добавление игроков КХЛ",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.StatusResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/players/nhl/create": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Добавление игроков НХЛ в базу данных",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "players"
+                ],
+                "summary": "Добавление игроков НХЛ",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.StatusResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/players/statistic_player/{player_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращается все поля статистики за всё время",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "players"
+                ],
+                "summary": "Получение полной статистики по id игрока",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id игрока",
+                        "name": "player_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.PlayersStatisticDB"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/store/fairness/seed": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Хэш сида сервера, сид клиента и nonce, которыми будет открыт следующий набор. Сам сид сервера раскрывается при смене пары",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "store"
+                ],
+                "summary": "Текущая пара сидов для открытия наборов",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.PackSeed"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/store/fairness/seed/rotate": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Раскрывает текущий сид сервера, чтобы по нему можно было проверить открытые наборы, и начинает новую пару с переданным сидом клиента",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "store"
+                ],
+                "summary": "Смена пары сидов",
+                "parameters": [
+                    {
+                        "description": "Входные параметры",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.RotatePackSeedInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.PackSeedRotation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/store/openings/{id}/verify": {
+            "get": {
+                "description": "Пересчитывает набор по раскрытым сидам: seed = HMAC-SHA256(serverSeed, \"clientSeed:nonce\"), первые 8 байт seed засевают генератор розыгрыша. Доступно после смены пары сидов, которой был открыт набор",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "store"
+                ],
+                "summary": "Проверка открытия набора",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id открытия набора (packOpeningID карточки)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.PackVerification"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/store/products": {
+            "get": {
+                "description": "Получение списка товаров из fantasy магазина",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "store"
+                ],
+                "summary": "Получение товаров из fantasy магазина",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.Product"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/store/products/buy": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Покупка товара в магазине по id товара",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "store"
+                ],
+                "summary": "Покупка товара в магазине",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "id товара",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор с тем же ключом вернет сохраненный ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.StatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/store/products/{id}/odds": {
+            "get": {
+                "description": "Раскрытие шансов: вероятность каждой редкости для негарантированного слота в процентах, гарантированные слоты, квоты позиций и шанс звездного игрока",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "store"
+                ],
+                "summary": "Шансы выпадения карточек в наборе",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id товара",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.ProductOdds"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournament/create_team_khl": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Добавлят информацию о команде KHL",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tournament"
+                ],
+                "summary": "Создание команд KHL",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournament/create_team_nhl": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Добавлят информацию о команде NHL",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tournament"
+                ],
+                "summary": "Создание команд NHL",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournament/get_matches/{league}": {
+            "get": {
+                "description": "Дата берётся автоматически",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tournament"
+                ],
+                "summary": "Получение матчей на следующий день",
+                "parameters": [
+                    {
+                        "enum": [
+                            "NHL",
+                            "KHL"
+                        ],
+                        "type": "string",
+                        "description": "league",
+                        "name": "league",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_tournaments.Matches"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournament/get_tournaments/{league}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Дата берётся автоматически",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tournament"
+                ],
+                "summary": "Получение турниров на ближайшие 2 дня",
+                "parameters": [
+                    {
+                        "enum": [
+                            "NHL",
+                            "KHL",
+                            "Both"
+                        ],
+                        "type": "string",
+                        "description": "league",
+                        "name": "league",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_tournaments.Tournament"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournament/matches_by_tournament_id/{tournament_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращается вся необходимая информация о матчах",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tournament"
+                ],
+                "summary": "Получение матчей по id турнира",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id турнира",
+                        "name": "tournament_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_tournaments.GetMatchesByTourId"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournament/results": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Получение результатов турнира",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tournament"
+                ],
+                "summary": "Получение результатов турнира",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "tournamentID",
+                        "name": "tournamentID",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.TournamentResults"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournament/roster": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Получение составов на турнир",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tournament"
+                ],
+                "summary": "Получение составов на турнир",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "tournamentID",
+                        "name": "tournamentID",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.TournamentRosterResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournament/team": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Получение команды пользователя в турнире",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tournament"
+                ],
+                "summary": "Получение команды пользователя в турнире",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "tournamentID",
+                        "name": "tournamentID",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.UserTeamResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournament/team/create": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Создание команды пользователя в турнире",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tournament"
+                ],
+                "summary": "Создание команды пользователя в турнире",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "tournamentID",
+                        "name": "tournamentID",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Входные параметры",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_tournaments.UserTeamInput"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор с тем же ключом вернет сохраненный ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.StatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournament/team/edit": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Редактирование команды пользователя в турнире",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tournament"
+                ],
+                "summary": "Редактирование команды пользователя в турнире",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "tournamentID",
+                        "name": "tournamentID",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Входные параметры",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_tournaments.UserTeamInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.StatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/tournaments": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Получение турниров",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tournament"
+                ],
+                "summary": "Получение турниров",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "tournamentID",
+                        "name": "tournamentID",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "NHL",
+                            "KHL"
+                        ],
+                        "type": "string",
+                        "description": "league",
+                        "name": "league",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "not_yet_started",
+                            "started",
+                            "finished",
+                            "active"
+                        ],
+                        "type": "string",
+                        "description": "status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "all",
+                            "personal"
+                        ],
+                        "type": "string",
+                        "description": "type",
+                        "name": "type",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_tournaments.Tournament"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/trades/offers": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Входящие и исходящие предложения обмена от новых к старым",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trades"
+                ],
+                "summary": "Предложения обмена пользователя",
+                "parameters": [
+                    {
+                        "enum": [
+                            "incoming",
+                            "outgoing"
+                        ],
+                        "type": "string",
+                        "description": "Ящик",
+                        "name": "box",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "accepted",
+                            "declined",
+                            "countered",
+                            "expired"
+                        ],
+                        "type": "string",
+                        "description": "Статус",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Размер выборки, по умолчанию 50, максимум 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.TradeOffer"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Предложить свои карточки и монеты в обмен на карточки другого пользователя. Все карточки должны быть распакованы и не стоять в составе незавершенного турнира",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trades"
+                ],
+                "summary": "Предложение обмена",
+                "parameters": [
+                    {
+                        "description": "Входные параметры",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.TradeOfferInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.TradeOffer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/trades/offers/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Предложение доступно только его отправителю и получателю",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trades"
+                ],
+                "summary": "Предложение обмена",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id предложения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.TradeOffer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/trades/offers/{id}/accept": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Владельцы всех карточек проверяются заново, монеты и карточки переходят сторонам в одной транзакции. Карточки обмена снимаются с продажи на рынке",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trades"
+                ],
+                "summary": "Принятие предложения обмена",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id предложения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор с тем же ключом вернет сохраненный ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.TradeOffer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/trades/offers/{id}/counter": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Закрывает входящее предложение и отправляет его автору новое. В новом предложении offeredCards - ваши карточки, requestedCards - карточки автора исходного предложения",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trades"
+                ],
+                "summary": "Встречное предложение обмена",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id предложения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Входные параметры",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.CounterTradeOfferInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.TradeOffer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/trades/offers/{id}/decline": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Отклонить можно только действующее входящее предложение",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trades"
+                ],
+                "summary": "Отклонение предложения обмена",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id предложения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.TradeOffer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/2fa/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Подтверждение подключения 2FA первым кодом из приложения. Возвращает одноразовые коды восстановления",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Включение 2FA",
+                "parameters": [
+                    {
+                        "description": "Входные параметры",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.TwoFactorCodeInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.RecoveryCodes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/2fa/disable": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Отключение 2FA. Требуется TOTP код или код восстановления",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Отключение 2FA",
+                "parameters": [
+                    {
+                        "description": "Входные параметры",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.TwoFactorCodeInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.StatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/2fa/enroll": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Генерация секрета TOTP и otpauth URI для QR кода. 2FA включится после подтверждения первым кодом",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Подключение 2FA",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.TwoFactorEnrollment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/2fa/recovery-codes": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Генерация нового набора кодов восстановления, старые коды перестают действовать. Требуется TOTP код",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Новые коды восстановления 2FA",
+                "parameters": [
+                    {
+                        "description": "Входные параметры",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.TwoFactorCodeInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.RecoveryCodes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/avatar": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Загрузка аватара (JPEG, PNG или GIF). Изображение обрезается до квадрата и сохраняется в нескольких размерах",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Загрузка аватара",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Изображение",
+                        "name": "avatar",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.Avatar"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Удаление профиля пользователя. Все сессии завершаются, аккаунт можно восстановить входом в течение срока из конфига",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Удаление профиля",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.StatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/email/change": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Отправка кода подтверждения на новый email. Требуется текущий пароль",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Запрос смены email",
+                "parameters": [
+                    {
+                        "description": "Входные параметры",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.ChangeEmailInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.StatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/email/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Смена email по коду из письма. Все сессии пользователя завершаются, на старый email уходит ссылка для отмены",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Подтверждение смены email",
+                "parameters": [
+                    {
+                        "description": "Входные параметры",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.ConfirmEmailChangeInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.StatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/email/undo": {
+            "post": {
+                "description": "Возврат прежнего email по ссылке из письма, отправленного на старый адрес. Все сессии пользователя завершаются",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Отмена смены email",
+                "parameters": [
+                    {
+                        "description": "Входные параметры",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.UndoEmailChangeInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.StatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/exists": {
+            "get": {
+                "description": "Существует ли уже пользователь с таким email или nickname. Код 200: пользователь с такими данными уже существует, код 404: пользователь с такими данными не найден.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Существует ли пользователь с указанными параметрами",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "test@test.test",
+                        "description": "Email пользователя",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "Qwerty1",
+                        "description": "Nickname пользователя",
+                        "name": "nickname",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.StatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/exports": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Ставит в очередь сборку архива со всеми данными пользователя. Когда архив готов, на почту приходит ссылка для скачивания",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Запрос выгрузки персональных данных",
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.DataExport"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/exports/download": {
+            "get": {
+                "description": "Отдает zip архив по подписанной ссылке из письма или из статуса выгрузки",
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Скачивание выгрузки персональных данных",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID выгрузки",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Срок действия ссылки",
+                        "name": "expires",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Подпись",
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/exports/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Статус выгрузки. Для готовой выгрузки возвращается ссылка на скачивание с ограниченным сроком действия",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Статус выгрузки персональных данных",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID выгрузки",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.DataExport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/info": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Получение пользовательской информации по access токену",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Получение информации о пользователе",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.UserInfoModel"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/password/change": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Смена пароля",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Смена пароля",
+                "parameters": [
+                    {
+                        "description": "Входные параметры",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.ChangePasswordInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.StatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/password/forgot": {
+            "post": {
+                "description": "Отправка письма с информацией о сбросе пароля",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Забыли пароль",
+                "parameters": [
+                    {
+                        "description": "Входные параметры",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.EmailInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.StatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/password/reset": {
+            "patch": {
+                "description": "Восстановление пароля",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Восстановление пароля",
+                "parameters": [
+                    {
+                        "description": "Входные параметры",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.ResetPasswordInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.StatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/preferences": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Получение подписок пользователя на категории писем",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Настройки рассылок",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.NotificationPreferences"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Сохранение подписок пользователя на категории писем",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Изменение настроек рассылок",
+                "parameters": [
+                    {
+                        "description": "Входные параметры",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.NotificationPreferencesInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.StatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/profile": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Смена никнейма. Никнейм можно менять не чаще, чем раз в период из конфига",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Редактирование профиля",
+                "parameters": [
+                    {
+                        "description": "Входные параметры",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.UpdateProfileInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.StatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Получение списка устройств, на которых выполнен вход в аккаунт",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Получение активных сессий пользователя",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.SessionInfo"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Завершение всех сессий пользователя, включая текущую",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Выход на всех устройствах",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.StatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/sessions/revoke-others": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Завершение сессий на всех устройствах, кроме текущего. Текущая сессия определяется по refresh токену",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Завершение всех сессий, кроме текущей",
+                "parameters": [
+                    {
+                        "description": "Входные параметры",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.RefreshInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.StatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/sessions/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Завершение сессии на выбранном устройстве",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Завершение сессии",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id сессии",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.StatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/transactions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Страница истории транзакций от новых к старым и сводка по фильтру. Следующая страница запрашивается\nс cursor из nextCursor. С format=csv или заголовком Accept: text/csv отдается выписка по всему фильтру",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Получение истории транзакций пользователя",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 20,
+                        "description": "Размер страницы, до 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2024-06-01T00:00:00Z",
+                        "description": "Начало периода, RFC3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2024-07-01T00:00:00Z",
+                        "description": "Конец периода, не включается, RFC3339",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "credit",
+                            "debit"
+                        ],
+                        "type": "string",
+                        "description": "Направление: credit - начисления, debit - списания",
+                        "name": "sign",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "purchase",
+                            "entry_fee",
+                            "prize",
+                            "refund",
+                            "grant",
+                            "adjustment",
+                            "marketplace_sale",
+                            "marketplace_fee",
+                            "trade"
+                        ],
+                        "type": "string",
+                        "description": "Тип транзакции",
+                        "name": "reason",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "description": "Формат ответа",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.CoinTransactionsPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/unsubscribe": {
+            "get": {
+                "description": "Страница подтверждения по ссылке из письма. Отписка выполняется только отправкой формы",
+                "produces": [
+                    "text/html"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Страница отписки от рассылки",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Токен из ссылки",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Отписка от категории писем без входа в аккаунт: форма со страницы отписки или почтовый клиент в один клик (RFC 8058)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Отписка от рассылки",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Токен из ссылки",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.StatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/pkg_api.Error"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.CardConversion": {
+            "type": "object",
+            "properties": {
+                "consumedCards": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "dustAmount": {
+                    "type": "integer"
+                },
+                "dustBalanceAfter": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "producedCardID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.CardListing": {
+            "type": "object",
+            "properties": {
+                "buyerID": {
+                    "type": "string"
+                },
+                "cardID": {
+                    "type": "integer"
+                },
+                "closedAt": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "fee": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "sellerID": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.CardListingResponse": {
+            "type": "object",
+            "properties": {
+                "buyerID": {
+                    "type": "string"
+                },
+                "cardID": {
+                    "type": "integer"
+                },
+                "closedAt": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "fee": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "league": {
+                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_tournaments.League"
+                },
+                "leagueName": {
+                    "type": "string"
+                },
+                "multiply": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "photo": {
+                    "type": "string"
+                },
+                "playerID": {
+                    "type": "integer"
+                },
+                "position": {
+                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.Position"
+                },
+                "positionName": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "rarity": {
+                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.CardRarity"
+                },
+                "rarityName": {
+                    "type": "string"
+                },
+                "sellerID": {
+                    "type": "string"
+                },
+                "sellerNickname": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "teamID": {
+                    "type": "integer"
+                },
+                "teamName": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.CounterTradeOfferInput": {
+            "type": "object",
+            "properties": {
+                "coins": {
+                    "type": "integer",
+                    "maximum": 1000000,
+                    "minimum": 0
+                },
+                "offeredCards": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "requestedCards": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.CraftCardInput": {
+            "type": "object",
+            "required": [
+                "playerID",
+                "rarity"
+            ],
+            "properties": {
+                "playerID": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "rarity": {
+                    "enum": [
+                        1,
+                        2
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.CardRarity"
+                        }
+                    ]
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.CraftingInfo": {
+            "type": "object",
+            "properties": {
+                "craftPrice": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.RarityDust"
+                    }
+                },
+                "dismantleDust": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.RarityDust"
+                    }
+                },
+                "dust": {
+                    "type": "integer"
+                },
+                "upgradeCards": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.CreateListingInput": {
+            "type": "object",
+            "required": [
+                "cardID",
+                "price"
+            ],
+            "properties": {
+                "cardID": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "price": {
+                    "type": "integer",
+                    "maximum": 1000000,
+                    "minimum": 1
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.DismantleCardsInput": {
+            "type": "object",
+            "required": [
+                "cardIDs"
+            ],
+            "properties": {
+                "cardIDs": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "minItems": 1,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.FullPlayerStatInfo": {
+            "type": "object",
+            "properties": {
+                "assists": {
+                    "type": "integer"
+                },
+                "fantasyPoint": {
+                    "type": "number"
+                },
+                "gameDate": {
+                    "type": "string"
+                },
+                "goals": {
+                    "type": "integer"
+                },
+                "hits": {
+                    "type": "integer"
+                },
+                "missedGoals": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "opponent": {
+                    "type": "string"
+                },
+                "photo": {
+                    "type": "string"
+                },
+                "pims": {
+                    "type": "integer"
+                },
+                "playerID": {
+                    "type": "integer"
+                },
+                "position": {
+                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.Position"
+                },
+                "positionName": {
+                    "type": "string"
+                },
+                "rarity": {
+                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.CardRarity"
+                },
+                "rarityName": {
+                    "type": "string"
+                },
+                "saves": {
+                    "type": "integer"
+                },
+                "shots": {
+                    "type": "integer"
+                },
+                "shutout": {
+                    "type": "boolean"
+                },
+                "teamLogo": {
+                    "type": "string"
+                },
+                "teamName": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.PlayerCardResponse": {
+            "type": "object",
+            "properties": {
+                "bonusMetric": {
+                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.BonusMetric"
+                },
+                "bonusMetricName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "league": {
+                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_tournaments.League"
+                },
+                "leagueName": {
+                    "type": "string"
+                },
+                "multiply": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "packOpeningID": {
+                    "type": "integer"
+                },
+                "photo": {
+                    "type": "string"
+                },
+                "playerID": {
+                    "type": "integer"
+                },
+                "position": {
+                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.Position"
+                },
+                "positionName": {
+                    "type": "string"
+                },
+                "profileID": {
+                    "type": "string"
+                },
+                "rarity": {
+                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.CardRarity"
+                },
+                "rarityName": {
+                    "type": "string"
+                },
+                "sweaterNumber": {
+                    "type": "integer"
+                },
+                "teamID": {
+                    "type": "integer"
+                },
+                "teamLogo": {
+                    "type": "string"
+                },
+                "teamName": {
+                    "type": "string"
+                },
+                "unpacked": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.PlayerResponse": {
+            "type": "object",
+            "properties": {
+                "avgFantasyPoints": {
+                    "type": "number"
+                },
+                "cardRarity": {
+                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.CardRarity"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "league": {
+                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_tournaments.League"
+                },
+                "leagueName": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "photo": {
+                    "type": "string"
+                },
+                "playerCost": {
+                    "type": "number"
+                },
+                "position": {
+                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.Position"
+                },
+                "positionName": {
+                    "type": "string"
+                },
+                "rarityName": {
+                    "type": "string",
+                    "default": "Default"
+                },
+                "sweaterNumber": {
+                    "type": "integer"
+                },
+                "teamID": {
+                    "type": "integer"
+                },
+                "teamLogo": {
+                    "type": "string"
+                },
+                "teamName": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.PlayersStatisticDB": {
+            "type": "object",
+            "properties": {
+                "assists": {
+                    "type": "integer"
+                },
+                "fantasyPoint": {
+                    "type": "number"
+                },
+                "gameDate": {
+                    "type": "string"
+                },
+                "goals": {
+                    "type": "integer"
+                },
+                "hits": {
+                    "type": "integer"
+                },
+                "league": {
+                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_tournaments.League"
+                },
+                "matchIdLocal": {
+                    "type": "integer"
+                },
+                "missedGoals": {
+                    "type": "integer"
+                },
+                "opponent": {
+                    "type": "string"
+                },
+                "pims": {
+                    "type": "integer"
+                },
+                "playerIdNhl": {
+                    "type": "integer"
+                },
+                "saves": {
+                    "type": "integer"
+                },
+                "shots": {
+                    "type": "integer"
+                },
+                "shutout": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.Position": {
+            "type": "integer",
+            "enum": [
+                0,
+                1,
+                2,
+                3
+            ],
+            "x-enum-varnames": [
+                "ErrPlayerPosition",
+                "Goalie",
+                "Defensemen",
+                "Forward"
+            ]
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.PositionData": {
+            "type": "object",
+            "properties": {
+                "positionAbbrev": {
+                    "type": "string"
+                },
+                "positionName": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.RarityDust": {
+            "type": "object",
+            "properties": {
+                "dust": {
+                    "type": "integer"
+                },
+                "rarity": {
+                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.CardRarity"
+                },
+                "rarityName": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.TeamData": {
+            "type": "object",
+            "properties": {
+                "teamAbbrev": {
+                    "type": "string"
+                },
+                "teamID": {
+                    "type": "integer"
+                },
+                "teamName": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.TournamentResults": {
+            "type": "object",
+            "properties": {
+                "coins": {
+                    "type": "integer"
+                },
+                "fantasyPoints": {
+                    "type": "number"
+                },
+                "nickname": {
+                    "type": "string"
+                },
+                "place": {
+                    "type": "integer"
+                },
+                "profileID": {
+                    "type": "string"
+                },
+                "userPhoto": {
+                    "type": "string"
+                },
+                "userTeam": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.FullPlayerStatInfo"
+                    }
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.TournamentRosterResponse": {
+            "type": "object",
+            "properties": {
+                "players": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.PlayerResponse"
+                    }
+                },
+                "positions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.PositionData"
+                    }
+                },
+                "teams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.TeamData"
+                    }
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.TradeOffer": {
+            "type": "object",
+            "properties": {
+                "closedAt": {
+                    "type": "string"
+                },
+                "coins": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "offeredCards": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "parentID": {
+                    "type": "integer"
+                },
+                "proposerID": {
+                    "type": "string"
+                },
+                "proposerNickname": {
+                    "type": "string"
+                },
+                "recipientID": {
+                    "type": "string"
+                },
+                "recipientNickname": {
+                    "type": "string"
+                },
+                "requestedCards": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.TradeOfferInput": {
+            "type": "object",
+            "required": [
+                "recipientID"
+            ],
+            "properties": {
+                "coins": {
+                    "type": "integer",
+                    "maximum": 1000000,
+                    "minimum": 0
+                },
+                "offeredCards": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "recipientID": {
+                    "type": "string"
+                },
+                "requestedCards": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.UpgradeCardsInput": {
+            "type": "object",
+            "required": [
+                "cardIDs"
+            ],
+            "properties": {
+                "cardIDs": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "minItems": 2,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.UserTeamResponse": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "number"
+                },
+                "players": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.PlayerResponse"
+                    }
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.AdminProduct": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "archivedAt": {
+                    "type": "string"
+                },
+                "availableFrom": {
+                    "type": "string"
+                },
+                "availableUntil": {
+                    "type": "string"
+                },
+                "dropTable": {
+                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.DropTable"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "league": {
+                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_tournaments.League"
+                },
+                "leagueName": {
+                    "type": "string"
+                },
+                "photoLink": {
+                    "type": "string"
+                },
+                "playerCardsCount": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "productName": {
+                    "type": "string"
+                },
+                "rarity": {
+                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.CardRarity"
+                },
+                "rarityName": {
+                    "type": "string"
+                },
+                "sortOrder": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.BonusMetric": {
+            "type": "integer",
+            "enum": [
+                0,
+                1,
+                2,
+                3
+            ],
+            "x-enum-varnames": [
+                "ErrPlayerMetric",
+                "GoalieMetric",
+                "DefensemenMetric",
+                "ForwardMetric"
+            ]
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.CardRarity": {
+            "type": "integer",
+            "enum": [
+                0,
+                1,
+                2
+            ],
+            "x-enum-varnames": [
+                "ErrCardRarity",
+                "Silver",
+                "Gold"
+            ]
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.DropTable": {
+            "type": "object",
+            "required": [
+                "rarityWeights"
+            ],
+            "properties": {
+                "guarantees": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.RarityGuarantee"
+                    }
+                },
+                "positionQuotas": {
+                    "type": "array",
+                    "maxItems": 3,
+                    "items": {
+                        "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.PositionQuota"
+                    }
+                },
+                "rarityWeights": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.RarityWeight"
+                    }
+                },
+                "starChance": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "starCost": {
+                    "type": "number",
+                    "minimum": 0
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.PackCard": {
+            "type": "object",
+            "properties": {
+                "playerID": {
+                    "type": "integer"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "rarity": {
+                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.CardRarity"
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.PackSeed": {
+            "type": "object",
+            "properties": {
+                "clientSeed": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "nonce": {
+                    "type": "integer"
+                },
+                "revealedAt": {
+                    "type": "string"
+                },
+                "serverSeedHash": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.PackSeedRotation": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.PackSeed"
+                },
+                "previous": {
+                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.RevealedPackSeed"
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.PackVerification": {
+            "type": "object",
+            "properties": {
+                "cards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.PackCard"
+                    }
                 },
-                "opponent": {
+                "clientSeed": {
                     "type": "string"
                 },
-                "photo": {
-                    "type": "string"
+                "derivedCards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.PackCard"
+                    }
                 },
-                "pims": {
-                    "type": "integer"
+                "dropTable": {
+                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.DropTable"
                 },
-                "playerID": {
+                "nonce": {
                     "type": "integer"
                 },
-                "position": {
-                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.Position"
-                },
-                "positionName": {
-                    "type": "string"
-                },
-                "rarity": {
-                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.CardRarity"
-                },
-                "rarityName": {
+                "openedAt": {
                     "type": "string"
                 },
-                "saves": {
+                "openingID": {
                     "type": "integer"
                 },
-                "shots": {
+                "productID": {
                     "type": "integer"
                 },
-                "shutout": {
-                    "type": "boolean"
+                "seed": {
+                    "type": "string"
                 },
-                "teamLogo": {
+                "serverSeed": {
                     "type": "string"
                 },
-                "teamName": {
+                "serverSeedHash": {
                     "type": "string"
+                },
+                "verified": {
+                    "type": "boolean"
                 }
             }
         },
-        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.PlayerCardResponse": {
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.PositionQuota": {
             "type": "object",
+            "required": [
+                "count",
+                "position"
+            ],
             "properties": {
-                "bonusMetric": {
-                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.BonusMetric"
-                },
-                "bonusMetricName": {
-                    "type": "string"
+                "count": {
+                    "type": "integer",
+                    "minimum": 1
                 },
+                "position": {
+                    "type": "integer",
+                    "maximum": 3,
+                    "minimum": 1
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.Product": {
+            "type": "object",
+            "properties": {
                 "id": {
                     "type": "integer"
                 },
@@ -1727,25 +6133,16 @@ const docTemplate = `{
                 "leagueName": {
                     "type": "string"
                 },
-                "multiply": {
-                    "type": "number"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "photo": {
+                "photoLink": {
                     "type": "string"
                 },
-                "playerID": {
+                "playerCardsCount": {
                     "type": "integer"
                 },
-                "position": {
-                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.Position"
-                },
-                "positionName": {
-                    "type": "string"
+                "price": {
+                    "type": "integer"
                 },
-                "profileID": {
+                "productName": {
                     "type": "string"
                 },
                 "rarity": {
@@ -1753,492 +6150,682 @@ const docTemplate = `{
                 },
                 "rarityName": {
                     "type": "string"
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.ProductInput": {
+            "type": "object",
+            "required": [
+                "active",
+                "league",
+                "playerCardsCount",
+                "price",
+                "productName",
+                "rarity"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean"
                 },
-                "sweaterNumber": {
-                    "type": "integer"
-                },
-                "teamID": {
-                    "type": "integer"
-                },
-                "teamLogo": {
+                "availableFrom": {
                     "type": "string"
                 },
-                "teamName": {
+                "availableUntil": {
                     "type": "string"
                 },
-                "unpacked": {
-                    "type": "boolean"
+                "league": {
+                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_tournaments.League"
+                },
+                "playerCardsCount": {
+                    "type": "integer",
+                    "maximum": 20,
+                    "minimum": 1
+                },
+                "price": {
+                    "type": "integer",
+                    "maximum": 1000000,
+                    "minimum": 1
+                },
+                "productName": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                },
+                "rarity": {
+                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.CardRarity"
+                },
+                "sortOrder": {
+                    "description": "SortOrder 0 - при создании товар встает в конец витрины, при изменении порядок не меняется",
+                    "type": "integer"
                 }
             }
         },
-        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.PlayerResponse": {
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.ProductOdds": {
             "type": "object",
             "properties": {
-                "avgFantasyPoints": {
-                    "type": "number"
-                },
-                "cardRarity": {
-                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.CardRarity"
+                "guarantees": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.RarityGuarantee"
+                    }
                 },
-                "id": {
+                "playerCardsCount": {
                     "type": "integer"
                 },
-                "league": {
-                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_tournaments.League"
+                "positionQuotas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.PositionQuota"
+                    }
                 },
-                "leagueName": {
-                    "type": "string"
+                "productID": {
+                    "type": "integer"
                 },
-                "name": {
-                    "type": "string"
+                "rarities": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.RarityOdds"
+                    }
                 },
-                "photo": {
-                    "type": "string"
+                "starChance": {
+                    "type": "integer"
                 },
-                "playerCost": {
+                "starCost": {
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.RarityGuarantee": {
+            "type": "object",
+            "required": [
+                "count",
+                "rarity"
+            ],
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "rarity": {
+                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.CardRarity"
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.RarityOdds": {
+            "type": "object",
+            "properties": {
+                "chance": {
                     "type": "number"
                 },
-                "position": {
-                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.Position"
+                "rarity": {
+                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.CardRarity"
                 },
-                "positionName": {
+                "rarityName": {
                     "type": "string"
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.RarityWeight": {
+            "type": "object",
+            "required": [
+                "rarity",
+                "weight"
+            ],
+            "properties": {
+                "rarity": {
+                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.CardRarity"
                 },
-                "rarityName": {
-                    "type": "string",
-                    "default": "Default"
+                "weight": {
+                    "type": "integer",
+                    "maximum": 10000,
+                    "minimum": 1
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.ReorderProductsInput": {
+            "type": "object",
+            "required": [
+                "ids"
+            ],
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "maxItems": 500,
+                    "minItems": 1,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.RevealedPackSeed": {
+            "type": "object",
+            "properties": {
+                "clientSeed": {
+                    "type": "string"
                 },
-                "sweaterNumber": {
+                "nonce": {
                     "type": "integer"
                 },
-                "teamID": {
-                    "type": "integer"
+                "revealedAt": {
+                    "type": "string"
                 },
-                "teamLogo": {
+                "serverSeed": {
                     "type": "string"
                 },
-                "teamName": {
+                "serverSeedHash": {
                     "type": "string"
                 }
             }
         },
-        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.PlayersStatisticDB": {
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.RotatePackSeedInput": {
             "type": "object",
+            "required": [
+                "clientSeed"
+            ],
             "properties": {
-                "assists": {
+                "clientSeed": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 1
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_tournaments.GetMatchesByTourId": {
+            "type": "object",
+            "properties": {
+                "awayScore": {
                     "type": "integer"
                 },
-                "fantasyPoint": {
-                    "type": "number"
-                },
-                "gameDate": {
+                "awayTeamAbbrev": {
                     "type": "string"
                 },
-                "goals": {
+                "awayTeamId": {
                     "type": "integer"
                 },
-                "hits": {
-                    "type": "integer"
+                "awayTeamLogo": {
+                    "type": "string"
                 },
-                "league": {
-                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_tournaments.League"
+                "endAt": {
+                    "type": "string"
                 },
-                "matchIdLocal": {
+                "eventId": {
                     "type": "integer"
                 },
-                "missedGoals": {
+                "homeScore": {
                     "type": "integer"
                 },
-                "opponent": {
+                "homeTeamAbbrev": {
                     "type": "string"
                 },
-                "pims": {
+                "homeTeamId": {
                     "type": "integer"
                 },
-                "playerIdNhl": {
-                    "type": "integer"
+                "homeTeamLogo": {
+                    "type": "string"
                 },
-                "saves": {
-                    "type": "integer"
+                "league": {
+                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_tournaments.League"
                 },
-                "shots": {
+                "matchId": {
                     "type": "integer"
                 },
-                "shutout": {
-                    "type": "boolean"
+                "startAt": {
+                    "type": "string"
+                },
+                "statusEvent": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.Position": {
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_tournaments.League": {
             "type": "integer",
             "enum": [
                 0,
                 1,
-                2,
-                3
+                2
             ],
             "x-enum-varnames": [
-                "ErrPlayerPosition",
-                "Goalie",
-                "Defensemen",
-                "Forward"
-            ]
-        },
-        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.PositionData": {
-            "type": "object",
-            "properties": {
-                "positionAbbrev": {
-                    "type": "string"
-                },
-                "positionName": {
-                    "type": "string"
-                }
-            }
+                "ErrLeague",
+                "NHL",
+                "KHL"
+            ]
         },
-        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.TeamData": {
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_tournaments.Matches": {
             "type": "object",
             "properties": {
-                "teamAbbrev": {
-                    "type": "string"
+                "awayScore": {
+                    "type": "integer"
                 },
-                "teamID": {
+                "awayTeamId": {
                     "type": "integer"
                 },
-                "teamName": {
+                "endAt": {
+                    "type": "integer"
+                },
+                "eventId": {
+                    "type": "integer"
+                },
+                "homeScore": {
+                    "type": "integer"
+                },
+                "homeTeamId": {
+                    "type": "integer"
+                },
+                "league": {
+                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_tournaments.League"
+                },
+                "matchId": {
+                    "type": "integer"
+                },
+                "startAt": {
+                    "type": "integer"
+                },
+                "statusEvent": {
                     "type": "string"
                 }
             }
         },
-        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.TournamentResults": {
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_tournaments.Tournament": {
             "type": "object",
             "properties": {
-                "coins": {
+                "deposit": {
                     "type": "integer"
                 },
-                "fantasyPoints": {
-                    "type": "number"
+                "league": {
+                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_tournaments.League"
                 },
-                "nickname": {
+                "matchesIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "playersAmount": {
+                    "type": "integer"
+                },
+                "prizeFond": {
+                    "type": "integer"
+                },
+                "statusParticipation": {
+                    "type": "boolean"
+                },
+                "statusTournament": {
                     "type": "string"
                 },
-                "place": {
+                "timeEnd": {
                     "type": "integer"
                 },
-                "profileID": {
+                "timeEndTS": {
                     "type": "string"
                 },
-                "userPhoto": {
+                "timeStart": {
+                    "type": "integer"
+                },
+                "timeStartTS": {
                     "type": "string"
                 },
-                "userTeam": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.FullPlayerStatInfo"
-                    }
+                "title": {
+                    "type": "string"
+                },
+                "tournamentId": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.TournamentRosterResponse": {
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_tournaments.UserTeamInput": {
             "type": "object",
             "properties": {
-                "players": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.PlayerResponse"
-                    }
-                },
-                "positions": {
+                "team": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.PositionData"
+                        "type": "integer"
                     }
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.Avatar": {
+            "type": "object",
+            "properties": {
+                "photoLink": {
+                    "type": "string"
                 },
-                "teams": {
+                "sizes": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.TeamData"
+                        "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.AvatarSize"
                     }
                 }
             }
         },
-        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.UserTeamResponse": {
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.AvatarSize": {
             "type": "object",
             "properties": {
-                "balance": {
-                    "type": "number"
+                "size": {
+                    "type": "integer"
                 },
-                "players": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_players.PlayerResponse"
-                    }
+                "url": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.BonusMetric": {
-            "type": "integer",
-            "enum": [
-                0,
-                1,
-                2,
-                3
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.ChangeEmailInput": {
+            "type": "object",
+            "required": [
+                "newEmail",
+                "password"
             ],
-            "x-enum-varnames": [
-                "ErrPlayerMetric",
-                "GoalieMetric",
-                "DefensemenMetric",
-                "ForwardMetric"
-            ]
+            "properties": {
+                "newEmail": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 8
+                }
+            }
         },
-        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.CardRarity": {
-            "type": "integer",
-            "enum": [
-                0,
-                1,
-                2
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.ChangePasswordInput": {
+            "type": "object",
+            "required": [
+                "newPassword",
+                "oldPassword"
             ],
-            "x-enum-varnames": [
-                "ErrCardRarity",
-                "Silver",
-                "Gold"
-            ]
+            "properties": {
+                "newPassword": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 8
+                },
+                "oldPassword": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 8
+                }
+            }
         },
-        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.Product": {
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.CoinAdjustment": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "integer"
-                },
-                "league": {
-                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_tournaments.League"
-                },
-                "leagueName": {
-                    "type": "string"
-                },
-                "photoLink": {
+                "adminID": {
                     "type": "string"
                 },
-                "playerCardsCount": {
+                "amount": {
                     "type": "integer"
                 },
-                "price": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "integer"
                 },
-                "productName": {
+                "profileID": {
                     "type": "string"
                 },
-                "rarity": {
-                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_store.CardRarity"
-                },
-                "rarityName": {
+                "reason": {
                     "type": "string"
                 }
             }
         },
-        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_tournaments.GetMatchesByTourId": {
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.CoinAdjustmentInput": {
             "type": "object",
+            "required": [
+                "amount",
+                "profileID",
+                "reason"
+            ],
             "properties": {
-                "awayScore": {
-                    "type": "integer"
+                "amount": {
+                    "type": "integer",
+                    "maximum": 1000000,
+                    "minimum": 1
                 },
-                "awayTeamAbbrev": {
+                "profileID": {
                     "type": "string"
                 },
-                "awayTeamId": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "minLength": 3
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.CoinTransaction": {
+            "type": "object",
+            "properties": {
+                "amount": {
                     "type": "integer"
                 },
-                "awayTeamLogo": {
-                    "type": "string"
-                },
-                "endAt": {
-                    "type": "string"
-                },
-                "eventId": {
+                "balanceAfter": {
                     "type": "integer"
                 },
-                "homeScore": {
-                    "type": "integer"
+                "counterpartyKind": {
+                    "type": "string"
                 },
-                "homeTeamAbbrev": {
+                "createdAt": {
                     "type": "string"
                 },
-                "homeTeamId": {
+                "id": {
                     "type": "integer"
                 },
-                "homeTeamLogo": {
+                "reason": {
                     "type": "string"
                 },
-                "league": {
-                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_tournaments.League"
-                },
-                "matchId": {
-                    "type": "integer"
-                },
-                "startAt": {
+                "referenceID": {
                     "type": "string"
                 },
-                "statusEvent": {
+                "referenceType": {
                     "type": "string"
                 }
             }
         },
-        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_tournaments.League": {
-            "type": "integer",
-            "enum": [
-                0,
-                1,
-                2
-            ],
-            "x-enum-varnames": [
-                "ErrLeague",
-                "NHL",
-                "KHL"
-            ]
-        },
-        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_tournaments.Matches": {
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.CoinTransactionsPage": {
             "type": "object",
             "properties": {
-                "awayScore": {
-                    "type": "integer"
+                "nextCursor": {
+                    "type": "string"
                 },
-                "awayTeamId": {
-                    "type": "integer"
+                "summary": {
+                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.CoinTransactionsSummary"
                 },
-                "endAt": {
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.CoinTransaction"
+                    }
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.CoinTransactionsSummary": {
+            "type": "object",
+            "properties": {
+                "totalSpent": {
                     "type": "integer"
                 },
-                "eventId": {
+                "totalWon": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.ConfirmEmailChangeInput": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
                     "type": "integer"
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.DataExport": {
+            "type": "object",
+            "properties": {
+                "completedAt": {
+                    "type": "string"
                 },
-                "homeScore": {
-                    "type": "integer"
+                "createdAt": {
+                    "type": "string"
                 },
-                "homeTeamId": {
-                    "type": "integer"
+                "downloadURL": {
+                    "type": "string"
                 },
-                "league": {
-                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_tournaments.League"
+                "expiresAt": {
+                    "type": "string"
                 },
-                "matchId": {
-                    "type": "integer"
+                "id": {
+                    "type": "string"
                 },
-                "startAt": {
+                "size": {
                     "type": "integer"
                 },
-                "statusEvent": {
+                "status": {
                     "type": "string"
                 }
             }
         },
-        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_tournaments.Tournament": {
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.EmailInput": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 64
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.NotificationPreferences": {
             "type": "object",
             "properties": {
-                "deposit": {
-                    "type": "integer"
+                "securityAlerts": {
+                    "type": "boolean"
                 },
-                "league": {
-                    "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_tournaments.League"
+                "storeOffers": {
+                    "type": "boolean"
                 },
-                "matchesIds": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "tournamentReminders": {
+                    "type": "boolean"
                 },
-                "playersAmount": {
-                    "type": "integer"
+                "tournamentResults": {
+                    "type": "boolean"
                 },
-                "prizeFond": {
-                    "type": "integer"
+                "tradeOffers": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.NotificationPreferencesInput": {
+            "type": "object",
+            "required": [
+                "securityAlerts",
+                "storeOffers",
+                "tournamentReminders",
+                "tournamentResults"
+            ],
+            "properties": {
+                "securityAlerts": {
+                    "type": "boolean"
                 },
-                "statusParticipation": {
+                "storeOffers": {
                     "type": "boolean"
                 },
-                "statusTournament": {
-                    "type": "string"
+                "tournamentReminders": {
+                    "type": "boolean"
                 },
-                "timeEnd": {
+                "tournamentResults": {
+                    "type": "boolean"
+                },
+                "tradeOffers": {
+                    "description": "TradeOffers не обязателен: если не передан, настройка не меняется",
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.ReconciliationDiscrepancy": {
+            "type": "object",
+            "properties": {
+                "actual": {
                     "type": "integer"
                 },
-                "timeEndTS": {
-                    "type": "string"
+                "expected": {
+                    "type": "integer"
                 },
-                "timeStart": {
+                "id": {
                     "type": "integer"
                 },
-                "timeStartTS": {
+                "kind": {
                     "type": "string"
                 },
-                "title": {
+                "profileID": {
                     "type": "string"
                 },
-                "tournamentId": {
+                "tournamentID": {
+                    "type": "integer"
+                },
+                "transactionID": {
                     "type": "integer"
                 }
             }
         },
-        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_tournaments.UserTeamInput": {
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.ReconciliationReport": {
             "type": "object",
             "properties": {
-                "team": {
+                "checkedProfiles": {
+                    "type": "integer"
+                },
+                "discrepancies": {
+                    "type": "integer"
+                },
+                "finishedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "items": {
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.ReconciliationDiscrepancy"
                     }
-                }
-            }
-        },
-        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.ChangePasswordInput": {
-            "type": "object",
-            "required": [
-                "newPassword",
-                "oldPassword"
-            ],
-            "properties": {
-                "newPassword": {
-                    "type": "string",
-                    "maxLength": 64,
-                    "minLength": 8
                 },
-                "oldPassword": {
-                    "type": "string",
-                    "maxLength": 64,
-                    "minLength": 8
+                "startedAt": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.CoinTransactionsModel": {
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.ReconciliationRun": {
             "type": "object",
             "properties": {
-                "amount": {
+                "checkedProfiles": {
                     "type": "integer"
                 },
-                "id": {
+                "discrepancies": {
                     "type": "integer"
                 },
-                "profileID": {
-                    "type": "string"
-                },
-                "status": {
+                "finishedAt": {
                     "type": "string"
                 },
-                "transactionDate": {
-                    "type": "string"
+                "id": {
+                    "type": "integer"
                 },
-                "transactionDetails": {
+                "startedAt": {
                     "type": "string"
                 }
             }
         },
-        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.EmailInput": {
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.RecoveryCodes": {
             "type": "object",
-            "required": [
-                "email"
-            ],
             "properties": {
-                "email": {
-                    "type": "string",
-                    "maxLength": 64
+                "codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
@@ -2274,6 +6861,50 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.RoleInput": {
+            "type": "object",
+            "required": [
+                "profileID",
+                "role"
+            ],
+            "properties": {
+                "profileID": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "admin"
+                    ]
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.SessionInfo": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "device": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "lastUsedAt": {
+                    "type": "string"
+                },
+                "userAgent": {
+                    "type": "string"
+                }
+            }
+        },
         "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.SignInInput": {
             "type": "object",
             "required": [
@@ -2281,6 +6912,10 @@ const docTemplate = `{
                 "password"
             ],
             "properties": {
+                "device": {
+                    "type": "string",
+                    "maxLength": 100
+                },
                 "email": {
                     "type": "string",
                     "maxLength": 64
@@ -2334,6 +6969,86 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.TwoFactorChallenge": {
+            "type": "object",
+            "properties": {
+                "challenge": {
+                    "type": "string"
+                },
+                "expiresIn": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.TwoFactorCodeInput": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 10,
+                    "minLength": 6
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.TwoFactorEnrollment": {
+            "type": "object",
+            "properties": {
+                "otpauthURI": {
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.TwoFactorVerifyInput": {
+            "type": "object",
+            "required": [
+                "challenge",
+                "code"
+            ],
+            "properties": {
+                "challenge": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 64
+                },
+                "code": {
+                    "type": "string",
+                    "maxLength": 10,
+                    "minLength": 6
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.UndoEmailChangeInput": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 64
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.UpdateProfileInput": {
+            "type": "object",
+            "required": [
+                "nickname"
+            ],
+            "properties": {
+                "nickname": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 4
+                }
+            }
+        },
         "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_models_user.UserInfoModel": {
             "type": "object",
             "properties": {
@@ -2357,6 +7072,40 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_service.JWK": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "type": "string"
+                },
+                "crv": {
+                    "type": "string"
+                },
+                "kid": {
+                    "type": "string"
+                },
+                "kty": {
+                    "type": "string"
+                },
+                "use": {
+                    "type": "string"
+                },
+                "x": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_Frozen-Fantasy_fantasy-backend_git_pkg_service.JWKSet": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Frozen-Fantasy_fantasy-backend_git_pkg_service.JWK"
+                    }
+                }
+            }
+        },
         "pkg_api.Error": {
             "type": "object",
             "properties": {
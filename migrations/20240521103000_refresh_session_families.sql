@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE refresh_sessions
+    ADD COLUMN family_id  UUID,
+    ADD COLUMN rotated_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE refresh_sessions SET family_id = gen_random_uuid() WHERE family_id IS NULL;
+
+ALTER TABLE refresh_sessions
+    ALTER COLUMN family_id SET NOT NULL;
+
+CREATE UNIQUE INDEX refresh_sessions_refresh_token_id_idx ON refresh_sessions (refresh_token_id);
+CREATE INDEX refresh_sessions_family_id_idx ON refresh_sessions (family_id);
+
+CREATE TABLE security_events
+(
+    id         SERIAL PRIMARY KEY,
+    profile_id UUID REFERENCES user_profile (id) ON DELETE CASCADE,
+    event_type VARCHAR(50)              NOT NULL,
+    details    VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS security_events;
+
+DROP INDEX IF EXISTS refresh_sessions_family_id_idx;
+DROP INDEX IF EXISTS refresh_sessions_refresh_token_id_idx;
+
+ALTER TABLE refresh_sessions
+    DROP COLUMN IF EXISTS rotated_at,
+    DROP COLUMN IF EXISTS family_id;
+-- +goose StatementEnd
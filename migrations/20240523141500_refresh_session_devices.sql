@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE refresh_sessions
+    ADD COLUMN user_agent VARCHAR(512) NOT NULL DEFAULT '',
+    ADD COLUMN ip         VARCHAR(64)  NOT NULL DEFAULT '',
+    ADD COLUMN device     VARCHAR(100) NOT NULL DEFAULT '';
+
+CREATE INDEX refresh_sessions_profile_id_idx ON refresh_sessions (profile_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS refresh_sessions_profile_id_idx;
+
+ALTER TABLE refresh_sessions
+    DROP COLUMN IF EXISTS user_agent,
+    DROP COLUMN IF EXISTS ip,
+    DROP COLUMN IF EXISTS device;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- Первый администратор назначается вручную:
+-- UPDATE user_profile SET roles = array_append(roles, 'admin') WHERE id = '<profile_id>';
+-- +goose StatementBegin
+ALTER TABLE user_profile
+    ADD COLUMN roles TEXT[] NOT NULL DEFAULT '{}';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE user_profile
+    DROP COLUMN IF EXISTS roles;
+-- +goose StatementEnd
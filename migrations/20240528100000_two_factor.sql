@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE user_two_factor
+(
+    profile_id     UUID PRIMARY KEY REFERENCES user_profile (id) ON DELETE CASCADE,
+    secret         VARCHAR(64)              NOT NULL,
+    enabled        BOOLEAN                  NOT NULL DEFAULT false,
+    last_used_step BIGINT                   NOT NULL DEFAULT 0,
+    created_at     TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    confirmed_at   TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE two_factor_recovery_codes
+(
+    id         SERIAL PRIMARY KEY,
+    profile_id UUID REFERENCES user_profile (id) ON DELETE CASCADE,
+    code_hash  VARCHAR(64) NOT NULL,
+    used_at    TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX two_factor_recovery_codes_profile_id_idx ON two_factor_recovery_codes (profile_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS two_factor_recovery_codes;
+
+DROP TABLE IF EXISTS user_two_factor;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE email_outbox
+(
+    id              SERIAL PRIMARY KEY,
+    recipient       VARCHAR(255)             NOT NULL,
+    subject         VARCHAR(255)             NOT NULL,
+    body            TEXT                     NOT NULL,
+    headers         JSONB                    NOT NULL DEFAULT '{}',
+    status          VARCHAR(20)              NOT NULL DEFAULT 'pending',
+    attempts        INTEGER                  NOT NULL DEFAULT 0,
+    last_error      TEXT,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    sent_at         TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX email_outbox_pending_idx ON email_outbox (next_attempt_at) WHERE status = 'pending';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS email_outbox;
+-- +goose StatementEnd
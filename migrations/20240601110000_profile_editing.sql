@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE user_profile
+    ADD COLUMN nickname_changed_at TIMESTAMP WITH TIME ZONE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE user_profile
+    DROP COLUMN IF EXISTS nickname_changed_at;
+-- +goose StatementEnd
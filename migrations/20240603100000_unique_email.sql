@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Смена email проверяет занятость адреса и обновляет его одним запросом, поэтому уникальность держит база
+CREATE UNIQUE INDEX IF NOT EXISTS user_contacts_email_unique_idx ON user_contacts (LOWER(email));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS user_contacts_email_unique_idx;
+-- +goose StatementEnd
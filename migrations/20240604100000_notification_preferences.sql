@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE notification_preferences
+(
+    profile_id           UUID PRIMARY KEY REFERENCES user_profile (id) ON DELETE CASCADE,
+    tournament_reminders BOOLEAN                  NOT NULL DEFAULT FALSE,
+    tournament_results   BOOLEAN                  NOT NULL DEFAULT FALSE,
+    store_offers         BOOLEAN                  NOT NULL DEFAULT FALSE,
+    security_alerts      BOOLEAN                  NOT NULL DEFAULT TRUE,
+    updated_at           TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+-- Прежний общий флаг email_subscription переносится во все рекламные категории
+INSERT INTO notification_preferences (profile_id, tournament_reminders, tournament_results, store_offers)
+SELECT profile_id, COALESCE(email_subscription, FALSE), COALESCE(email_subscription, FALSE), COALESCE(email_subscription, FALSE)
+FROM user_contacts
+WHERE profile_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS notification_preferences;
+-- +goose StatementEnd
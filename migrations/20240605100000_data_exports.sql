@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE data_exports
+(
+    id           UUID PRIMARY KEY,
+    profile_id   UUID                     NOT NULL REFERENCES user_profile (id) ON DELETE CASCADE,
+    status       VARCHAR(20)              NOT NULL DEFAULT 'pending',
+    blob_key     VARCHAR(255),
+    size         BIGINT                   NOT NULL DEFAULT 0,
+    last_error   TEXT,
+    claimed_at   TIMESTAMP WITH TIME ZONE,
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    completed_at TIMESTAMP WITH TIME ZONE,
+    expires_at   TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX data_exports_profile_idx ON data_exports (profile_id, created_at DESC);
+CREATE INDEX data_exports_pending_idx ON data_exports (created_at) WHERE status = 'pending';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS data_exports;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE user_profile
+    ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX user_profile_deleted_at_idx ON user_profile (deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- У user_roster нет внешнего ключа на профиль, поэтому после удаления профиля
+-- в прошлых результатах турниров остается обезличенный никнейм
+ALTER TABLE user_roster
+    ADD COLUMN nickname VARCHAR(64);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE user_roster
+    DROP COLUMN IF EXISTS nickname;
+
+DROP INDEX IF EXISTS user_profile_deleted_at_idx;
+
+ALTER TABLE user_profile
+    DROP COLUMN IF EXISTS deleted_at;
+-- +goose StatementEnd
@@ -0,0 +1,156 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE ledger_accounts
+(
+    id            SERIAL PRIMARY KEY,
+    kind          VARCHAR(20)              NOT NULL,
+    -- при удалении профиля счет и проводки остаются, иначе сломается баланс двойной записи
+    profile_id    UUID UNIQUE REFERENCES user_profile (id) ON DELETE SET NULL,
+    tournament_id BIGINT UNIQUE REFERENCES tournaments (id) ON DELETE SET NULL,
+    created_at    TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX ledger_accounts_house_idx ON ledger_accounts (kind) WHERE kind = 'house';
+
+CREATE TABLE ledger_transactions
+(
+    id             BIGSERIAL PRIMARY KEY,
+    reason         VARCHAR(30)              NOT NULL,
+    reference_type VARCHAR(30),
+    reference_id   VARCHAR(64),
+    -- id строки coin_transactions, из которой перенесена транзакция
+    legacy_id      INTEGER,
+    created_at     TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+-- Каждая транзакция - ровно две проводки с противоположными суммами
+CREATE TABLE ledger_entries
+(
+    id             BIGSERIAL PRIMARY KEY,
+    transaction_id BIGINT  NOT NULL REFERENCES ledger_transactions (id),
+    account_id     INTEGER NOT NULL REFERENCES ledger_accounts (id),
+    amount         BIGINT  NOT NULL,
+    -- баланс пользовательского счета после проводки, для остальных счетов NULL
+    balance_after  BIGINT
+);
+
+CREATE INDEX ledger_entries_account_idx ON ledger_entries (account_id, transaction_id);
+CREATE INDEX ledger_entries_transaction_idx ON ledger_entries (transaction_id);
+
+INSERT INTO ledger_accounts (kind) VALUES ('house');
+INSERT INTO ledger_accounts (kind, profile_id) SELECT 'user', id FROM user_profile;
+INSERT INTO ledger_accounts (kind, tournament_id) SELECT 'prize_pool', id FROM tournaments;
+
+-- Перенос coin_transactions. Причина восстанавливается по тексту transaction_details
+INSERT INTO ledger_transactions (reason, reference_type, reference_id, legacy_id, created_at)
+SELECT CASE
+           WHEN ct.transaction_details LIKE 'Покупка:%' THEN 'purchase'
+           WHEN ct.transaction_details LIKE 'Участие в турнире №%' THEN 'entry_fee'
+           WHEN ct.transaction_details LIKE 'Награда за участие в турнире №%' THEN 'prize'
+           ELSE 'grant'
+           END,
+       CASE
+           WHEN ct.transaction_details LIKE '%турнире №%' THEN 'tournament'
+           WHEN ct.transaction_details = 'Бонус за создание аккаунта' THEN 'signup'
+           ELSE 'legacy'
+           END,
+       CASE
+           WHEN ct.transaction_details LIKE '%турнире №%' THEN substring(ct.transaction_details FROM '№([0-9]+)')
+           ELSE NULL
+           END,
+       ct.id,
+       ct.transaction_date
+FROM coin_transactions ct
+WHERE ct.amount <> 0
+  AND ct.status = 'Выполнено'
+  AND ct.profile_id IS NOT NULL
+ORDER BY ct.transaction_date, ct.id;
+
+INSERT INTO ledger_entries (transaction_id, account_id, amount)
+SELECT lt.id, ua.id, ct.amount
+FROM ledger_transactions lt
+         JOIN coin_transactions ct ON ct.id = lt.legacy_id
+         JOIN ledger_accounts ua ON ua.profile_id = ct.profile_id;
+
+INSERT INTO ledger_entries (transaction_id, account_id, amount)
+SELECT lt.id, COALESCE(pa.id, house.id), -ct.amount
+FROM ledger_transactions lt
+         JOIN coin_transactions ct ON ct.id = lt.legacy_id
+         CROSS JOIN (SELECT id FROM ledger_accounts WHERE kind = 'house') house
+         LEFT JOIN ledger_accounts pa ON lt.reference_type = 'tournament' AND pa.tournament_id = lt.reference_id::BIGINT;
+
+-- Если баланс пользователя не сходится с перенесенной историей, разница фиксируется начальным начислением от house
+INSERT INTO ledger_transactions (reason, reference_type, reference_id, created_at)
+SELECT 'grant', 'opening_balance', a.id::TEXT, p.date_registration
+FROM ledger_accounts a
+         JOIN user_profile p ON p.id = a.profile_id
+         LEFT JOIN ledger_entries e ON e.account_id = a.id
+GROUP BY a.id, p.coins, p.date_registration
+HAVING p.coins <> COALESCE(SUM(e.amount), 0);
+
+INSERT INTO ledger_entries (transaction_id, account_id, amount)
+SELECT lt.id, a.id, d.delta
+FROM ledger_transactions lt
+         JOIN ledger_accounts a ON a.id = lt.reference_id::INTEGER
+         JOIN (SELECT a.id AS account_id, p.coins - COALESCE(SUM(e.amount), 0) AS delta
+               FROM ledger_accounts a
+                        JOIN user_profile p ON p.id = a.profile_id
+                        LEFT JOIN ledger_entries e ON e.account_id = a.id
+               GROUP BY a.id, p.coins) d ON d.account_id = a.id
+WHERE lt.reference_type = 'opening_balance';
+
+INSERT INTO ledger_entries (transaction_id, account_id, amount)
+SELECT lt.id, house.id, -e.amount
+FROM ledger_transactions lt
+         JOIN ledger_entries e ON e.transaction_id = lt.id
+         CROSS JOIN (SELECT id FROM ledger_accounts WHERE kind = 'house') house
+WHERE lt.reference_type = 'opening_balance';
+
+-- Призовой фонд турнира больше суммы взносов: разницу добавляет house
+INSERT INTO ledger_transactions (reason, reference_type, reference_id, created_at)
+SELECT 'grant', 'prize_pool_bonus', t.id::TEXT, now()
+FROM tournaments t
+         JOIN ledger_accounts pa ON pa.tournament_id = t.id
+         LEFT JOIN ledger_transactions lt ON lt.reason = 'entry_fee' AND lt.reference_type = 'tournament' AND lt.reference_id = t.id::TEXT
+         LEFT JOIN ledger_entries e ON e.transaction_id = lt.id AND e.account_id = pa.id
+GROUP BY t.id, t.prize_fond
+HAVING t.prize_fond > COALESCE(SUM(e.amount), 0);
+
+INSERT INTO ledger_entries (transaction_id, account_id, amount)
+SELECT lt.id, pa.id, t.prize_fond - COALESCE((SELECT SUM(e.amount)
+                                              FROM ledger_entries e
+                                                       JOIN ledger_transactions fee ON fee.id = e.transaction_id
+                                              WHERE e.account_id = pa.id
+                                                AND fee.reason = 'entry_fee'), 0)
+FROM ledger_transactions lt
+         JOIN tournaments t ON t.id = lt.reference_id::BIGINT
+         JOIN ledger_accounts pa ON pa.tournament_id = t.id
+WHERE lt.reference_type = 'prize_pool_bonus';
+
+INSERT INTO ledger_entries (transaction_id, account_id, amount)
+SELECT lt.id, house.id, -e.amount
+FROM ledger_transactions lt
+         JOIN ledger_entries e ON e.transaction_id = lt.id
+         CROSS JOIN (SELECT id FROM ledger_accounts WHERE kind = 'house') house
+WHERE lt.reference_type = 'prize_pool_bonus';
+
+-- После переноса бонусы за турнир ссылаются на турнир, как и новые
+UPDATE ledger_transactions SET reference_type = 'tournament' WHERE reference_type = 'prize_pool_bonus';
+
+UPDATE ledger_entries e
+SET balance_after = r.running
+FROM (SELECT e.id,
+             SUM(e.amount) OVER (PARTITION BY e.account_id ORDER BY lt.created_at, lt.id) AS running
+      FROM ledger_entries e
+               JOIN ledger_transactions lt ON lt.id = e.transaction_id
+               JOIN ledger_accounts a ON a.id = e.account_id
+      WHERE a.kind = 'user') r
+WHERE r.id = e.id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS ledger_entries;
+DROP TABLE IF EXISTS ledger_transactions;
+DROP TABLE IF EXISTS ledger_accounts;
+-- +goose StatementEnd
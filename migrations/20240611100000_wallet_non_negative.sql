@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Последняя линия защиты от ухода баланса в минус. NOT VALID - старые строки не проверяются,
+-- ограничение действует на все новые изменения баланса
+ALTER TABLE user_profile
+    ADD CONSTRAINT user_profile_coins_non_negative CHECK (coins >= 0) NOT VALID;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE user_profile
+    DROP CONSTRAINT user_profile_coins_non_negative;
+-- +goose StatementEnd
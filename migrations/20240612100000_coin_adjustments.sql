@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Журнал ручных начислений и списаний монет администраторами. Внешних ключей на профили нет,
+-- чтобы записи журнала переживали окончательное удаление профилей
+CREATE TABLE coin_adjustments
+(
+    id         BIGSERIAL PRIMARY KEY,
+    profile_id UUID                     NOT NULL,
+    admin_id   UUID                     NOT NULL,
+    amount     INTEGER                  NOT NULL CHECK (amount <> 0),
+    reason     VARCHAR(500)             NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX coin_adjustments_profile_idx ON coin_adjustments (profile_id, created_at);
+CREATE INDEX coin_adjustments_admin_idx ON coin_adjustments (admin_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS coin_adjustments;
+-- +goose StatementEnd
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE reconciliation_runs
+(
+    id               BIGSERIAL PRIMARY KEY,
+    started_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    finished_at      TIMESTAMP WITH TIME ZONE,
+    checked_profiles INTEGER                  NOT NULL DEFAULT 0,
+    discrepancies    INTEGER                  NOT NULL DEFAULT 0
+);
+
+-- kind: balance - user_profile.coins не равен сумме проводок счета пользователя,
+-- unbalanced_transaction - проводки транзакции в сумме не дают ноль,
+-- negative_prize_pool - из призового фонда выплачено больше, чем в него поступило
+CREATE TABLE reconciliation_discrepancies
+(
+    id             BIGSERIAL PRIMARY KEY,
+    run_id         BIGINT      NOT NULL REFERENCES reconciliation_runs (id) ON DELETE CASCADE,
+    kind           VARCHAR(30) NOT NULL,
+    profile_id     UUID,
+    tournament_id  BIGINT,
+    transaction_id BIGINT,
+    expected       BIGINT      NOT NULL,
+    actual         BIGINT      NOT NULL
+);
+
+CREATE INDEX reconciliation_discrepancies_run_idx ON reconciliation_discrepancies (run_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS reconciliation_discrepancies;
+DROP TABLE IF EXISTS reconciliation_runs;
+-- +goose StatementEnd
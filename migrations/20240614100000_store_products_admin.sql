@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE fantasy_store
+    ADD COLUMN active          BOOLEAN                  NOT NULL DEFAULT TRUE,
+    ADD COLUMN available_from  TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN available_until TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN sort_order      INTEGER                  NOT NULL DEFAULT 0,
+    ADD COLUMN archived_at     TIMESTAMP WITH TIME ZONE;
+
+UPDATE fantasy_store SET sort_order = id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE fantasy_store
+    DROP COLUMN active,
+    DROP COLUMN available_from,
+    DROP COLUMN available_until,
+    DROP COLUMN sort_order,
+    DROP COLUMN archived_at;
+-- +goose StatementEnd
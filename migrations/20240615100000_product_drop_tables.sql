@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE fantasy_store
+    ADD COLUMN drop_table JSONB;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE fantasy_store
+    DROP COLUMN drop_table;
+-- +goose StatementEnd
@@ -0,0 +1,45 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE pack_seeds
+(
+    id               BIGSERIAL PRIMARY KEY,
+    profile_id       UUID                     NOT NULL REFERENCES user_profile (id) ON DELETE CASCADE,
+    server_seed      VARCHAR(64)              NOT NULL,
+    server_seed_hash VARCHAR(64)              NOT NULL,
+    client_seed      VARCHAR(64)              NOT NULL,
+    nonce            INTEGER                  NOT NULL DEFAULT 0,
+    created_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    revealed_at      TIMESTAMP WITH TIME ZONE
+);
+
+-- у пользователя одновременно только одна нераскрытая пара сидов
+CREATE UNIQUE INDEX pack_seeds_active_idx ON pack_seeds (profile_id) WHERE revealed_at IS NULL;
+
+CREATE TABLE pack_openings
+(
+    id         BIGSERIAL PRIMARY KEY,
+    profile_id UUID                     NOT NULL REFERENCES user_profile (id) ON DELETE CASCADE,
+    product_id INTEGER                  NOT NULL REFERENCES fantasy_store (id),
+    seed_id    BIGINT                   NOT NULL REFERENCES pack_seeds (id) ON DELETE CASCADE,
+    nonce      INTEGER                  NOT NULL,
+    seed       VARCHAR(64)              NOT NULL,
+    drop_table JSONB                    NOT NULL,
+    inputs     JSONB                    NOT NULL,
+    cards      JSONB                    NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX pack_openings_profile_idx ON pack_openings (profile_id, id);
+
+ALTER TABLE player_cards
+    ADD COLUMN pack_opening_id BIGINT REFERENCES pack_openings (id) ON DELETE SET NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE player_cards
+    DROP COLUMN pack_opening_id;
+
+DROP TABLE IF EXISTS pack_openings;
+DROP TABLE IF EXISTS pack_seeds;
+-- +goose StatementEnd
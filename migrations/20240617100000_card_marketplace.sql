@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE card_listings
+(
+    id           BIGSERIAL PRIMARY KEY,
+    card_id      INTEGER                  NOT NULL REFERENCES player_cards (id) ON DELETE CASCADE,
+    seller_id    UUID                     NOT NULL REFERENCES user_profile (id) ON DELETE CASCADE,
+    price        INTEGER                  NOT NULL CHECK (price > 0),
+    status       VARCHAR(20)              NOT NULL DEFAULT 'active',
+    buyer_id     UUID REFERENCES user_profile (id) ON DELETE SET NULL,
+    fee          INTEGER                  NOT NULL DEFAULT 0,
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    closed_at    TIMESTAMP WITH TIME ZONE
+);
+
+-- карточка может быть выставлена только в одном активном объявлении
+CREATE UNIQUE INDEX card_listings_active_card_idx ON card_listings (card_id) WHERE status = 'active';
+CREATE INDEX card_listings_active_idx ON card_listings (id) WHERE status = 'active';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS card_listings;
+-- +goose StatementEnd
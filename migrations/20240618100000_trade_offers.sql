@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE trade_offers
+(
+    id              BIGSERIAL PRIMARY KEY,
+    proposer_id     UUID                     NOT NULL REFERENCES user_profile (id) ON DELETE CASCADE,
+    recipient_id    UUID                     NOT NULL REFERENCES user_profile (id) ON DELETE CASCADE,
+    offered_cards   INTEGER[]                NOT NULL DEFAULT '{}',
+    requested_cards INTEGER[]                NOT NULL DEFAULT '{}',
+    coins           INTEGER                  NOT NULL DEFAULT 0 CHECK (coins >= 0),
+    status          VARCHAR(20)              NOT NULL DEFAULT 'pending',
+    parent_id       BIGINT REFERENCES trade_offers (id) ON DELETE SET NULL,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    expires_at      TIMESTAMP WITH TIME ZONE NOT NULL,
+    closed_at       TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX trade_offers_proposer_idx ON trade_offers (proposer_id, id);
+CREATE INDEX trade_offers_recipient_idx ON trade_offers (recipient_id, id);
+CREATE INDEX trade_offers_pending_idx ON trade_offers (expires_at) WHERE status = 'pending';
+
+ALTER TABLE notification_preferences
+    ADD COLUMN trade_offers BOOLEAN NOT NULL DEFAULT TRUE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE notification_preferences
+    DROP COLUMN trade_offers;
+
+DROP TABLE IF EXISTS trade_offers;
+-- +goose StatementEnd
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE user_profile
+    ADD COLUMN dust INTEGER NOT NULL DEFAULT 0 CONSTRAINT user_profile_dust_non_negative CHECK (dust >= 0);
+
+-- Разобранные и объединенные карточки не удаляются, а теряют владельца: на них ссылаются
+-- история рынка и открытия наборов
+ALTER TABLE player_cards
+    ADD COLUMN burned_at TIMESTAMP WITH TIME ZONE;
+
+-- Журнал всех превращений карточек и движения пыли. dust_amount со знаком, dust_balance_after - баланс после записи
+CREATE TABLE card_conversions
+(
+    id                 BIGSERIAL PRIMARY KEY,
+    profile_id         UUID REFERENCES user_profile (id) ON DELETE SET NULL,
+    kind               VARCHAR(20)              NOT NULL,
+    consumed_cards     INTEGER[]                NOT NULL DEFAULT '{}',
+    produced_card_id   INTEGER REFERENCES player_cards (id) ON DELETE SET NULL,
+    dust_amount        INTEGER                  NOT NULL,
+    dust_balance_after INTEGER                  NOT NULL,
+    created_at         TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX card_conversions_profile_idx ON card_conversions (profile_id, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS card_conversions;
+
+ALTER TABLE player_cards
+    DROP COLUMN burned_at;
+
+ALTER TABLE user_profile
+    DROP COLUMN dust;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- История монет ведется в главной книге (ledger_transactions, ledger_entries). Старые строки перенесены
+-- миграцией coin_ledger и указаны в ledger_transactions.legacy_id, новые операции в таблицу не пишутся.
+-- Таблица остается только как исходные данные переноса
+COMMENT ON TABLE coin_transactions IS 'Устарела: заменена главной книгой ledger_transactions/ledger_entries, не пополняется';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+COMMENT ON TABLE coin_transactions IS NULL;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Никнеймы, совпадающие без учета регистра, кроме самого раннего, получают суффикс из id профиля
+UPDATE user_profile p
+SET nickname = p.nickname || '_' || LEFT(p.id::TEXT, 8)
+FROM (SELECT id, ROW_NUMBER() OVER (PARTITION BY LOWER(nickname) ORDER BY date_registration, id) AS n
+      FROM user_profile) d
+WHERE d.id = p.id
+  AND d.n > 1;
+
+CREATE UNIQUE INDEX user_profile_nickname_lower_idx ON user_profile (LOWER(nickname));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS user_profile_nickname_lower_idx;
+-- +goose StatementEnd
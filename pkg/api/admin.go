@@ -0,0 +1,185 @@
+package api
+
+import (
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// grantRole godoc
+// @Summary Выдача роли пользователю
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Выдача роли пользователю. Доступно только администраторам. Роль появится в токене пользователя после обновления токенов
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body user.RoleInput true "Входные параметры"
+// @Success 200 {object} StatusResponse
+// @Failure 400,401,403 {object} Error
+// @Failure 500 {object} Error
+// @Router /admin/roles/grant [post]
+func (api Api) grantRole(ctx *gin.Context) {
+	var inp user.RoleInput
+	if err := ctx.BindJSON(&inp); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputBodyError))
+		return
+	}
+
+	err := api.services.User.GrantRole(inp)
+	if err != nil {
+		log.Println("GrantRole:", err)
+		switch err {
+		case storage.UserDoesNotExistError:
+			ctx.JSON(http.StatusBadRequest, getBadRequestError(err))
+			return
+		default:
+			ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, StatusResponse{"ок"})
+}
+
+// revokeRole godoc
+// @Summary Отзыв роли у пользователя
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Отзыв роли у пользователя. Доступно только администраторам
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body user.RoleInput true "Входные параметры"
+// @Success 200 {object} StatusResponse
+// @Failure 400,401,403 {object} Error
+// @Failure 500 {object} Error
+// @Router /admin/roles/revoke [post]
+func (api Api) revokeRole(ctx *gin.Context) {
+	var inp user.RoleInput
+	if err := ctx.BindJSON(&inp); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputBodyError))
+		return
+	}
+
+	err := api.services.User.RevokeRole(inp)
+	if err != nil {
+		log.Println("RevokeRole:", err)
+		switch err {
+		case storage.UserDoesNotExistError:
+			ctx.JSON(http.StatusBadRequest, getBadRequestError(err))
+			return
+		default:
+			ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, StatusResponse{"ок"})
+}
+
+// grantCoins godoc
+// @Summary Начисление монет пользователю
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Ручное начисление монет с обязательной причиной. Доступно только администраторам, попадает в журнал изменений
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body user.CoinAdjustmentInput true "Входные параметры"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор с тем же ключом вернет сохраненный ответ"
+// @Success 200 {object} user.CoinAdjustment
+// @Failure 400,401,403 {object} Error
+// @Failure 409,422 {object} Error
+// @Failure 500 {object} Error
+// @Router /admin/coins/grant [post]
+func (api Api) grantCoins(ctx *gin.Context) {
+	api.adjustCoins(ctx, api.services.User.GrantCoins)
+}
+
+// deductCoins godoc
+// @Summary Списание монет у пользователя
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Ручное списание монет с обязательной причиной. Доступно только администраторам, попадает в журнал изменений
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body user.CoinAdjustmentInput true "Входные параметры"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор с тем же ключом вернет сохраненный ответ"
+// @Success 200 {object} user.CoinAdjustment
+// @Failure 400,401,403 {object} Error
+// @Failure 409,422 {object} Error
+// @Failure 500 {object} Error
+// @Router /admin/coins/deduct [post]
+func (api Api) deductCoins(ctx *gin.Context) {
+	api.adjustCoins(ctx, api.services.User.DeductCoins)
+}
+
+func (api Api) adjustCoins(ctx *gin.Context, adjust func(uuid.UUID, user.CoinAdjustmentInput) (user.CoinAdjustment, error)) {
+	adminID, err := parseUserIDFromContext(ctx)
+	if err != nil {
+		log.Println("AdjustCoins:", err)
+		ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+		return
+	}
+
+	var inp user.CoinAdjustmentInput
+	if err = ctx.BindJSON(&inp); err != nil || strings.TrimSpace(inp.Reason) == "" {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputBodyError))
+		return
+	}
+
+	adj, err := adjust(adminID, inp)
+	if err != nil {
+		log.Println("AdjustCoins:", err)
+		if handleInsufficientFundsError(ctx, err) {
+			return
+		}
+		switch err {
+		case storage.UserDoesNotExistError,
+			service.SelfAdjustmentError:
+			ctx.JSON(http.StatusBadRequest, getBadRequestError(err))
+			return
+		default:
+			ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, adj)
+}
+
+// getCoinAdjustments godoc
+// @Summary Журнал ручных изменений баланса
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Изменения баланса пользователя и изменения, сделанные им как администратором, от новых к старым
+// @Tags admin
+// @Produce json
+// @Param profileID query string true "ID пользователя"
+// @Success 200 {array} user.CoinAdjustment
+// @Failure 400,401,403 {object} Error
+// @Failure 500 {object} Error
+// @Router /admin/coins/adjustments [get]
+func (api Api) getCoinAdjustments(ctx *gin.Context) {
+	var inp user.CoinAdjustmentsInput
+	if err := ctx.ShouldBindQuery(&inp); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputParametersError))
+		return
+	}
+
+	adjustments, err := api.services.User.GetCoinAdjustments(uuid.MustParse(inp.ProfileID))
+	if err != nil {
+		log.Println("GetCoinAdjustments:", err)
+		ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, adjustments)
+}
@@ -0,0 +1,334 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service"
+	mock_service "github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service/mocks"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHandler_grantRole(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockUser, tm *mock_service.MockTokenManager, inp user.RoleInput)
+	profileID, _ := uuid.Parse("6bc57ea9-c881-47d3-a293-b925ff1ddf72")
+
+	testTable := []struct {
+		name                 string
+		inputBody            string
+		inputRole            user.RoleInput
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "OK",
+			inputBody: `{"profileID":"6bc57ea9-c881-47d3-a293-b925ff1ddf72","role":"admin"}`,
+			inputRole: user.RoleInput{ProfileID: profileID, Role: user.AdminRole},
+			mockBehavior: func(s *mock_service.MockUser, tm *mock_service.MockTokenManager, inp user.RoleInput) {
+				tm.EXPECT().ParseJWT("token").Return(user.AccessTokenClaims{UserID: profileID.String(), Roles: []string{user.AdminRole}}, nil)
+				s.EXPECT().CheckProfileActive(profileID).Return(nil)
+				s.EXPECT().GrantRole(inp).Return(nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"ок"}`,
+		},
+		{
+			name:      "Not admin",
+			inputBody: `{"profileID":"6bc57ea9-c881-47d3-a293-b925ff1ddf72","role":"admin"}`,
+			mockBehavior: func(s *mock_service.MockUser, tm *mock_service.MockTokenManager, inp user.RoleInput) {
+				tm.EXPECT().ParseJWT("token").Return(user.AccessTokenClaims{UserID: profileID.String()}, nil)
+				s.EXPECT().CheckProfileActive(profileID).Return(nil)
+			},
+			expectedStatusCode: 403,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				ForbiddenErrorTitle, AccessDeniedError),
+		},
+		{
+			name:      "Invalid token",
+			inputBody: `{"profileID":"6bc57ea9-c881-47d3-a293-b925ff1ddf72","role":"admin"}`,
+			mockBehavior: func(s *mock_service.MockUser, tm *mock_service.MockTokenManager, inp user.RoleInput) {
+				tm.EXPECT().ParseJWT("token").Return(user.AccessTokenClaims{}, service.InvalidAccessTokenError)
+			},
+			expectedStatusCode: 401,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				UnauthorizedErrorTitle, service.InvalidAccessTokenError),
+		},
+		{
+			name:      "Deleted profile",
+			inputBody: `{"profileID":"6bc57ea9-c881-47d3-a293-b925ff1ddf72","role":"admin"}`,
+			mockBehavior: func(s *mock_service.MockUser, tm *mock_service.MockTokenManager, inp user.RoleInput) {
+				tm.EXPECT().ParseJWT("token").Return(user.AccessTokenClaims{UserID: profileID.String(), Roles: []string{user.AdminRole}}, nil)
+				s.EXPECT().CheckProfileActive(profileID).Return(service.ProfileDeletedError)
+			},
+			expectedStatusCode: 401,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				UnauthorizedErrorTitle, service.ProfileDeletedError),
+		},
+		{
+			name:      "Unknown role",
+			inputBody: `{"profileID":"6bc57ea9-c881-47d3-a293-b925ff1ddf72","role":"owner"}`,
+			mockBehavior: func(s *mock_service.MockUser, tm *mock_service.MockTokenManager, inp user.RoleInput) {
+				tm.EXPECT().ParseJWT("token").Return(user.AccessTokenClaims{UserID: profileID.String(), Roles: []string{user.AdminRole}}, nil)
+				s.EXPECT().CheckProfileActive(profileID).Return(nil)
+			},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, InvalidInputBodyError),
+		},
+		{
+			name:      "User does not exist",
+			inputBody: `{"profileID":"6bc57ea9-c881-47d3-a293-b925ff1ddf72","role":"admin"}`,
+			inputRole: user.RoleInput{ProfileID: profileID, Role: user.AdminRole},
+			mockBehavior: func(s *mock_service.MockUser, tm *mock_service.MockTokenManager, inp user.RoleInput) {
+				tm.EXPECT().ParseJWT("token").Return(user.AccessTokenClaims{UserID: profileID.String(), Roles: []string{user.AdminRole}}, nil)
+				s.EXPECT().CheckProfileActive(profileID).Return(nil)
+				s.EXPECT().GrantRole(inp).Return(storage.UserDoesNotExistError)
+			},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, storage.UserDoesNotExistError),
+		},
+		{
+			name:      "Service error",
+			inputBody: `{"profileID":"6bc57ea9-c881-47d3-a293-b925ff1ddf72","role":"admin"}`,
+			inputRole: user.RoleInput{ProfileID: profileID, Role: user.AdminRole},
+			mockBehavior: func(s *mock_service.MockUser, tm *mock_service.MockTokenManager, inp user.RoleInput) {
+				tm.EXPECT().ParseJWT("token").Return(user.AccessTokenClaims{UserID: profileID.String(), Roles: []string{user.AdminRole}}, nil)
+				s.EXPECT().CheckProfileActive(profileID).Return(nil)
+				s.EXPECT().GrantRole(inp).Return(errors.New("something went wrong"))
+			},
+			expectedStatusCode: 500,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				InternalServerErrorTitle, InternalServerErrorMessage),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			user := mock_service.NewMockUser(c)
+			tokenManager := mock_service.NewMockTokenManager(c)
+			testCase.mockBehavior(user, tokenManager, testCase.inputRole)
+
+			services := &service.Services{User: user, TokenManager: tokenManager}
+			handler := Api{services: services}
+
+			r := gin.New()
+			r.POST("/admin/roles/grant", handler.userIdentity, handler.requireRole("admin"), handler.grantRole)
+
+			w := httptest.NewRecorder()
+
+			req := httptest.NewRequest("POST", "/admin/roles/grant",
+				bytes.NewBufferString(testCase.inputBody))
+			req.Header.Set("Authorization", "Bearer token")
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, w.Code, testCase.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), testCase.expectedResponseBody)
+		})
+	}
+}
+
+func TestHandler_adjustCoins(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockUser, inp user.CoinAdjustmentInput)
+	adminID, _ := uuid.Parse("0f3b2c1d-4e5f-4a6b-8c7d-9e0f1a2b3c4d")
+	profileID, _ := uuid.Parse("6bc57ea9-c881-47d3-a293-b925ff1ddf72")
+	createdAt, _ := time.Parse(time.RFC3339, "2024-06-12T10:00:00Z")
+	inputBody := `{"profileID":"6bc57ea9-c881-47d3-a293-b925ff1ddf72","amount":500,"reason":"Компенсация за сбой турнира"}`
+	input := user.CoinAdjustmentInput{ProfileID: profileID, Amount: 500, Reason: "Компенсация за сбой турнира"}
+	insufficientFunds := &storage.InsufficientFundsError{ProfileID: profileID, Balance: 100, Required: 500}
+
+	testTable := []struct {
+		name                 string
+		path                 string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Grant",
+			path:      "/admin/coins/grant",
+			inputBody: inputBody,
+			mockBehavior: func(s *mock_service.MockUser, inp user.CoinAdjustmentInput) {
+				s.EXPECT().GrantCoins(adminID, inp).Return(user.CoinAdjustment{ID: 1, ProfileID: profileID,
+					AdminID: adminID, Amount: 500, Reason: inp.Reason, CreatedAt: createdAt}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"id":1,"profileID":"6bc57ea9-c881-47d3-a293-b925ff1ddf72","adminID":"0f3b2c1d-4e5f-4a6b-8c7d-9e0f1a2b3c4d","amount":500,"reason":"Компенсация за сбой турнира","createdAt":"2024-06-12T10:00:00Z"}`,
+		},
+		{
+			name:      "Deduct",
+			path:      "/admin/coins/deduct",
+			inputBody: inputBody,
+			mockBehavior: func(s *mock_service.MockUser, inp user.CoinAdjustmentInput) {
+				s.EXPECT().DeductCoins(adminID, inp).Return(user.CoinAdjustment{ID: 2, ProfileID: profileID,
+					AdminID: adminID, Amount: -500, Reason: inp.Reason, CreatedAt: createdAt}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"id":2,"profileID":"6bc57ea9-c881-47d3-a293-b925ff1ddf72","adminID":"0f3b2c1d-4e5f-4a6b-8c7d-9e0f1a2b3c4d","amount":-500,"reason":"Компенсация за сбой турнира","createdAt":"2024-06-12T10:00:00Z"}`,
+		},
+		{
+			name:               "Missing reason",
+			path:               "/admin/coins/grant",
+			inputBody:          `{"profileID":"6bc57ea9-c881-47d3-a293-b925ff1ddf72","amount":500,"reason":"   "}`,
+			mockBehavior:       func(s *mock_service.MockUser, inp user.CoinAdjustmentInput) {},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, InvalidInputBodyError),
+		},
+		{
+			name:               "Negative amount",
+			path:               "/admin/coins/deduct",
+			inputBody:          `{"profileID":"6bc57ea9-c881-47d3-a293-b925ff1ddf72","amount":-500,"reason":"Компенсация"}`,
+			mockBehavior:       func(s *mock_service.MockUser, inp user.CoinAdjustmentInput) {},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, InvalidInputBodyError),
+		},
+		{
+			name:      "Insufficient funds",
+			path:      "/admin/coins/deduct",
+			inputBody: inputBody,
+			mockBehavior: func(s *mock_service.MockUser, inp user.CoinAdjustmentInput) {
+				s.EXPECT().DeductCoins(adminID, inp).Return(user.CoinAdjustment{}, insufficientFunds)
+			},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, insufficientFunds),
+		},
+		{
+			name:      "Own balance",
+			path:      "/admin/coins/grant",
+			inputBody: inputBody,
+			mockBehavior: func(s *mock_service.MockUser, inp user.CoinAdjustmentInput) {
+				s.EXPECT().GrantCoins(adminID, inp).Return(user.CoinAdjustment{}, service.SelfAdjustmentError)
+			},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, service.SelfAdjustmentError),
+		},
+		{
+			name:      "Service error",
+			path:      "/admin/coins/grant",
+			inputBody: inputBody,
+			mockBehavior: func(s *mock_service.MockUser, inp user.CoinAdjustmentInput) {
+				s.EXPECT().GrantCoins(adminID, inp).Return(user.CoinAdjustment{}, errors.New("something went wrong"))
+			},
+			expectedStatusCode: 500,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				InternalServerErrorTitle, InternalServerErrorMessage),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			user := mock_service.NewMockUser(c)
+			testCase.mockBehavior(user, input)
+
+			services := &service.Services{User: user}
+			handler := Api{services: services}
+
+			r := gin.New()
+			setAdmin := func(ctx *gin.Context) {
+				ctx.Set("userID", adminID.String())
+			}
+			r.POST("/admin/coins/grant", setAdmin, handler.grantCoins)
+			r.POST("/admin/coins/deduct", setAdmin, handler.deductCoins)
+
+			w := httptest.NewRecorder()
+
+			req := httptest.NewRequest("POST", testCase.path, bytes.NewBufferString(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, w.Code, testCase.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), testCase.expectedResponseBody)
+		})
+	}
+}
+
+func TestHandler_getCoinAdjustments(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockUser)
+	adminID, _ := uuid.Parse("0f3b2c1d-4e5f-4a6b-8c7d-9e0f1a2b3c4d")
+	profileID, _ := uuid.Parse("6bc57ea9-c881-47d3-a293-b925ff1ddf72")
+	createdAt, _ := time.Parse(time.RFC3339, "2024-06-12T10:00:00Z")
+
+	testTable := []struct {
+		name                 string
+		query                string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "OK",
+			query: "?profileID=6bc57ea9-c881-47d3-a293-b925ff1ddf72",
+			mockBehavior: func(s *mock_service.MockUser) {
+				s.EXPECT().GetCoinAdjustments(profileID).Return([]user.CoinAdjustment{
+					{ID: 1, ProfileID: profileID, AdminID: adminID, Amount: 500, Reason: "Компенсация", CreatedAt: createdAt},
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `[{"id":1,"profileID":"6bc57ea9-c881-47d3-a293-b925ff1ddf72","adminID":"0f3b2c1d-4e5f-4a6b-8c7d-9e0f1a2b3c4d","amount":500,"reason":"Компенсация","createdAt":"2024-06-12T10:00:00Z"}]`,
+		},
+		{
+			name:               "Invalid profile id",
+			query:              "?profileID=123",
+			mockBehavior:       func(s *mock_service.MockUser) {},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, InvalidInputParametersError),
+		},
+		{
+			name:  "Service error",
+			query: "?profileID=6bc57ea9-c881-47d3-a293-b925ff1ddf72",
+			mockBehavior: func(s *mock_service.MockUser) {
+				s.EXPECT().GetCoinAdjustments(profileID).Return(nil, errors.New("something went wrong"))
+			},
+			expectedStatusCode: 500,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				InternalServerErrorTitle, InternalServerErrorMessage),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			user := mock_service.NewMockUser(c)
+			testCase.mockBehavior(user)
+
+			services := &service.Services{User: user}
+			handler := Api{services: services}
+
+			r := gin.New()
+			r.GET("/admin/coins/adjustments", handler.getCoinAdjustments)
+
+			w := httptest.NewRecorder()
+
+			req := httptest.NewRequest("GET", "/admin/coins/adjustments"+testCase.query, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, w.Code, testCase.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), testCase.expectedResponseBody)
+		})
+	}
+}
@@ -5,8 +5,8 @@ import (
 	"github.com/Frozen-Fantasy/fantasy-backend.git/config"
 	"github.com/Frozen-Fantasy/fantasy-backend.git/docs"
 	_ "github.com/Frozen-Fantasy/fantasy-backend.git/docs"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
 	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service"
-	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/storage"
 	"github.com/gin-gonic/gin"
 	swaggerfiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -21,16 +21,12 @@ type Api struct {
 func NewApi(
 	router *gin.Engine,
 	cfg config.ServiceConfiguration,
+	services *service.Services,
 ) *Api {
 	svc := &Api{
-		router: router,
-		cfg:    cfg,
-		services: service.NewServices(service.Deps{
-			Cfg:      cfg,
-			Storage:  storage.NewPostgresStorage(cfg),
-			RStorage: storage.NewRedisStorage(cfg),
-			Jwt:      service.NewTokenManager(cfg),
-		}),
+		router:   router,
+		cfg:      cfg,
+		services: services,
 	}
 	svc.router.Use(CORSMiddleware())
 	svc.registerRoutes()
@@ -49,32 +45,58 @@ func (api Api) Run() {
 
 func (api *Api) registerRoutes() {
 	base := api.router.Group(BasePath)
+	adminOnly := api.requireRole(user.AdminRole)
 
 	api.router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerfiles.Handler))
 
 	auth := base.Group("/auth")
 	{
 		auth.POST("/sign-up", api.signUp)
-		auth.POST("/sign-in", api.signIn)
-		auth.POST("/email/send-code", api.sendVerificationCode)
+		auth.POST("/sign-in", api.rateLimit("sign_in", api.cfg.RateLimits.SignIn), api.signIn)
+		auth.POST("/email/send-code", api.rateLimit("send_code", api.cfg.RateLimits.SendCode), api.sendVerificationCode)
 		auth.POST("/refresh-tokens", api.refreshTokens)
 		auth.POST("/logout", api.logout)
+		auth.GET("/jwks.json", api.getJWKS)
+		auth.POST("/2fa/verify", api.rateLimit("two_factor_verify", api.cfg.RateLimits.TwoFactorVerify), api.verifyTwoFactor)
 	}
 	user := base.Group("/user")
 	{
 		user.GET("/exists", api.checkUserDataExists)
+		user.GET("/unsubscribe", api.unsubscribePage)
+		user.POST("/unsubscribe", api.unsubscribe)
+		user.GET("/exports/download", api.downloadDataExport)
 		userAuthenticated := user.Group("/", api.userIdentity)
 		{
 			userAuthenticated.GET("/info", api.userInfo)
+			userAuthenticated.PATCH("/profile", api.updateProfile)
+			userAuthenticated.POST("/avatar", api.uploadAvatar)
+			userAuthenticated.POST("/email/change", api.rateLimit("send_code", api.cfg.RateLimits.SendCode), api.changeEmail)
+			userAuthenticated.POST("/email/confirm", api.confirmEmailChange)
+			userAuthenticated.GET("/preferences", api.getPreferences)
+			userAuthenticated.PUT("/preferences", api.updatePreferences)
+			userAuthenticated.POST("/exports", api.requestDataExport)
+			userAuthenticated.GET("/exports/:id", api.getDataExport)
 			userAuthenticated.PATCH("/password/change", api.changePassword)
 			userAuthenticated.DELETE("/delete", api.deleteProfile)
 			userAuthenticated.GET("/transactions", api.getCoinTransactions)
+			userAuthenticated.GET("/sessions", api.getSessions)
+			userAuthenticated.DELETE("/sessions", api.revokeAllSessions)
+			userAuthenticated.DELETE("/sessions/:id", api.revokeSession)
+			userAuthenticated.POST("/sessions/revoke-others", api.revokeOtherSessions)
+			userAuthenticated.POST("/2fa/enroll", api.enrollTwoFactor)
+			userAuthenticated.POST("/2fa/confirm", api.confirmTwoFactor)
+			userAuthenticated.POST("/2fa/disable", api.disableTwoFactor)
+			userAuthenticated.POST("/2fa/recovery-codes", api.regenerateRecoveryCodes)
 		}
 		password := user.Group("/password")
 		{
-			password.POST("/forgot", api.forgotPassword)
+			password.POST("/forgot", api.rateLimit("forgot_password", api.cfg.RateLimits.ForgotPassword), api.forgotPassword)
 			password.PATCH("/reset", api.resetPassword)
 		}
+		email := user.Group("/email")
+		{
+			email.POST("/undo", api.undoEmailChange)
+		}
 	}
 
 	team := base.Group("/tournament")
@@ -83,10 +105,10 @@ func (api *Api) registerRoutes() {
 
 		teamAuthenticated := team.Group("/", api.userIdentity)
 		{
-			teamAuthenticated.GET("/create_team_nhl", api.CreateTeamsNHL)
-			teamAuthenticated.GET("/create_team_khl", api.CreateTeamsKHL)
+			teamAuthenticated.GET("/create_team_nhl", adminOnly, api.CreateTeamsNHL)
+			teamAuthenticated.GET("/create_team_khl", adminOnly, api.CreateTeamsKHL)
 			teamAuthenticated.GET("/roster", api.getTournamentRoster)
-			teamAuthenticated.POST("team/create", api.createTournamentTeam)
+			teamAuthenticated.POST("team/create", api.idempotent, api.createTournamentTeam)
 			teamAuthenticated.GET("team", api.getTournamentTeam)
 			teamAuthenticated.PUT("team/edit", api.editTournamentTeam)
 			teamAuthenticated.GET("/get_tournaments/:league", api.GetTournaments)
@@ -103,9 +125,13 @@ func (api *Api) registerRoutes() {
 	store := base.Group("/store")
 	{
 		store.GET("/products", api.getAllProducts)
+		store.GET("/products/:id/odds", api.getProductOdds)
+		store.GET("/openings/:id/verify", api.verifyPackOpening)
 		storeAuthenticated := store.Group("/", api.userIdentity)
 		{
-			storeAuthenticated.POST("/products/buy", api.buyProduct)
+			storeAuthenticated.POST("/products/buy", api.idempotent, api.buyProduct)
+			storeAuthenticated.GET("/fairness/seed", api.getPackSeed)
+			storeAuthenticated.POST("/fairness/seed/rotate", api.rotatePackSeed)
 		}
 	}
 
@@ -115,12 +141,67 @@ func (api *Api) registerRoutes() {
 		players.GET("/cards", api.getPlayerCards)
 		playersAuthenticated := players.Group("/", api.userIdentity)
 		{
-			playersAuthenticated.POST("/khl/create", api.createKHLPlayers)
-			playersAuthenticated.POST("/nhl/create", api.createNHLPlayers)
+			playersAuthenticated.POST("/khl/create", adminOnly, api.createKHLPlayers)
+			playersAuthenticated.POST("/nhl/create", adminOnly, api.createNHLPlayers)
 			playersAuthenticated.POST("/cards/unpack", api.cardUnpacking)
 			playersAuthenticated.GET("/statistic_player/:player_id", api.GetStatisticByPlayerId)
 		}
 	}
+
+	marketplace := base.Group("/marketplace")
+	{
+		marketplace.GET("/listings", api.getListings)
+		marketplaceAuthenticated := marketplace.Group("/", api.userIdentity)
+		{
+			marketplaceAuthenticated.POST("/listings", api.createListing)
+			marketplaceAuthenticated.DELETE("/listings/:id", api.cancelListing)
+			marketplaceAuthenticated.POST("/listings/:id/buy", api.idempotent, api.buyListing)
+		}
+	}
+
+	trades := base.Group("/trades", api.userIdentity)
+	{
+		trades.GET("/offers", api.getTradeOffers)
+		trades.POST("/offers", api.createTradeOffer)
+		trades.GET("/offers/:id", api.getTradeOffer)
+		trades.POST("/offers/:id/counter", api.counterTradeOffer)
+		trades.POST("/offers/:id/accept", api.idempotent, api.acceptTradeOffer)
+		trades.POST("/offers/:id/decline", api.declineTradeOffer)
+	}
+
+	crafting := base.Group("/crafting", api.userIdentity)
+	{
+		crafting.GET("", api.getCraftingInfo)
+		crafting.GET("/history", api.getCardConversions)
+		crafting.POST("/dismantle", api.idempotent, api.dismantleCards)
+		crafting.POST("/upgrade", api.idempotent, api.upgradeCards)
+		crafting.POST("/craft", api.idempotent, api.craftCard)
+	}
+
+	files := base.Group("/files")
+	{
+		files.GET("/avatars/:name", api.getAvatar)
+		files.GET("/products/:name", api.getProductPhoto)
+	}
+
+	admin := base.Group("/admin", api.userIdentity, adminOnly)
+	{
+		admin.POST("/roles/grant", api.grantRole)
+		admin.POST("/roles/revoke", api.revokeRole)
+		admin.POST("/coins/grant", api.idempotent, api.grantCoins)
+		admin.POST("/coins/deduct", api.idempotent, api.deductCoins)
+		admin.GET("/coins/adjustments", api.getCoinAdjustments)
+		admin.GET("/reconciliation/runs", api.getReconciliationRuns)
+		admin.POST("/reconciliation/runs", api.runReconciliation)
+		admin.GET("/reconciliation/runs/:id", api.getReconciliationReport)
+		admin.GET("/store/products", api.getAdminProducts)
+		admin.POST("/store/products", api.createProduct)
+		admin.PUT("/store/products/order", api.reorderProducts)
+		admin.PUT("/store/products/:id", api.updateProduct)
+		admin.POST("/store/products/:id/archive", api.archiveProduct)
+		admin.POST("/store/products/:id/photo", api.uploadProductPhoto)
+		admin.PUT("/store/products/:id/drop-table", api.updateProductDropTable)
+	}
 }
 
 type Error struct {
@@ -130,6 +211,8 @@ type Error struct {
 
 const (
 	UnauthorizedErrorTitle     = "Ошибка авторизации"
+	ForbiddenErrorTitle        = "Доступ запрещен"
+	TooManyRequestsErrorTitle  = "Превышен лимит запросов"
 	InternalServerErrorTitle   = "Ошибка произошла на стороне сервера"
 	InternalServerErrorMessage = "Ошибка на сервере. Зайдите позже :("
 	NotFoundErrorMessage       = "Записей не найдено"
@@ -139,6 +222,7 @@ const (
 var (
 	InvalidInputBodyError       = errors.New("невалидное тело запроса")
 	InvalidInputParametersError = errors.New("невалидные параметры запроса")
+	AccessDeniedError           = errors.New("недостаточно прав для выполнения запроса")
 )
 
 func getUnauthorizedError(err error) Error {
@@ -148,6 +232,20 @@ func getUnauthorizedError(err error) Error {
 	}
 }
 
+func getForbiddenError(err error) Error {
+	return Error{
+		Error:   ForbiddenErrorTitle,
+		Message: err.Error(),
+	}
+}
+
+func getTooManyRequestsError(err error) Error {
+	return Error{
+		Error:   TooManyRequestsErrorTitle,
+		Message: err.Error(),
+	}
+}
+
 func getInternalServerError() Error {
 	return Error{
 		Error:   InternalServerErrorTitle,
@@ -0,0 +1,213 @@
+package api
+
+import (
+	"errors"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/players"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/storage"
+	"github.com/gin-gonic/gin"
+	"log"
+	"net/http"
+)
+
+// getCraftingInfo godoc
+// @Summary Баланс пыли и цены крафта
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Сколько пыли у пользователя, сколько дает разбор и стоит создание карточки каждой редкости
+// @Tags crafting
+// @Accept json
+// @Produce json
+// @Success 200 {object} players.CraftingInfo
+// @Failure 400,401 {object} Error
+// @Failure 500 {object} Error
+// @Router /crafting [get]
+func (api Api) getCraftingInfo(ctx *gin.Context) {
+	userID, err := parseUserIDFromContext(ctx)
+	if err != nil {
+		log.Println("GetCraftingInfo:", err)
+		return
+	}
+
+	info, err := api.services.Crafting.GetCraftingInfo(userID)
+	if err != nil {
+		log.Println("GetCraftingInfo:", err)
+		ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, info)
+}
+
+// getCardConversions godoc
+// @Summary Журнал крафта
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Все разборы, улучшения и созданные карточки с изменением баланса пыли, от новых к старым. Для следующей страницы передайте в before id последней записи
+// @Tags crafting
+// @Accept json
+// @Produce json
+// @Param before query int false "id последней записи предыдущей страницы"
+// @Param limit query int false "Размер страницы, по умолчанию 20, максимум 100"
+// @Success 200 {array} players.CardConversion
+// @Failure 400,401 {object} Error
+// @Failure 500 {object} Error
+// @Router /crafting/history [get]
+func (api Api) getCardConversions(ctx *gin.Context) {
+	userID, err := parseUserIDFromContext(ctx)
+	if err != nil {
+		log.Println("GetCardConversions:", err)
+		return
+	}
+
+	var filter players.CardConversionsFilter
+	if err = ctx.ShouldBindQuery(&filter); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputParametersError))
+		return
+	}
+
+	conversions, err := api.services.Crafting.GetCardConversions(userID, filter)
+	if err != nil {
+		log.Println("GetCardConversions:", err)
+		ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, conversions)
+}
+
+// dismantleCards godoc
+// @Summary Разбор карточек на пыль
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Разобрать можно свои распакованные карточки вне состава незавершенного турнира и не выставленные на рынок
+// @Tags crafting
+// @Accept json
+// @Produce json
+// @Param data body players.DismantleCardsInput true "Входные параметры"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор с тем же ключом вернет сохраненный ответ"
+// @Success 200 {object} players.CardConversion
+// @Failure 400,401 {object} Error
+// @Failure 409,422 {object} Error
+// @Failure 500 {object} Error
+// @Router /crafting/dismantle [post]
+func (api Api) dismantleCards(ctx *gin.Context) {
+	userID, err := parseUserIDFromContext(ctx)
+	if err != nil {
+		log.Println("DismantleCards:", err)
+		return
+	}
+
+	var inp players.DismantleCardsInput
+	if err = ctx.BindJSON(&inp); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputBodyError))
+		return
+	}
+
+	conversion, err := api.services.Crafting.DismantleCards(userID, inp)
+	if err != nil {
+		log.Println("DismantleCards:", err)
+		handleCraftingError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, conversion)
+}
+
+// upgradeCards godoc
+// @Summary Улучшение карточек
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Объединяет серебряные карточки одного игрока в одну золотую. Нужное количество карточек - upgradeCards из GET /crafting
+// @Tags crafting
+// @Accept json
+// @Produce json
+// @Param data body players.UpgradeCardsInput true "Входные параметры"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор с тем же ключом вернет сохраненный ответ"
+// @Success 200 {object} players.CardConversion
+// @Failure 400,401 {object} Error
+// @Failure 409,422 {object} Error
+// @Failure 500 {object} Error
+// @Router /crafting/upgrade [post]
+func (api Api) upgradeCards(ctx *gin.Context) {
+	userID, err := parseUserIDFromContext(ctx)
+	if err != nil {
+		log.Println("UpgradeCards:", err)
+		return
+	}
+
+	var inp players.UpgradeCardsInput
+	if err = ctx.BindJSON(&inp); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputBodyError))
+		return
+	}
+
+	conversion, err := api.services.Crafting.UpgradeCards(userID, inp)
+	if err != nil {
+		log.Println("UpgradeCards:", err)
+		handleCraftingError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, conversion)
+}
+
+// craftCard godoc
+// @Summary Создание карточки за пыль
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Создает распакованную карточку выбранного игрока. Цена зависит от редкости
+// @Tags crafting
+// @Accept json
+// @Produce json
+// @Param data body players.CraftCardInput true "Входные параметры"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор с тем же ключом вернет сохраненный ответ"
+// @Success 200 {object} players.CardConversion
+// @Failure 400,401 {object} Error
+// @Failure 409,422 {object} Error
+// @Failure 500 {object} Error
+// @Router /crafting/craft [post]
+func (api Api) craftCard(ctx *gin.Context) {
+	userID, err := parseUserIDFromContext(ctx)
+	if err != nil {
+		log.Println("CraftCard:", err)
+		return
+	}
+
+	var inp players.CraftCardInput
+	if err = ctx.BindJSON(&inp); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputBodyError))
+		return
+	}
+
+	conversion, err := api.services.Crafting.CraftCard(userID, inp)
+	if err != nil {
+		log.Println("CraftCard:", err)
+		handleCraftingError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, conversion)
+}
+
+func handleCraftingError(ctx *gin.Context, err error) {
+	var dustErr *storage.InsufficientDustError
+	if errors.As(err, &dustErr) {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(err))
+		return
+	}
+
+	switch err {
+	case service.UpgradeCardsCountError,
+		storage.UpgradeCardsMismatchError,
+		storage.PlayerNotFoundError,
+		storage.PlayerCardNotFoundError,
+		storage.IncorrectPlayerCardUserID,
+		storage.CardNotUnpackedError,
+		storage.CardInActiveRosterError,
+		storage.CardAlreadyListedError:
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(err))
+	default:
+		ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+	}
+}
@@ -0,0 +1,198 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/players"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/store"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service"
+	mock_service "github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service/mocks"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHandler_craftCard(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockCrafting)
+	userID, _ := uuid.Parse("6bc57ea9-c881-47d3-a293-b925ff1ddf72")
+	createdAt, _ := time.Parse(time.RFC3339, "2024-06-19T10:00:00Z")
+	cardID := 120
+	insufficientDust := &storage.InsufficientDustError{Balance: 30, Required: 200}
+
+	testTable := []struct {
+		name                 string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "OK",
+			inputBody: `{"playerID":5,"rarity":2}`,
+			mockBehavior: func(s *mock_service.MockCrafting) {
+				s.EXPECT().CraftCard(userID, players.CraftCardInput{PlayerID: 5, Rarity: store.Gold}).Return(players.CardConversion{
+					ID: 9, Kind: players.CraftConversion, ConsumedCards: []int{}, ProducedCardID: &cardID,
+					DustAmount: -200, DustBalanceAfter: 15, CreatedAt: createdAt,
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"id":9,"kind":"craft","consumedCards":[],"producedCardID":120,"dustAmount":-200,"dustBalanceAfter":15,"createdAt":"2024-06-19T10:00:00Z"}`,
+		},
+		{
+			name:      "Not enough dust",
+			inputBody: `{"playerID":5,"rarity":2}`,
+			mockBehavior: func(s *mock_service.MockCrafting) {
+				s.EXPECT().CraftCard(userID, players.CraftCardInput{PlayerID: 5, Rarity: store.Gold}).
+					Return(players.CardConversion{}, insufficientDust)
+			},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, insufficientDust),
+		},
+		{
+			name:      "Unknown player",
+			inputBody: `{"playerID":5,"rarity":1}`,
+			mockBehavior: func(s *mock_service.MockCrafting) {
+				s.EXPECT().CraftCard(userID, players.CraftCardInput{PlayerID: 5, Rarity: store.Silver}).
+					Return(players.CardConversion{}, storage.PlayerNotFoundError)
+			},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, storage.PlayerNotFoundError),
+		},
+		{
+			name:               "Unknown rarity",
+			inputBody:          `{"playerID":5,"rarity":3}`,
+			mockBehavior:       func(s *mock_service.MockCrafting) {},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, InvalidInputBodyError),
+		},
+		{
+			name:      "Service error",
+			inputBody: `{"playerID":5,"rarity":1}`,
+			mockBehavior: func(s *mock_service.MockCrafting) {
+				s.EXPECT().CraftCard(userID, players.CraftCardInput{PlayerID: 5, Rarity: store.Silver}).
+					Return(players.CardConversion{}, errors.New("something went wrong"))
+			},
+			expectedStatusCode: 500,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				InternalServerErrorTitle, InternalServerErrorMessage),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			crafting := mock_service.NewMockCrafting(c)
+			testCase.mockBehavior(crafting)
+
+			services := &service.Services{Crafting: crafting}
+			handler := Api{services: services}
+
+			r := gin.New()
+			r.POST("/crafting/craft", func(ctx *gin.Context) {
+				ctx.Set("userID", userID.String())
+			}, handler.craftCard)
+
+			w := httptest.NewRecorder()
+
+			req := httptest.NewRequest("POST", "/crafting/craft", bytes.NewBufferString(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, w.Code, testCase.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), testCase.expectedResponseBody)
+		})
+	}
+}
+
+func TestHandler_upgradeCards(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockCrafting)
+	userID, _ := uuid.Parse("6bc57ea9-c881-47d3-a293-b925ff1ddf72")
+
+	testTable := []struct {
+		name                 string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Wrong number of cards",
+			inputBody: `{"cardIDs":[1,2]}`,
+			mockBehavior: func(s *mock_service.MockCrafting) {
+				s.EXPECT().UpgradeCards(userID, players.UpgradeCardsInput{CardIDs: []int{1, 2}}).
+					Return(players.CardConversion{}, service.UpgradeCardsCountError)
+			},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, service.UpgradeCardsCountError),
+		},
+		{
+			name:      "Different players",
+			inputBody: `{"cardIDs":[1,2,3]}`,
+			mockBehavior: func(s *mock_service.MockCrafting) {
+				s.EXPECT().UpgradeCards(userID, players.UpgradeCardsInput{CardIDs: []int{1, 2, 3}}).
+					Return(players.CardConversion{}, storage.UpgradeCardsMismatchError)
+			},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, storage.UpgradeCardsMismatchError),
+		},
+		{
+			name:      "Listed card",
+			inputBody: `{"cardIDs":[1,2,3]}`,
+			mockBehavior: func(s *mock_service.MockCrafting) {
+				s.EXPECT().UpgradeCards(userID, players.UpgradeCardsInput{CardIDs: []int{1, 2, 3}}).
+					Return(players.CardConversion{}, storage.CardAlreadyListedError)
+			},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, storage.CardAlreadyListedError),
+		},
+		{
+			name:               "Duplicate cards",
+			inputBody:          `{"cardIDs":[1,1,2]}`,
+			mockBehavior:       func(s *mock_service.MockCrafting) {},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, InvalidInputBodyError),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			crafting := mock_service.NewMockCrafting(c)
+			testCase.mockBehavior(crafting)
+
+			services := &service.Services{Crafting: crafting}
+			handler := Api{services: services}
+
+			r := gin.New()
+			r.POST("/crafting/upgrade", func(ctx *gin.Context) {
+				ctx.Set("userID", userID.String())
+			}, handler.upgradeCards)
+
+			w := httptest.NewRecorder()
+
+			req := httptest.NewRequest("POST", "/crafting/upgrade", bytes.NewBufferString(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, w.Code, testCase.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), testCase.expectedResponseBody)
+		})
+	}
+}
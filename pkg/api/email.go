@@ -0,0 +1,143 @@
+package api
+
+import (
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/storage"
+	"github.com/gin-gonic/gin"
+	"log"
+	"net/http"
+)
+
+// changeEmail godoc
+// @Summary Запрос смены email
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Отправка кода подтверждения на новый email. Требуется текущий пароль
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param data body user.ChangeEmailInput true "Входные параметры"
+// @Success 200 {object} StatusResponse
+// @Failure 400,401 {object} Error
+// @Failure 429 {object} Error
+// @Failure 500 {object} Error
+// @Router /user/email/change [post]
+func (api Api) changeEmail(ctx *gin.Context) {
+	var inp user.ChangeEmailInput
+	if err := ctx.BindJSON(&inp); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputBodyError))
+		return
+	}
+
+	userID, err := parseUserIDFromContext(ctx)
+	if err != nil {
+		log.Println("ChangeEmail:", err)
+		return
+	}
+
+	err = api.services.User.RequestEmailChange(userID, inp)
+	if err != nil {
+		log.Println("ChangeEmail:", err)
+		if handleRateLimitError(ctx, err) {
+			return
+		}
+		switch err {
+		case service.IncorrectPasswordError,
+			service.SameEmailError,
+			storage.EmailTakenError,
+			storage.UserDoesNotExistError:
+			ctx.JSON(http.StatusBadRequest, getBadRequestError(err))
+			return
+		default:
+			ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, StatusResponse{"ок"})
+}
+
+// confirmEmailChange godoc
+// @Summary Подтверждение смены email
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Смена email по коду из письма. Все сессии пользователя завершаются, на старый email уходит ссылка для отмены
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param data body user.ConfirmEmailChangeInput true "Входные параметры"
+// @Success 200 {object} StatusResponse
+// @Failure 400,401 {object} Error
+// @Failure 500 {object} Error
+// @Router /user/email/confirm [post]
+func (api Api) confirmEmailChange(ctx *gin.Context) {
+	var inp user.ConfirmEmailChangeInput
+	if err := ctx.BindJSON(&inp); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputBodyError))
+		return
+	}
+
+	userID, err := parseUserIDFromContext(ctx)
+	if err != nil {
+		log.Println("ConfirmEmailChange:", err)
+		return
+	}
+
+	err = api.services.User.ConfirmEmailChange(userID, inp.Code)
+	if err != nil {
+		log.Println("ConfirmEmailChange:", err)
+		switch err {
+		case service.InvalidVerificationCodeError,
+			storage.VerificationCodeError,
+			storage.EmailChangeNotFoundError,
+			storage.EmailTakenError,
+			storage.EmailChangedError,
+			storage.UserDoesNotExistError:
+			ctx.JSON(http.StatusBadRequest, getBadRequestError(err))
+			return
+		default:
+			ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, StatusResponse{"ок"})
+}
+
+// undoEmailChange godoc
+// @Summary Отмена смены email
+// @Schemes
+// @Description Возврат прежнего email по ссылке из письма, отправленного на старый адрес. Все сессии пользователя завершаются
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param data body user.UndoEmailChangeInput true "Входные параметры"
+// @Success 200 {object} StatusResponse
+// @Failure 400 {object} Error
+// @Failure 500 {object} Error
+// @Router /user/email/undo [post]
+func (api Api) undoEmailChange(ctx *gin.Context) {
+	var inp user.UndoEmailChangeInput
+	if err := ctx.BindJSON(&inp); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputBodyError))
+		return
+	}
+
+	err := api.services.User.UndoEmailChange(inp.Token)
+	if err != nil {
+		log.Println("UndoEmailChange:", err)
+		switch err {
+		case storage.EmailChangeUndoError,
+			storage.EmailTakenError,
+			storage.EmailChangedError:
+			ctx.JSON(http.StatusBadRequest, getBadRequestError(err))
+			return
+		default:
+			ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, StatusResponse{"ок"})
+}
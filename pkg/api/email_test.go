@@ -0,0 +1,110 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service"
+	mock_service "github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service/mocks"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_changeEmail(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockUser, userID uuid.UUID, inp user.ChangeEmailInput)
+	userID, _ := uuid.Parse("6bc57ea9-c881-47d3-a293-b925ff1ddf72")
+
+	testTable := []struct {
+		name                 string
+		inputBody            string
+		inputData            user.ChangeEmailInput
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "OK",
+			inputBody: `{"newEmail":"new@mail.ru","password":"Qwerty123"}`,
+			inputData: user.ChangeEmailInput{NewEmail: "new@mail.ru", Password: "Qwerty123"},
+			mockBehavior: func(s *mock_service.MockUser, userID uuid.UUID, inp user.ChangeEmailInput) {
+				s.EXPECT().RequestEmailChange(userID, inp).Return(nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"ок"}`,
+		},
+		{
+			name:               "Invalid email",
+			inputBody:          `{"newEmail":"new","password":"Qwerty123"}`,
+			mockBehavior:       func(s *mock_service.MockUser, userID uuid.UUID, inp user.ChangeEmailInput) {},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, InvalidInputBodyError),
+		},
+		{
+			name:      "Email taken",
+			inputBody: `{"newEmail":"new@mail.ru","password":"Qwerty123"}`,
+			inputData: user.ChangeEmailInput{NewEmail: "new@mail.ru", Password: "Qwerty123"},
+			mockBehavior: func(s *mock_service.MockUser, userID uuid.UUID, inp user.ChangeEmailInput) {
+				s.EXPECT().RequestEmailChange(userID, inp).Return(storage.EmailTakenError)
+			},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, storage.EmailTakenError),
+		},
+		{
+			name:      "Incorrect password",
+			inputBody: `{"newEmail":"new@mail.ru","password":"Qwerty123"}`,
+			inputData: user.ChangeEmailInput{NewEmail: "new@mail.ru", Password: "Qwerty123"},
+			mockBehavior: func(s *mock_service.MockUser, userID uuid.UUID, inp user.ChangeEmailInput) {
+				s.EXPECT().RequestEmailChange(userID, inp).Return(service.IncorrectPasswordError)
+			},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, service.IncorrectPasswordError),
+		},
+		{
+			name:      "Service error",
+			inputBody: `{"newEmail":"new@mail.ru","password":"Qwerty123"}`,
+			inputData: user.ChangeEmailInput{NewEmail: "new@mail.ru", Password: "Qwerty123"},
+			mockBehavior: func(s *mock_service.MockUser, userID uuid.UUID, inp user.ChangeEmailInput) {
+				s.EXPECT().RequestEmailChange(userID, inp).Return(errors.New("something went wrong"))
+			},
+			expectedStatusCode: 500,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				InternalServerErrorTitle, InternalServerErrorMessage),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			user := mock_service.NewMockUser(c)
+			testCase.mockBehavior(user, userID, testCase.inputData)
+
+			services := &service.Services{User: user}
+			handler := Api{services: services}
+
+			r := gin.New()
+			r.POST("/user/email/change", func(ctx *gin.Context) {
+				ctx.Set("userID", userID.String())
+			}, handler.changeEmail)
+
+			w := httptest.NewRecorder()
+
+			req := httptest.NewRequest("POST", "/user/email/change", bytes.NewBufferString(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, w.Code, testCase.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), testCase.expectedResponseBody)
+		})
+	}
+}
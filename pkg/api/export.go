@@ -0,0 +1,121 @@
+package api
+
+import (
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"log"
+	"net/http"
+)
+
+// requestDataExport godoc
+// @Summary Запрос выгрузки персональных данных
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Ставит в очередь сборку архива со всеми данными пользователя. Когда архив готов, на почту приходит ссылка для скачивания
+// @Tags user
+// @Produce json
+// @Success 202 {object} user.DataExport
+// @Failure 401 {object} Error
+// @Failure 500 {object} Error
+// @Router /user/exports [post]
+func (api Api) requestDataExport(ctx *gin.Context) {
+	userID, err := parseUserIDFromContext(ctx)
+	if err != nil {
+		log.Println("RequestDataExport:", err)
+		return
+	}
+
+	export, err := api.services.Exports.RequestDataExport(userID)
+	if err != nil {
+		log.Println("RequestDataExport:", err)
+		ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, export)
+}
+
+// getDataExport godoc
+// @Summary Статус выгрузки персональных данных
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Статус выгрузки. Для готовой выгрузки возвращается ссылка на скачивание с ограниченным сроком действия
+// @Tags user
+// @Produce json
+// @Param id path string true "ID выгрузки"
+// @Success 200 {object} user.DataExport
+// @Failure 400,401,404 {object} Error
+// @Failure 500 {object} Error
+// @Router /user/exports/{id} [get]
+func (api Api) getDataExport(ctx *gin.Context) {
+	var inp user.DataExportIDInput
+	if err := ctx.ShouldBindUri(&inp); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputParametersError))
+		return
+	}
+
+	userID, err := parseUserIDFromContext(ctx)
+	if err != nil {
+		log.Println("GetDataExport:", err)
+		return
+	}
+
+	export, err := api.services.Exports.GetDataExport(userID, uuid.MustParse(inp.ID))
+	if err != nil {
+		log.Println("GetDataExport:", err)
+		switch err {
+		case storage.DataExportNotFoundError:
+			ctx.JSON(http.StatusNotFound, getNotFoundError())
+			return
+		default:
+			ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, export)
+}
+
+// downloadDataExport godoc
+// @Summary Скачивание выгрузки персональных данных
+// @Schemes
+// @Description Отдает zip архив по подписанной ссылке из письма или из статуса выгрузки
+// @Tags user
+// @Produce application/zip
+// @Param id query string true "ID выгрузки"
+// @Param expires query int true "Срок действия ссылки"
+// @Param signature query string true "Подпись"
+// @Success 200 {file} binary
+// @Failure 400,404 {object} Error
+// @Failure 500 {object} Error
+// @Router /user/exports/download [get]
+func (api Api) downloadDataExport(ctx *gin.Context) {
+	var inp user.DataExportDownloadInput
+	if err := ctx.ShouldBindQuery(&inp); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputParametersError))
+		return
+	}
+
+	file, info, err := api.services.Exports.OpenDataExport(inp)
+	if err != nil {
+		log.Println("DownloadDataExport:", err)
+		switch err {
+		case service.InvalidDownloadLinkError,
+			storage.DataExportNotFoundError:
+			ctx.JSON(http.StatusNotFound, getNotFoundError())
+			return
+		default:
+			ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+			return
+		}
+	}
+	defer file.Close()
+
+	ctx.DataFromReader(http.StatusOK, info.Size, "application/zip", file, map[string]string{
+		"Content-Disposition": `attachment; filename="frozen-fantasy-data.zip"`,
+		"Cache-Control":       "no-store",
+	})
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/store"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/storage"
+	"github.com/gin-gonic/gin"
+	"log"
+	"net/http"
+)
+
+// getPackSeed godoc
+// @Summary Текущая пара сидов для открытия наборов
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Хэш сида сервера, сид клиента и nonce, которыми будет открыт следующий набор. Сам сид сервера раскрывается при смене пары
+// @Tags store
+// @Accept json
+// @Produce json
+// @Success 200 {object} store.PackSeed
+// @Failure 401 {object} Error
+// @Failure 500 {object} Error
+// @Router /store/fairness/seed [get]
+func (api Api) getPackSeed(ctx *gin.Context) {
+	userID, err := parseUserIDFromContext(ctx)
+	if err != nil {
+		log.Println("GetPackSeed:", err)
+		return
+	}
+
+	seed, err := api.services.Store.GetPackSeed(userID)
+	if err != nil {
+		log.Println("GetPackSeed:", err)
+		ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, seed)
+}
+
+// rotatePackSeed godoc
+// @Summary Смена пары сидов
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Раскрывает текущий сид сервера, чтобы по нему можно было проверить открытые наборы, и начинает новую пару с переданным сидом клиента
+// @Tags store
+// @Accept json
+// @Produce json
+// @Param data body store.RotatePackSeedInput true "Входные параметры"
+// @Success 200 {object} store.PackSeedRotation
+// @Failure 400,401 {object} Error
+// @Failure 500 {object} Error
+// @Router /store/fairness/seed/rotate [post]
+func (api Api) rotatePackSeed(ctx *gin.Context) {
+	userID, err := parseUserIDFromContext(ctx)
+	if err != nil {
+		log.Println("RotatePackSeed:", err)
+		return
+	}
+
+	var inp store.RotatePackSeedInput
+	if err = ctx.BindJSON(&inp); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputBodyError))
+		return
+	}
+
+	rotation, err := api.services.Store.RotatePackSeed(userID, inp)
+	if err != nil {
+		log.Println("RotatePackSeed:", err)
+		ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rotation)
+}
+
+// verifyPackOpening godoc
+// @Summary Проверка открытия набора
+// @Schemes
+// @Description Пересчитывает набор по раскрытым сидам: seed = HMAC-SHA256(serverSeed, "clientSeed:nonce"), первые 8 байт seed засевают генератор розыгрыша. Доступно после смены пары сидов, которой был открыт набор
+// @Tags store
+// @Accept json
+// @Produce json
+// @Param id path int true "id открытия набора (packOpeningID карточки)"
+// @Success 200 {object} store.PackVerification
+// @Failure 400,404 {object} Error
+// @Failure 500 {object} Error
+// @Router /store/openings/{id}/verify [get]
+func (api Api) verifyPackOpening(ctx *gin.Context) {
+	var inp store.PackOpeningIDInput
+	if err := ctx.ShouldBindUri(&inp); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputParametersError))
+		return
+	}
+
+	verification, err := api.services.Store.VerifyPackOpening(inp.ID)
+	if err != nil {
+		log.Println("VerifyPackOpening:", err)
+		switch err {
+		case storage.PackOpeningNotFoundError:
+			ctx.JSON(http.StatusNotFound, getNotFoundError())
+			return
+		case storage.PackSeedNotRevealedError:
+			ctx.JSON(http.StatusBadRequest, getBadRequestError(err))
+			return
+		default:
+			ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, verification)
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/store"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service"
+	mock_service "github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service/mocks"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHandler_verifyPackOpening(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockStore)
+	openedAt, _ := time.Parse(time.RFC3339, "2024-06-16T12:00:00Z")
+	cards := store.PackCards{{PlayerID: 17, Rarity: store.Gold, Position: 3}}
+
+	testTable := []struct {
+		name                 string
+		path                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "OK",
+			path: "/store/openings/5/verify",
+			mockBehavior: func(s *mock_service.MockStore) {
+				s.EXPECT().VerifyPackOpening(int64(5)).Return(store.PackVerification{
+					OpeningID: 5, ProductID: 1, OpenedAt: openedAt, ServerSeed: "s", ServerSeedHash: "h",
+					ClientSeed: "c", Nonce: 3, Seed: "ab", DropTable: store.DefaultDropTable(store.Gold),
+					Cards: cards, DerivedCards: cards, Verified: true,
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"openingID":5,"productID":1,"openedAt":"2024-06-16T12:00:00Z","serverSeed":"s","serverSeedHash":"h","clientSeed":"c","nonce":3,"seed":"ab","dropTable":{"rarityWeights":[{"rarity":2,"weight":1}],"guarantees":null,"positionQuotas":null,"starCost":0,"starChance":0},"cards":[{"playerID":17,"rarity":2,"position":3}],"derivedCards":[{"playerID":17,"rarity":2,"position":3}],"verified":true}`,
+		},
+		{
+			name: "Seed not revealed",
+			path: "/store/openings/6/verify",
+			mockBehavior: func(s *mock_service.MockStore) {
+				s.EXPECT().VerifyPackOpening(int64(6)).Return(store.PackVerification{}, storage.PackSeedNotRevealedError)
+			},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, storage.PackSeedNotRevealedError),
+		},
+		{
+			name: "Not found",
+			path: "/store/openings/7/verify",
+			mockBehavior: func(s *mock_service.MockStore) {
+				s.EXPECT().VerifyPackOpening(int64(7)).Return(store.PackVerification{}, storage.PackOpeningNotFoundError)
+			},
+			expectedStatusCode: 404,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, NotFoundErrorMessage),
+		},
+		{
+			name: "Service error",
+			path: "/store/openings/5/verify",
+			mockBehavior: func(s *mock_service.MockStore) {
+				s.EXPECT().VerifyPackOpening(int64(5)).Return(store.PackVerification{}, errors.New("something went wrong"))
+			},
+			expectedStatusCode: 500,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				InternalServerErrorTitle, InternalServerErrorMessage),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			storeService := mock_service.NewMockStore(c)
+			testCase.mockBehavior(storeService)
+
+			services := &service.Services{Store: storeService}
+			handler := Api{services: services}
+
+			r := gin.New()
+			r.GET("/store/openings/:id/verify", handler.verifyPackOpening)
+
+			w := httptest.NewRecorder()
+
+			req := httptest.NewRequest("GET", testCase.path, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, w.Code, testCase.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), testCase.expectedResponseBody)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service"
+	"github.com/gin-gonic/gin"
+	"io"
+	"log"
+	"net/http"
+)
+
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// idempotencyWriter копирует тело ответа, чтобы сохранить его для повторных запросов
+type idempotencyWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *idempotencyWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *idempotencyWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// idempotent делает обработчик идемпотентным по заголовку Idempotency-Key: первый ответ сохраняется
+// и отдается повторным запросам того же пользователя с тем же ключом, а параллельный дубль получает 409.
+// Запросы без заголовка обрабатываются как обычно. Используется после userIdentity
+func (api Api) idempotent(ctx *gin.Context) {
+	key := ctx.GetHeader(IdempotencyKeyHeader)
+	if key == "" {
+		return
+	}
+
+	userID, err := parseUserIDFromContext(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, getInternalServerError())
+		return
+	}
+
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, getBadRequestError(InvalidInputBodyError))
+		return
+	}
+	ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	hash := sha256.New()
+	hash.Write([]byte(ctx.Request.Method + " " + ctx.Request.URL.RequestURI() + "\n"))
+	hash.Write(body)
+	fingerprint := hex.EncodeToString(hash.Sum(nil))
+
+	saved, err := api.services.Idempotency.Begin(userID, key, fingerprint)
+	if err != nil {
+		switch err {
+		case service.InvalidIdempotencyKeyError:
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, getBadRequestError(err))
+		case service.IdempotencyKeyInProgressError:
+			ctx.AbortWithStatusJSON(http.StatusConflict, getBadRequestError(err))
+		case service.IdempotencyKeyMismatchError:
+			ctx.AbortWithStatusJSON(http.StatusUnprocessableEntity, getBadRequestError(err))
+		default:
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, getInternalServerError())
+		}
+		return
+	}
+	if saved != nil {
+		ctx.Header("Idempotent-Replayed", "true")
+		ctx.Data(saved.StatusCode, saved.ContentType, saved.Body)
+		ctx.Abort()
+		return
+	}
+
+	writer := &idempotencyWriter{ResponseWriter: ctx.Writer}
+	ctx.Writer = writer
+	stop := api.services.Idempotency.Hold(userID, key, fingerprint)
+	defer stop()
+
+	// Паника обработчика перехватывается Recovery выше по цепочке, поэтому ключ освобождается здесь,
+	// иначе повторы с этим ключом навсегда получали бы 409
+	defer func() {
+		if r := recover(); r != nil {
+			if err := api.services.Idempotency.Release(userID, key); err != nil {
+				log.Println("Idempotency:", err)
+			}
+			panic(r)
+		}
+	}()
+
+	ctx.Next()
+
+	// После ошибки сервера списания не было, ключ освобождается, чтобы запрос можно было повторить
+	if writer.Status() >= http.StatusInternalServerError {
+		if err = api.services.Idempotency.Release(userID, key); err != nil {
+			log.Println("Idempotency:", err)
+		}
+		return
+	}
+
+	err = api.services.Idempotency.Complete(userID, key, user.IdempotentRequest{
+		Fingerprint: fingerprint,
+		StatusCode:  writer.Status(),
+		ContentType: writer.Header().Get("Content-Type"),
+		Body:        writer.body.Bytes(),
+	})
+	if err != nil {
+		log.Println("Idempotency:", err)
+	}
+}
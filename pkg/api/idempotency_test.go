@@ -0,0 +1,188 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/store"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service"
+	mock_service "github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service/mocks"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_idempotent(t *testing.T) {
+	type mockBehavior func(i *mock_service.MockIdempotency, s *mock_service.MockStore)
+	userID, _ := uuid.Parse("6bc57ea9-c881-47d3-a293-b925ff1ddf72")
+	insufficientFunds := &storage.InsufficientFundsError{ProfileID: userID, Balance: 100, Required: 500}
+	buy := store.BuyProductModel{ID: 1, ProfileID: userID}
+	key := "a6f1c0e2-buy-1"
+
+	testTable := []struct {
+		name                 string
+		idempotencyKey       string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+		expectedReplayed     string
+	}{
+		{
+			name: "Without key",
+			mockBehavior: func(i *mock_service.MockIdempotency, s *mock_service.MockStore) {
+				s.EXPECT().BuyProduct(buy).Return(nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"ок"}`,
+		},
+		{
+			name:           "First request",
+			idempotencyKey: key,
+			mockBehavior: func(i *mock_service.MockIdempotency, s *mock_service.MockStore) {
+				i.EXPECT().Begin(userID, key, gomock.Any()).Return(nil, nil)
+				i.EXPECT().Hold(userID, key, gomock.Any()).Return(func() {})
+				s.EXPECT().BuyProduct(buy).Return(nil)
+				i.EXPECT().Complete(userID, key, gomock.Any()).DoAndReturn(
+					func(_ uuid.UUID, _ string, resp user.IdempotentRequest) error {
+						assert.Equal(t, 200, resp.StatusCode)
+						assert.Equal(t, `{"status":"ок"}`, string(resp.Body))
+						assert.NotEmpty(t, resp.Fingerprint)
+						return nil
+					})
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"ок"}`,
+		},
+		{
+			name:           "Business error is saved",
+			idempotencyKey: key,
+			mockBehavior: func(i *mock_service.MockIdempotency, s *mock_service.MockStore) {
+				i.EXPECT().Begin(userID, key, gomock.Any()).Return(nil, nil)
+				i.EXPECT().Hold(userID, key, gomock.Any()).Return(func() {})
+				s.EXPECT().BuyProduct(buy).Return(insufficientFunds)
+				i.EXPECT().Complete(userID, key, gomock.Any()).Return(nil)
+			},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, insufficientFunds),
+		},
+		{
+			name:           "Replay",
+			idempotencyKey: key,
+			mockBehavior: func(i *mock_service.MockIdempotency, s *mock_service.MockStore) {
+				i.EXPECT().Begin(userID, key, gomock.Any()).Return(&user.IdempotentRequest{
+					Status:      user.IdempotencyCompleted,
+					StatusCode:  200,
+					ContentType: "application/json; charset=utf-8",
+					Body:        []byte(`{"status":"ок"}`),
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"ок"}`,
+			expectedReplayed:     "true",
+		},
+		{
+			name:           "In progress",
+			idempotencyKey: key,
+			mockBehavior: func(i *mock_service.MockIdempotency, s *mock_service.MockStore) {
+				i.EXPECT().Begin(userID, key, gomock.Any()).Return(nil, service.IdempotencyKeyInProgressError)
+			},
+			expectedStatusCode: 409,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, service.IdempotencyKeyInProgressError),
+		},
+		{
+			name:           "Key reused for another request",
+			idempotencyKey: key,
+			mockBehavior: func(i *mock_service.MockIdempotency, s *mock_service.MockStore) {
+				i.EXPECT().Begin(userID, key, gomock.Any()).Return(nil, service.IdempotencyKeyMismatchError)
+			},
+			expectedStatusCode: 422,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, service.IdempotencyKeyMismatchError),
+		},
+		{
+			name:           "Server error releases key",
+			idempotencyKey: key,
+			mockBehavior: func(i *mock_service.MockIdempotency, s *mock_service.MockStore) {
+				i.EXPECT().Begin(userID, key, gomock.Any()).Return(nil, nil)
+				i.EXPECT().Hold(userID, key, gomock.Any()).Return(func() {})
+				s.EXPECT().BuyProduct(buy).Return(errors.New("something went wrong"))
+				i.EXPECT().Release(userID, key).Return(nil)
+			},
+			expectedStatusCode: 500,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				InternalServerErrorTitle, InternalServerErrorMessage),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			idempotency := mock_service.NewMockIdempotency(c)
+			store := mock_service.NewMockStore(c)
+			testCase.mockBehavior(idempotency, store)
+
+			services := &service.Services{Idempotency: idempotency, Store: store}
+			handler := Api{services: services}
+
+			r := gin.New()
+			r.POST("/store/products/buy", func(ctx *gin.Context) {
+				ctx.Set("userID", userID.String())
+			}, handler.idempotent, handler.buyProduct)
+
+			w := httptest.NewRecorder()
+
+			req := httptest.NewRequest("POST", "/store/products/buy?id=1", nil)
+			if testCase.idempotencyKey != "" {
+				req.Header.Set(IdempotencyKeyHeader, testCase.idempotencyKey)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, w.Code, testCase.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), testCase.expectedResponseBody)
+			assert.Equal(t, w.Header().Get("Idempotent-Replayed"), testCase.expectedReplayed)
+		})
+	}
+}
+
+func TestHandler_idempotentPanic(t *testing.T) {
+	userID, _ := uuid.Parse("6bc57ea9-c881-47d3-a293-b925ff1ddf72")
+	key := "buy-1"
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	stopped := false
+	idempotency := mock_service.NewMockIdempotency(c)
+	idempotency.EXPECT().Begin(userID, key, gomock.Any()).Return(nil, nil)
+	idempotency.EXPECT().Hold(userID, key, gomock.Any()).Return(func() { stopped = true })
+	idempotency.EXPECT().Release(userID, key).Return(nil)
+
+	handler := Api{services: &service.Services{Idempotency: idempotency}}
+
+	r := gin.New()
+	r.Use(gin.Recovery())
+	r.POST("/store/products/buy", func(ctx *gin.Context) {
+		ctx.Set("userID", userID.String())
+	}, handler.idempotent, func(ctx *gin.Context) {
+		panic("something went wrong")
+	})
+
+	w := httptest.NewRecorder()
+
+	req := httptest.NewRequest("POST", "/store/products/buy?id=1", nil)
+	req.Header.Set(IdempotencyKeyHeader, key)
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, 500, w.Code)
+	assert.True(t, stopped)
+}
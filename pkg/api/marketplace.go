@@ -0,0 +1,172 @@
+package api
+
+import (
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/players"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/storage"
+	"github.com/gin-gonic/gin"
+	"log"
+	"net/http"
+)
+
+// getListings godoc
+// @Summary Объявления рынка карточек
+// @Schemes
+// @Description Активные объявления от новых к старым. Для следующей страницы передайте в before id последнего объявления
+// @Tags marketplace
+// @Accept json
+// @Produce json
+// @Param league query int false "Лига" Enums(1, 2)
+// @Param position query int false "Позиция" Enums(1, 2, 3)
+// @Param rarity query int false "Редкость" Enums(1, 2)
+// @Param team query int false "id команды"
+// @Param before query int false "id последнего объявления предыдущей страницы"
+// @Param limit query int false "Размер страницы, по умолчанию 20, максимум 100"
+// @Success 200 {array} players.CardListingResponse
+// @Failure 400 {object} Error
+// @Failure 500 {object} Error
+// @Router /marketplace/listings [get]
+func (api Api) getListings(ctx *gin.Context) {
+	var filter players.CardListingsFilter
+	if err := ctx.BindQuery(&filter); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputParametersError))
+		return
+	}
+
+	listings, err := api.services.Marketplace.GetListings(filter)
+	if err != nil {
+		log.Println("GetListings:", err)
+		ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, listings)
+}
+
+// createListing godoc
+// @Summary Выставление карточки на продажу
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Выставить можно только свою распакованную карточку, игрок которой не стоит в составе незавершенного турнира
+// @Tags marketplace
+// @Accept json
+// @Produce json
+// @Param data body players.CreateListingInput true "Входные параметры"
+// @Success 200 {object} players.CardListing
+// @Failure 400,401,404 {object} Error
+// @Failure 500 {object} Error
+// @Router /marketplace/listings [post]
+func (api Api) createListing(ctx *gin.Context) {
+	userID, err := parseUserIDFromContext(ctx)
+	if err != nil {
+		log.Println("CreateListing:", err)
+		return
+	}
+
+	var inp players.CreateListingInput
+	if err = ctx.BindJSON(&inp); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputBodyError))
+		return
+	}
+
+	listing, err := api.services.Marketplace.CreateListing(userID, inp)
+	if err != nil {
+		log.Println("CreateListing:", err)
+		handleListingError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, listing)
+}
+
+// cancelListing godoc
+// @Summary Снятие карточки с продажи
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Снятие своего активного объявления
+// @Tags marketplace
+// @Accept json
+// @Produce json
+// @Param id path int true "id объявления"
+// @Success 200 {object} StatusResponse
+// @Failure 400,401,404 {object} Error
+// @Failure 500 {object} Error
+// @Router /marketplace/listings/{id} [delete]
+func (api Api) cancelListing(ctx *gin.Context) {
+	userID, err := parseUserIDFromContext(ctx)
+	if err != nil {
+		log.Println("CancelListing:", err)
+		return
+	}
+
+	var inp players.ListingIDInput
+	if err = ctx.ShouldBindUri(&inp); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputParametersError))
+		return
+	}
+
+	err = api.services.Marketplace.CancelListing(userID, inp.ID)
+	if err != nil {
+		log.Println("CancelListing:", err)
+		handleListingError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, StatusResponse{"ок"})
+}
+
+// buyListing godoc
+// @Summary Покупка карточки на рынке
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Покупатель платит цену объявления, продавец получает ее за вычетом комиссии площадки, карточка переходит покупателю
+// @Tags marketplace
+// @Accept json
+// @Produce json
+// @Param id path int true "id объявления"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор с тем же ключом вернет сохраненный ответ"
+// @Success 200 {object} players.CardListing
+// @Failure 400,401,404 {object} Error
+// @Failure 409,422 {object} Error
+// @Failure 500 {object} Error
+// @Router /marketplace/listings/{id}/buy [post]
+func (api Api) buyListing(ctx *gin.Context) {
+	userID, err := parseUserIDFromContext(ctx)
+	if err != nil {
+		log.Println("BuyListing:", err)
+		return
+	}
+
+	var inp players.ListingIDInput
+	if err = ctx.ShouldBindUri(&inp); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputParametersError))
+		return
+	}
+
+	listing, err := api.services.Marketplace.BuyListing(userID, inp.ID)
+	if err != nil {
+		log.Println("BuyListing:", err)
+		if handleInsufficientFundsError(ctx, err) {
+			return
+		}
+		handleListingError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, listing)
+}
+
+func handleListingError(ctx *gin.Context, err error) {
+	switch err {
+	case storage.ListingNotFoundError,
+		storage.PlayerCardNotFoundError:
+		ctx.JSON(http.StatusNotFound, getNotFoundError())
+	case storage.IncorrectPlayerCardUserID,
+		storage.CardNotUnpackedError,
+		storage.CardInActiveRosterError,
+		storage.CardAlreadyListedError,
+		storage.OwnListingPurchaseError:
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(err))
+	default:
+		ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+	}
+}
@@ -0,0 +1,178 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/players"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service"
+	mock_service "github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service/mocks"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHandler_buyListing(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockMarketplace)
+	userID, _ := uuid.Parse("6bc57ea9-c881-47d3-a293-b925ff1ddf72")
+	sellerID, _ := uuid.Parse("0f8fad5b-d9cb-469f-a165-70867728950e")
+	createdAt, _ := time.Parse(time.RFC3339, "2024-06-17T10:00:00Z")
+	closedAt := createdAt.Add(time.Hour)
+	insufficientFunds := &storage.InsufficientFundsError{ProfileID: userID, Balance: 100, Required: 500}
+
+	testTable := []struct {
+		name                 string
+		path                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "OK",
+			path: "/marketplace/listings/3/buy",
+			mockBehavior: func(s *mock_service.MockMarketplace) {
+				s.EXPECT().BuyListing(userID, int64(3)).Return(players.CardListing{
+					ID: 3, CardID: 40, SellerID: sellerID, Price: 500, Status: players.SoldListing,
+					BuyerID: &userID, Fee: 25, CreatedAt: createdAt, ClosedAt: &closedAt,
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"id":3,"cardID":40,"sellerID":"0f8fad5b-d9cb-469f-a165-70867728950e","price":500,"status":"sold","buyerID":"6bc57ea9-c881-47d3-a293-b925ff1ddf72","fee":25,"createdAt":"2024-06-17T10:00:00Z","closedAt":"2024-06-17T11:00:00Z"}`,
+		},
+		{
+			name: "Not enough coins",
+			path: "/marketplace/listings/3/buy",
+			mockBehavior: func(s *mock_service.MockMarketplace) {
+				s.EXPECT().BuyListing(userID, int64(3)).Return(players.CardListing{}, insufficientFunds)
+			},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, insufficientFunds),
+		},
+		{
+			name: "Card in active roster",
+			path: "/marketplace/listings/3/buy",
+			mockBehavior: func(s *mock_service.MockMarketplace) {
+				s.EXPECT().BuyListing(userID, int64(3)).Return(players.CardListing{}, storage.CardInActiveRosterError)
+			},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, storage.CardInActiveRosterError),
+		},
+		{
+			name: "Listing closed",
+			path: "/marketplace/listings/3/buy",
+			mockBehavior: func(s *mock_service.MockMarketplace) {
+				s.EXPECT().BuyListing(userID, int64(3)).Return(players.CardListing{}, storage.ListingNotFoundError)
+			},
+			expectedStatusCode: 404,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, NotFoundErrorMessage),
+		},
+		{
+			name:               "Invalid id",
+			path:               "/marketplace/listings/abc/buy",
+			mockBehavior:       func(s *mock_service.MockMarketplace) {},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, InvalidInputParametersError),
+		},
+		{
+			name: "Service error",
+			path: "/marketplace/listings/3/buy",
+			mockBehavior: func(s *mock_service.MockMarketplace) {
+				s.EXPECT().BuyListing(userID, int64(3)).Return(players.CardListing{}, errors.New("something went wrong"))
+			},
+			expectedStatusCode: 500,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				InternalServerErrorTitle, InternalServerErrorMessage),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			marketplace := mock_service.NewMockMarketplace(c)
+			testCase.mockBehavior(marketplace)
+
+			services := &service.Services{Marketplace: marketplace}
+			handler := Api{services: services}
+
+			r := gin.New()
+			r.POST("/marketplace/listings/:id/buy", func(ctx *gin.Context) {
+				ctx.Set("userID", userID.String())
+			}, handler.buyListing)
+
+			w := httptest.NewRecorder()
+
+			req := httptest.NewRequest("POST", testCase.path, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, w.Code, testCase.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), testCase.expectedResponseBody)
+		})
+	}
+}
+
+func TestHandler_getListings(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockMarketplace)
+
+	testTable := []struct {
+		name                 string
+		query                string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "Filters",
+			query: "?league=1&position=3&rarity=2&team=12&before=50",
+			mockBehavior: func(s *mock_service.MockMarketplace) {
+				s.EXPECT().GetListings(players.CardListingsFilter{League: 1, Position: players.Forward, Rarity: 2,
+					TeamID: 12, Before: 50}).Return([]players.CardListingResponse{}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `[]`,
+		},
+		{
+			name:               "Invalid position",
+			query:              "?position=7",
+			mockBehavior:       func(s *mock_service.MockMarketplace) {},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, InvalidInputParametersError),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			marketplace := mock_service.NewMockMarketplace(c)
+			testCase.mockBehavior(marketplace)
+
+			services := &service.Services{Marketplace: marketplace}
+			handler := Api{services: services}
+
+			r := gin.New()
+			r.GET("/marketplace/listings", handler.getListings)
+
+			w := httptest.NewRecorder()
+
+			req := httptest.NewRequest("GET", "/marketplace/listings"+testCase.query, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, w.Code, testCase.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), testCase.expectedResponseBody)
+		})
+	}
+}
@@ -2,38 +2,116 @@ package api
 
 import (
 	"errors"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/config"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
 	user_service "github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/storage"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
 )
 
 func (api Api) userIdentity(ctx *gin.Context) {
-	id, err := api.parseAuthHeader(ctx)
+	claims, err := api.parseAuthHeader(ctx)
 	if err != nil {
 		log.Println("Authorization:", err)
-		ctx.JSON(http.StatusUnauthorized, getUnauthorizedError(err))
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, getUnauthorizedError(err))
 		return
 	}
 
-	ctx.Set("userID", id)
+	profileID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		log.Println("Authorization:", err)
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, getUnauthorizedError(user_service.InvalidAccessTokenError))
+		return
+	}
+
+	err = api.services.User.CheckProfileActive(profileID)
+	if err != nil {
+		log.Println("Authorization:", err)
+		switch err {
+		case user_service.ProfileDeletedError,
+			storage.UserDoesNotExistError:
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, getUnauthorizedError(err))
+		default:
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, getInternalServerError())
+		}
+		return
+	}
+
+	ctx.Set("userID", claims.UserID)
+	ctx.Set("roles", claims.Roles)
 }
 
-func (api Api) parseAuthHeader(ctx *gin.Context) (string, error) {
+// requireRole пропускает запрос только если в access токене есть указанная роль.
+// Используется после userIdentity
+func (api Api) requireRole(role string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		roles, _ := ctx.Get("roles")
+		claims := user.AccessTokenClaims{}
+		claims.Roles, _ = roles.([]string)
+
+		if !claims.HasRole(role) {
+			log.Println("Authorization:", AccessDeniedError)
+			ctx.AbortWithStatusJSON(http.StatusForbidden, getForbiddenError(AccessDeniedError))
+			return
+		}
+	}
+}
+
+// rateLimit ограничивает количество запросов к эндпоинту с одного IP
+func (api Api) rateLimit(name string, limit config.RateLimit) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		err := api.services.RateLimiter.Allow(name+"_ip_"+ctx.ClientIP(), limit)
+		if err != nil {
+			log.Println("RateLimit:", err)
+			if !handleRateLimitError(ctx, err) {
+				ctx.AbortWithStatusJSON(http.StatusInternalServerError, getInternalServerError())
+			}
+			return
+		}
+	}
+}
+
+// handleRateLimitError отвечает 429 с заголовком Retry-After, если err - ошибка превышения лимита
+func handleRateLimitError(ctx *gin.Context, err error) bool {
+	var rateLimitErr *user_service.RateLimitError
+	if !errors.As(err, &rateLimitErr) {
+		return false
+	}
+
+	ctx.Header("Retry-After", strconv.Itoa(rateLimitErr.RetrySeconds()))
+	ctx.AbortWithStatusJSON(http.StatusTooManyRequests, getTooManyRequestsError(err))
+	return true
+}
+
+// handleInsufficientFundsError отвечает 400, если err - ошибка нехватки монет на балансе
+func handleInsufficientFundsError(ctx *gin.Context, err error) bool {
+	var fundsErr *storage.InsufficientFundsError
+	if !errors.As(err, &fundsErr) {
+		return false
+	}
+
+	ctx.JSON(http.StatusBadRequest, getBadRequestError(err))
+	return true
+}
+
+func (api Api) parseAuthHeader(ctx *gin.Context) (user.AccessTokenClaims, error) {
 	header := ctx.GetHeader("Authorization")
 	if header == "" {
-		return "", user_service.AuthHeaderError
+		return user.AccessTokenClaims{}, user_service.AuthHeaderError
 	}
 
 	headerParts := strings.Split(header, " ")
 	if len(headerParts) != 2 || headerParts[0] != "Bearer" {
-		return "", user_service.InvalidAuthHeaderError
+		return user.AccessTokenClaims{}, user_service.InvalidAuthHeaderError
 	}
 
 	if len(headerParts[1]) == 0 {
-		return "", user_service.EmptyTokenError
+		return user.AccessTokenClaims{}, user_service.EmptyTokenError
 	}
 
 	return api.services.TokenManager.ParseJWT(headerParts[1])
@@ -53,12 +131,24 @@ func parseUserIDFromContext(c *gin.Context) (uuid.UUID, error) {
 	return parsedUserID, nil
 }
 
+func getDeviceInfo(c *gin.Context) user.DeviceInfo {
+	userAgent := c.Request.UserAgent()
+	if len(userAgent) > 512 {
+		userAgent = userAgent[:512]
+	}
+
+	return user.DeviceInfo{
+		UserAgent: userAgent,
+		IP:        c.ClientIP(),
+	}
+}
+
 func CORSMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, Idempotency-Key")
+		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, PATCH, DELETE")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -27,6 +27,7 @@ import (
 // @Produce json
 // @Success 200 {object} StatusResponse
 // @Failure 401 {object} Error
+// @Failure 403 {object} Error
 // @Failure 500 {object} Error
 // @Router /players/khl/create [post]
 func (api Api) createKHLPlayers(ctx *gin.Context) {
@@ -112,6 +113,7 @@ func (api Api) createKHLPlayers(ctx *gin.Context) {
 // @Produce json
 // @Success 200 {object} StatusResponse
 // @Failure 401 {object} Error
+// @Failure 403 {object} Error
 // @Failure 500 {object} Error
 // @Router /players/nhl/create [post]
 func (api Api) createNHLPlayers(ctx *gin.Context) {
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service"
+	"github.com/gin-gonic/gin"
+	"html/template"
+	"log"
+	"net/http"
+)
+
+// unsubscribeConfirmation - страница по ссылке из письма. Сама ссылка ничего не меняет: почтовые сканеры
+// открывают ссылки из писем, поэтому отписка выполняется только POST запросом формы
+var unsubscribeConfirmation = template.Must(template.New("unsubscribe").Parse(`<!DOCTYPE html>
+<html lang="ru">
+<head>
+    <meta charset="utf-8">
+    <title>Отписка от рассылки</title>
+</head>
+<body>
+<form method="post" action="?token={{.}}">
+    <p>Вы больше не будете получать письма этой рассылки.</p>
+    <button type="submit">Отписаться</button>
+</form>
+</body>
+</html>
+`))
+
+// getPreferences godoc
+// @Summary Настройки рассылок
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Получение подписок пользователя на категории писем
+// @Tags user
+// @Produce json
+// @Success 200 {object} user.NotificationPreferences
+// @Failure 401 {object} Error
+// @Failure 500 {object} Error
+// @Router /user/preferences [get]
+func (api Api) getPreferences(ctx *gin.Context) {
+	userID, err := parseUserIDFromContext(ctx)
+	if err != nil {
+		log.Println("GetPreferences:", err)
+		return
+	}
+
+	prefs, err := api.services.Notifications.GetPreferences(userID)
+	if err != nil {
+		log.Println("GetPreferences:", err)
+		ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, prefs)
+}
+
+// updatePreferences godoc
+// @Summary Изменение настроек рассылок
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Сохранение подписок пользователя на категории писем
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param data body user.NotificationPreferencesInput true "Входные параметры"
+// @Success 200 {object} StatusResponse
+// @Failure 400,401 {object} Error
+// @Failure 500 {object} Error
+// @Router /user/preferences [put]
+func (api Api) updatePreferences(ctx *gin.Context) {
+	var inp user.NotificationPreferencesInput
+	if err := ctx.BindJSON(&inp); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputBodyError))
+		return
+	}
+
+	userID, err := parseUserIDFromContext(ctx)
+	if err != nil {
+		log.Println("UpdatePreferences:", err)
+		return
+	}
+
+	err = api.services.Notifications.UpdatePreferences(userID, inp)
+	if err != nil {
+		log.Println("UpdatePreferences:", err)
+		ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, StatusResponse{"ок"})
+}
+
+// unsubscribePage godoc
+// @Summary Страница отписки от рассылки
+// @Schemes
+// @Description Страница подтверждения по ссылке из письма. Отписка выполняется только отправкой формы
+// @Tags user
+// @Produce html
+// @Param token query string true "Токен из ссылки"
+// @Success 200 {string} string
+// @Failure 400 {object} Error
+// @Router /user/unsubscribe [get]
+func (api Api) unsubscribePage(ctx *gin.Context) {
+	var inp user.UnsubscribeInput
+	if err := ctx.ShouldBindQuery(&inp); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputParametersError))
+		return
+	}
+
+	ctx.Header("Content-Type", "text/html; charset=utf-8")
+	ctx.Status(http.StatusOK)
+	if err := unsubscribeConfirmation.Execute(ctx.Writer, inp.Token); err != nil {
+		log.Println("UnsubscribePage:", err)
+	}
+}
+
+// unsubscribe godoc
+// @Summary Отписка от рассылки
+// @Schemes
+// @Description Отписка от категории писем без входа в аккаунт: форма со страницы отписки или почтовый клиент в один клик (RFC 8058)
+// @Tags user
+// @Produce json
+// @Param token query string true "Токен из ссылки"
+// @Success 200 {object} StatusResponse
+// @Failure 400 {object} Error
+// @Failure 500 {object} Error
+// @Router /user/unsubscribe [post]
+func (api Api) unsubscribe(ctx *gin.Context) {
+	var inp user.UnsubscribeInput
+	if err := ctx.ShouldBindQuery(&inp); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputParametersError))
+		return
+	}
+
+	err := api.services.Notifications.Unsubscribe(inp.Token)
+	if err != nil {
+		log.Println("Unsubscribe:", err)
+		switch err {
+		case service.InvalidUnsubscribeTokenError,
+			service.UnknownCategoryError:
+			ctx.JSON(http.StatusBadRequest, getBadRequestError(err))
+			return
+		default:
+			ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, StatusResponse{"ок"})
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"fmt"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service"
+	mock_service "github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_unsubscribe(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockNotifications)
+
+	testTable := []struct {
+		name                 string
+		method               string
+		query                string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:               "Page does not unsubscribe",
+			method:             "GET",
+			query:              "?token=abc%2Bdef",
+			mockBehavior:       func(s *mock_service.MockNotifications) {},
+			expectedStatusCode: 200,
+		},
+		{
+			name:                 "Page without token",
+			method:               "GET",
+			mockBehavior:         func(s *mock_service.MockNotifications) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`, BadRequestErrorTitle, InvalidInputParametersError),
+		},
+		{
+			name:   "OK",
+			method: "POST",
+			query:  "?token=abc%2Bdef",
+			mockBehavior: func(s *mock_service.MockNotifications) {
+				s.EXPECT().Unsubscribe("abc+def").Return(nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"ок"}`,
+		},
+		{
+			name:   "Invalid token",
+			method: "POST",
+			query:  "?token=abc",
+			mockBehavior: func(s *mock_service.MockNotifications) {
+				s.EXPECT().Unsubscribe("abc").Return(service.InvalidUnsubscribeTokenError)
+			},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, service.InvalidUnsubscribeTokenError),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			notifications := mock_service.NewMockNotifications(c)
+			testCase.mockBehavior(notifications)
+
+			services := &service.Services{Notifications: notifications}
+			handler := Api{services: services}
+
+			r := gin.New()
+			r.GET("/user/unsubscribe", handler.unsubscribePage)
+			r.POST("/user/unsubscribe", handler.unsubscribe)
+
+			w := httptest.NewRecorder()
+
+			req := httptest.NewRequest(testCase.method, "/user/unsubscribe"+testCase.query, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			if testCase.expectedResponseBody != "" {
+				assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+			} else {
+				assert.Contains(t, w.Body.String(), `<form method="post" action="?token=abc%2bdef">`)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package api
+
+import (
+	"errors"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/blobstore"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/storage"
+	"github.com/gin-gonic/gin"
+	"log"
+	"net/http"
+)
+
+var (
+	AvatarFileRequiredError = errors.New("файл аватара не передан")
+)
+
+// updateProfile godoc
+// @Summary Редактирование профиля
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Смена никнейма. Никнейм можно менять не чаще, чем раз в период из конфига
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param data body user.UpdateProfileInput true "Входные параметры"
+// @Success 200 {object} StatusResponse
+// @Failure 400,401 {object} Error
+// @Failure 500 {object} Error
+// @Router /user/profile [patch]
+func (api Api) updateProfile(ctx *gin.Context) {
+	userID, err := parseUserIDFromContext(ctx)
+	if err != nil {
+		log.Println("UpdateProfile:", err)
+		return
+	}
+
+	var inp user.UpdateProfileInput
+	if err = ctx.BindJSON(&inp); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputBodyError))
+		return
+	}
+
+	err = api.services.User.UpdateProfile(userID, inp)
+	if err != nil {
+		log.Println("UpdateProfile:", err)
+		switch err {
+		case service.InvalidNicknameError,
+			service.NicknameTakenError,
+			service.NicknameChangeCooldownError,
+			storage.UserDoesNotExistError:
+			ctx.JSON(http.StatusBadRequest, getBadRequestError(err))
+			return
+		default:
+			ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, StatusResponse{"ок"})
+}
+
+// uploadAvatar godoc
+// @Summary Загрузка аватара
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Загрузка аватара (JPEG, PNG или GIF). Изображение обрезается до квадрата и сохраняется в нескольких размерах
+// @Tags user
+// @Accept mpfd
+// @Produce json
+// @Param avatar formData file true "Изображение"
+// @Success 200 {object} user.Avatar
+// @Failure 400,401 {object} Error
+// @Failure 500 {object} Error
+// @Router /user/avatar [post]
+func (api Api) uploadAvatar(ctx *gin.Context) {
+	userID, err := parseUserIDFromContext(ctx)
+	if err != nil {
+		log.Println("UploadAvatar:", err)
+		return
+	}
+
+	// Запас на служебные части multipart
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, api.cfg.Profile.AvatarMaxSize+1<<20)
+
+	fileHeader, err := ctx.FormFile("avatar")
+	if err != nil {
+		log.Println("UploadAvatar:", err)
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			ctx.JSON(http.StatusBadRequest, getBadRequestError(service.AvatarTooLargeError))
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(AvatarFileRequiredError))
+		return
+	}
+	if fileHeader.Size > api.cfg.Profile.AvatarMaxSize {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(service.AvatarTooLargeError))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		log.Println("UploadAvatar:", err)
+		ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+		return
+	}
+	defer file.Close()
+
+	avatar, err := api.services.User.UploadAvatar(userID, file)
+	if err != nil {
+		log.Println("UploadAvatar:", err)
+		switch err {
+		case service.AvatarTooLargeError,
+			service.InvalidAvatarTypeError,
+			service.InvalidAvatarError,
+			storage.UserDoesNotExistError:
+			ctx.JSON(http.StatusBadRequest, getBadRequestError(err))
+			return
+		default:
+			ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, avatar)
+}
+
+// getAvatar godoc
+// @Summary Получение аватара
+// @Schemes
+// @Description Отдает файл аватара по ссылке из photoLink
+// @Tags user
+// @Produce png
+// @Param name path string true "Имя файла"
+// @Success 200 {file} binary
+// @Failure 400,404 {object} Error
+// @Failure 500 {object} Error
+// @Router /files/avatars/{name} [get]
+func (api Api) getAvatar(ctx *gin.Context) {
+	var inp user.AvatarInput
+	if err := ctx.ShouldBindUri(&inp); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputParametersError))
+		return
+	}
+
+	file, info, err := api.services.User.GetAvatar(inp.Name)
+	if err != nil {
+		log.Println("GetAvatar:", err)
+		switch err {
+		case blobstore.BlobNotFoundError:
+			ctx.JSON(http.StatusNotFound, getNotFoundError())
+			return
+		case blobstore.InvalidKeyError:
+			ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputParametersError))
+			return
+		default:
+			ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+			return
+		}
+	}
+	defer file.Close()
+
+	// Имя файла аватара уникально, поэтому содержимое по ссылке никогда не меняется
+	ctx.DataFromReader(http.StatusOK, info.Size, info.ContentType, file, map[string]string{
+		"Cache-Control": "public, max-age=31536000, immutable",
+	})
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service"
+	mock_service "github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_updateProfile(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockUser, userID uuid.UUID, inp user.UpdateProfileInput)
+	userID, _ := uuid.Parse("6bc57ea9-c881-47d3-a293-b925ff1ddf72")
+
+	testTable := []struct {
+		name                 string
+		inputBody            string
+		inputData            user.UpdateProfileInput
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "OK",
+			inputBody: `{"nickname":"NewNick"}`,
+			inputData: user.UpdateProfileInput{Nickname: "NewNick"},
+			mockBehavior: func(s *mock_service.MockUser, userID uuid.UUID, inp user.UpdateProfileInput) {
+				s.EXPECT().UpdateProfile(userID, inp).Return(nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"ок"}`,
+		},
+		{
+			name:               "Wrong input",
+			inputBody:          `{"nickname":"abc"}`,
+			mockBehavior:       func(s *mock_service.MockUser, userID uuid.UUID, inp user.UpdateProfileInput) {},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, InvalidInputBodyError),
+		},
+		{
+			name:      "Nickname taken",
+			inputBody: `{"nickname":"NewNick"}`,
+			inputData: user.UpdateProfileInput{Nickname: "NewNick"},
+			mockBehavior: func(s *mock_service.MockUser, userID uuid.UUID, inp user.UpdateProfileInput) {
+				s.EXPECT().UpdateProfile(userID, inp).Return(service.NicknameTakenError)
+			},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, service.NicknameTakenError),
+		},
+		{
+			name:      "Cooldown",
+			inputBody: `{"nickname":"NewNick"}`,
+			inputData: user.UpdateProfileInput{Nickname: "NewNick"},
+			mockBehavior: func(s *mock_service.MockUser, userID uuid.UUID, inp user.UpdateProfileInput) {
+				s.EXPECT().UpdateProfile(userID, inp).Return(service.NicknameChangeCooldownError)
+			},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, service.NicknameChangeCooldownError),
+		},
+		{
+			name:      "Service error",
+			inputBody: `{"nickname":"NewNick"}`,
+			inputData: user.UpdateProfileInput{Nickname: "NewNick"},
+			mockBehavior: func(s *mock_service.MockUser, userID uuid.UUID, inp user.UpdateProfileInput) {
+				s.EXPECT().UpdateProfile(userID, inp).Return(errors.New("something went wrong"))
+			},
+			expectedStatusCode: 500,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				InternalServerErrorTitle, InternalServerErrorMessage),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			user := mock_service.NewMockUser(c)
+			testCase.mockBehavior(user, userID, testCase.inputData)
+
+			services := &service.Services{User: user}
+			handler := Api{services: services}
+
+			r := gin.New()
+			r.PATCH("/user/profile", func(ctx *gin.Context) {
+				ctx.Set("userID", userID.String())
+			}, handler.updateProfile)
+
+			w := httptest.NewRecorder()
+
+			req := httptest.NewRequest("PATCH", "/user/profile",
+				bytes.NewBufferString(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, w.Code, testCase.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), testCase.expectedResponseBody)
+		})
+	}
+}
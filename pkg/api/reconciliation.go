@@ -0,0 +1,88 @@
+package api
+
+import (
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/storage"
+	"github.com/gin-gonic/gin"
+	"log"
+	"net/http"
+)
+
+// getReconciliationRuns godoc
+// @Summary Запуски сверки балансов
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Последние 30 запусков ежедневной сверки балансов с главной книгой, от новых к старым. Доступно только администраторам
+// @Tags admin
+// @Produce json
+// @Success 200 {array} user.ReconciliationRun
+// @Failure 401,403 {object} Error
+// @Failure 500 {object} Error
+// @Router /admin/reconciliation/runs [get]
+func (api Api) getReconciliationRuns(ctx *gin.Context) {
+	runs, err := api.services.Reconciliation.GetReconciliationRuns()
+	if err != nil {
+		log.Println("GetReconciliationRuns:", err)
+		ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, runs)
+}
+
+// getReconciliationReport godoc
+// @Summary Отчет сверки балансов
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Найденные при запуске сверки расхождения. Доступно только администраторам
+// @Tags admin
+// @Produce json
+// @Param id path int true "ID запуска сверки"
+// @Success 200 {object} user.ReconciliationReport
+// @Failure 400,401,403,404 {object} Error
+// @Failure 500 {object} Error
+// @Router /admin/reconciliation/runs/{id} [get]
+func (api Api) getReconciliationReport(ctx *gin.Context) {
+	var inp user.ReconciliationReportInput
+	if err := ctx.ShouldBindUri(&inp); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputParametersError))
+		return
+	}
+
+	report, err := api.services.Reconciliation.GetReconciliationReport(inp.ID)
+	if err != nil {
+		log.Println("GetReconciliationReport:", err)
+		switch err {
+		case storage.ReconciliationRunNotFoundError:
+			ctx.JSON(http.StatusNotFound, getNotFoundError())
+			return
+		default:
+			ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// runReconciliation godoc
+// @Summary Внеплановая сверка балансов
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Запускает сверку балансов сразу, не дожидаясь ежедневного запуска. Доступно только администраторам
+// @Tags admin
+// @Produce json
+// @Success 200 {object} user.ReconciliationRun
+// @Failure 401,403 {object} Error
+// @Failure 500 {object} Error
+// @Router /admin/reconciliation/runs [post]
+func (api Api) runReconciliation(ctx *gin.Context) {
+	run, err := api.services.Reconciliation.ReconcileBalances()
+	if err != nil {
+		log.Println("RunReconciliation:", err)
+		ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, run)
+}
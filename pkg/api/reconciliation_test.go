@@ -0,0 +1,101 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service"
+	mock_service "github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service/mocks"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHandler_getReconciliationReport(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockReconciliation)
+	profileID, _ := uuid.Parse("6bc57ea9-c881-47d3-a293-b925ff1ddf72")
+	startedAt, _ := time.Parse(time.RFC3339, "2024-06-13T03:00:00Z")
+	finishedAt := startedAt.Add(2 * time.Second)
+
+	testTable := []struct {
+		name                 string
+		path                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "OK",
+			path: "/admin/reconciliation/runs/7",
+			mockBehavior: func(s *mock_service.MockReconciliation) {
+				s.EXPECT().GetReconciliationReport(int64(7)).Return(user.ReconciliationReport{
+					ReconciliationRun: user.ReconciliationRun{ID: 7, StartedAt: startedAt, FinishedAt: &finishedAt,
+						CheckedProfiles: 120, Discrepancies: 1},
+					Items: []user.ReconciliationDiscrepancy{
+						{ID: 1, Kind: user.BalanceDiscrepancy, ProfileID: &profileID, Expected: 1500, Actual: 1000},
+					},
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"id":7,"startedAt":"2024-06-13T03:00:00Z","finishedAt":"2024-06-13T03:00:02Z","checkedProfiles":120,"discrepancies":1,"items":[{"id":1,"kind":"balance","profileID":"6bc57ea9-c881-47d3-a293-b925ff1ddf72","expected":1500,"actual":1000}]}`,
+		},
+		{
+			name:               "Invalid id",
+			path:               "/admin/reconciliation/runs/abc",
+			mockBehavior:       func(s *mock_service.MockReconciliation) {},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, InvalidInputParametersError),
+		},
+		{
+			name: "Not found",
+			path: "/admin/reconciliation/runs/8",
+			mockBehavior: func(s *mock_service.MockReconciliation) {
+				s.EXPECT().GetReconciliationReport(int64(8)).Return(user.ReconciliationReport{}, storage.ReconciliationRunNotFoundError)
+			},
+			expectedStatusCode: 404,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, NotFoundErrorMessage),
+		},
+		{
+			name: "Service error",
+			path: "/admin/reconciliation/runs/7",
+			mockBehavior: func(s *mock_service.MockReconciliation) {
+				s.EXPECT().GetReconciliationReport(int64(7)).Return(user.ReconciliationReport{}, errors.New("something went wrong"))
+			},
+			expectedStatusCode: 500,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				InternalServerErrorTitle, InternalServerErrorMessage),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			reconciliation := mock_service.NewMockReconciliation(c)
+			testCase.mockBehavior(reconciliation)
+
+			services := &service.Services{Reconciliation: reconciliation}
+			handler := Api{services: services}
+
+			r := gin.New()
+			r.GET("/admin/reconciliation/runs/:id", handler.getReconciliationReport)
+
+			w := httptest.NewRecorder()
+
+			req := httptest.NewRequest("GET", testCase.path, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, w.Code, testCase.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), testCase.expectedResponseBody)
+		})
+	}
+}
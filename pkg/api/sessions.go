@@ -0,0 +1,154 @@
+package api
+
+import (
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"log"
+	"net/http"
+)
+
+// getSessions godoc
+// @Summary Получение активных сессий пользователя
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Получение списка устройств, на которых выполнен вход в аккаунт
+// @Tags user
+// @Accept json
+// @Produce json
+// @Success 200 {array} user.SessionInfo
+// @Failure 400,401 {object} Error
+// @Failure 500 {object} Error
+// @Router /user/sessions [get]
+func (api Api) getSessions(ctx *gin.Context) {
+	userID, err := parseUserIDFromContext(ctx)
+	if err != nil {
+		log.Println("GetSessions:", err)
+		return
+	}
+
+	sessions, err := api.services.User.GetSessions(userID)
+	if err != nil {
+		log.Println("GetSessions:", err)
+		ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, sessions)
+}
+
+// revokeSession godoc
+// @Summary Завершение сессии
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Завершение сессии на выбранном устройстве
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param id path string true "id сессии"
+// @Success 200 {object} StatusResponse
+// @Failure 400,401 {object} Error
+// @Failure 500 {object} Error
+// @Router /user/sessions/{id} [delete]
+func (api Api) revokeSession(ctx *gin.Context) {
+	userID, err := parseUserIDFromContext(ctx)
+	if err != nil {
+		log.Println("RevokeSession:", err)
+		return
+	}
+
+	var inp user.SessionIDInput
+	if err = ctx.ShouldBindUri(&inp); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputParametersError))
+		return
+	}
+
+	err = api.services.User.RevokeSession(userID, uuid.MustParse(inp.ID))
+	if err != nil {
+		log.Println("RevokeSession:", err)
+		switch err {
+		case storage.SessionNotFoundError:
+			ctx.JSON(http.StatusBadRequest, getBadRequestError(err))
+			return
+		default:
+			ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, StatusResponse{"ок"})
+}
+
+// revokeOtherSessions godoc
+// @Summary Завершение всех сессий, кроме текущей
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Завершение сессий на всех устройствах, кроме текущего. Текущая сессия определяется по refresh токену
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param data body user.RefreshInput true "Входные параметры"
+// @Success 200 {object} StatusResponse
+// @Failure 400,401 {object} Error
+// @Failure 500 {object} Error
+// @Router /user/sessions/revoke-others [post]
+func (api Api) revokeOtherSessions(ctx *gin.Context) {
+	userID, err := parseUserIDFromContext(ctx)
+	if err != nil {
+		log.Println("RevokeOtherSessions:", err)
+		return
+	}
+
+	var inp user.RefreshInput
+	if err = ctx.BindJSON(&inp); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputBodyError))
+		return
+	}
+
+	err = api.services.User.RevokeOtherSessions(userID, inp.RefreshToken)
+	if err != nil {
+		log.Println("RevokeOtherSessions:", err)
+		switch err {
+		case service.InvalidRefreshTokenError,
+			storage.RefreshTokenNotFoundError:
+			ctx.JSON(http.StatusBadRequest, getBadRequestError(err))
+			return
+		default:
+			ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, StatusResponse{"ок"})
+}
+
+// revokeAllSessions godoc
+// @Summary Выход на всех устройствах
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Завершение всех сессий пользователя, включая текущую
+// @Tags user
+// @Accept json
+// @Produce json
+// @Success 200 {object} StatusResponse
+// @Failure 400,401 {object} Error
+// @Failure 500 {object} Error
+// @Router /user/sessions [delete]
+func (api Api) revokeAllSessions(ctx *gin.Context) {
+	userID, err := parseUserIDFromContext(ctx)
+	if err != nil {
+		log.Println("RevokeAllSessions:", err)
+		return
+	}
+
+	err = api.services.User.RevokeAllSessions(userID)
+	if err != nil {
+		log.Println("RevokeAllSessions:", err)
+		ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, StatusResponse{"ок"})
+}
@@ -0,0 +1,165 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service"
+	mock_service "github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service/mocks"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHandler_getSessions(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockUser, userID uuid.UUID, sessions []user.SessionInfo)
+	userID, _ := uuid.Parse("6bc57ea9-c881-47d3-a293-b925ff1ddf72")
+	sessionID, _ := uuid.Parse("2d4f1a5e-8c1a-4b6f-9f7e-3c2d1b0a9e8f")
+	issuedAt := time.Date(2024, 5, 23, 12, 0, 0, 0, time.UTC)
+
+	testTable := []struct {
+		name                 string
+		sessions             []user.SessionInfo
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "OK",
+			sessions: []user.SessionInfo{
+				{
+					ID:         sessionID,
+					CreatedAt:  issuedAt,
+					LastUsedAt: issuedAt,
+					ExpiresAt:  issuedAt.Add(720 * time.Hour),
+					DeviceInfo: user.DeviceInfo{UserAgent: "Mozilla/5.0", IP: "127.0.0.1", Device: "Chrome on Windows"},
+				},
+			},
+			mockBehavior: func(s *mock_service.MockUser, userID uuid.UUID, sessions []user.SessionInfo) {
+				s.EXPECT().GetSessions(userID).Return(sessions, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `[{"id":"2d4f1a5e-8c1a-4b6f-9f7e-3c2d1b0a9e8f","createdAt":"2024-05-23T12:00:00Z","lastUsedAt":"2024-05-23T12:00:00Z","expiresAt":"2024-06-22T12:00:00Z","userAgent":"Mozilla/5.0","ip":"127.0.0.1","device":"Chrome on Windows"}]`,
+		},
+		{
+			name: "Service error",
+			mockBehavior: func(s *mock_service.MockUser, userID uuid.UUID, sessions []user.SessionInfo) {
+				s.EXPECT().GetSessions(userID).Return([]user.SessionInfo{}, errors.New("something went wrong"))
+			},
+			expectedStatusCode: 500,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				InternalServerErrorTitle, InternalServerErrorMessage),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			user := mock_service.NewMockUser(c)
+			testCase.mockBehavior(user, userID, testCase.sessions)
+
+			services := &service.Services{User: user}
+			handler := Api{services: services}
+
+			r := gin.New()
+			r.GET("/user/sessions", func(ctx *gin.Context) {
+				ctx.Set("userID", userID.String())
+			}, handler.getSessions)
+
+			w := httptest.NewRecorder()
+
+			req := httptest.NewRequest("GET", "/user/sessions", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, w.Code, testCase.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), testCase.expectedResponseBody)
+		})
+	}
+}
+
+func TestHandler_revokeSession(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockUser, userID uuid.UUID, sessionID uuid.UUID)
+	userID, _ := uuid.Parse("6bc57ea9-c881-47d3-a293-b925ff1ddf72")
+	sessionID, _ := uuid.Parse("2d4f1a5e-8c1a-4b6f-9f7e-3c2d1b0a9e8f")
+
+	testTable := []struct {
+		name                 string
+		sessionID            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "OK",
+			sessionID: sessionID.String(),
+			mockBehavior: func(s *mock_service.MockUser, userID uuid.UUID, sessionID uuid.UUID) {
+				s.EXPECT().RevokeSession(userID, sessionID).Return(nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"ок"}`,
+		},
+		{
+			name:               "Invalid session id",
+			sessionID:          "123",
+			mockBehavior:       func(s *mock_service.MockUser, userID uuid.UUID, sessionID uuid.UUID) {},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, InvalidInputParametersError),
+		},
+		{
+			name:      "Session not found",
+			sessionID: sessionID.String(),
+			mockBehavior: func(s *mock_service.MockUser, userID uuid.UUID, sessionID uuid.UUID) {
+				s.EXPECT().RevokeSession(userID, sessionID).Return(storage.SessionNotFoundError)
+			},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, storage.SessionNotFoundError),
+		},
+		{
+			name:      "Service error",
+			sessionID: sessionID.String(),
+			mockBehavior: func(s *mock_service.MockUser, userID uuid.UUID, sessionID uuid.UUID) {
+				s.EXPECT().RevokeSession(userID, sessionID).Return(errors.New("something went wrong"))
+			},
+			expectedStatusCode: 500,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				InternalServerErrorTitle, InternalServerErrorMessage),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			user := mock_service.NewMockUser(c)
+			testCase.mockBehavior(user, userID, sessionID)
+
+			services := &service.Services{User: user}
+			handler := Api{services: services}
+
+			r := gin.New()
+			r.DELETE("/user/sessions/:id", func(ctx *gin.Context) {
+				ctx.Set("userID", userID.String())
+			}, handler.revokeSession)
+
+			w := httptest.NewRecorder()
+
+			req := httptest.NewRequest("DELETE", "/user/sessions/"+testCase.sessionID, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, w.Code, testCase.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), testCase.expectedResponseBody)
+		})
+	}
+}
@@ -45,8 +45,10 @@ func (api Api) getAllProducts(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id query int true "id товара" Example(1)
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор с тем же ключом вернет сохраненный ответ"
 // @Success 200 {object} StatusResponse
 // @Failure 400 {object} Error
+// @Failure 409,422 {object} Error
 // @Failure 500 {object} Error
 // @Router /store/products/buy [post]
 func (api Api) buyProduct(ctx *gin.Context) {
@@ -74,9 +76,11 @@ func (api Api) buyProduct(ctx *gin.Context) {
 	err = api.services.Store.BuyProduct(buy)
 	if err != nil {
 		log.Println("BuyProduct:", err)
+		if handleInsufficientFundsError(ctx, err) {
+			return
+		}
 		switch err {
 		case storage.IncorrectProductID,
-			storage.NotEnoughCoinsError,
 			storage.GetAllCardsError:
 			ctx.JSON(http.StatusBadRequest, getBadRequestError(err))
 			return
@@ -88,3 +92,38 @@ func (api Api) buyProduct(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, StatusResponse{"ок"})
 }
+
+// getProductOdds godoc
+// @Summary Шансы выпадения карточек в наборе
+// @Schemes
+// @Description Раскрытие шансов: вероятность каждой редкости для негарантированного слота в процентах, гарантированные слоты, квоты позиций и шанс звездного игрока
+// @Tags store
+// @Accept json
+// @Produce json
+// @Param id path int true "id товара"
+// @Success 200 {object} store.ProductOdds
+// @Failure 400,404 {object} Error
+// @Failure 500 {object} Error
+// @Router /store/products/{id}/odds [get]
+func (api Api) getProductOdds(ctx *gin.Context) {
+	var inp store.ProductIDInput
+	if err := ctx.ShouldBindUri(&inp); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputParametersError))
+		return
+	}
+
+	odds, err := api.services.Store.GetProductOdds(inp.ID)
+	if err != nil {
+		log.Println("GetProductOdds:", err)
+		switch err {
+		case storage.IncorrectProductID:
+			ctx.JSON(http.StatusNotFound, getNotFoundError())
+			return
+		default:
+			ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, odds)
+}
@@ -0,0 +1,331 @@
+package api
+
+import (
+	"errors"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/blobstore"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/store"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/storage"
+	"github.com/gin-gonic/gin"
+	"log"
+	"net/http"
+)
+
+var (
+	ProductPhotoFileRequiredError = errors.New("файл изображения товара не передан")
+)
+
+// getAdminProducts godoc
+// @Summary Получение всех товаров магазина
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Список товаров со служебными полями, включая неактивные и вне окна продажи. Доступно только администраторам
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param includeArchived query bool false "Показывать архивные товары"
+// @Success 200 {array} store.AdminProduct
+// @Failure 400,401,403 {object} Error
+// @Failure 500 {object} Error
+// @Router /admin/store/products [get]
+func (api Api) getAdminProducts(ctx *gin.Context) {
+	var filter store.AdminProductsFilter
+	if err := ctx.BindQuery(&filter); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputParametersError))
+		return
+	}
+
+	products, err := api.services.Store.GetAdminProducts(filter)
+	if err != nil {
+		log.Println("GetAdminProducts:", err)
+		ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, products)
+}
+
+// createProduct godoc
+// @Summary Создание товара
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Создание товара в магазине. Если sortOrder не указан, товар встает в конец витрины. Доступно только администраторам
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body store.ProductInput true "Входные параметры"
+// @Success 200 {object} store.AdminProduct
+// @Failure 400,401,403 {object} Error
+// @Failure 500 {object} Error
+// @Router /admin/store/products [post]
+func (api Api) createProduct(ctx *gin.Context) {
+	var inp store.ProductInput
+	if err := ctx.BindJSON(&inp); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputBodyError))
+		return
+	}
+
+	product, err := api.services.Store.CreateProduct(inp)
+	if err != nil {
+		log.Println("CreateProduct:", err)
+		handleProductError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, product)
+}
+
+// updateProduct godoc
+// @Summary Изменение товара
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Изменение товара в магазине. Архивный товар изменить нельзя. Доступно только администраторам
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path int true "id товара"
+// @Param data body store.ProductInput true "Входные параметры"
+// @Success 200 {object} store.AdminProduct
+// @Failure 400,401,403,404 {object} Error
+// @Failure 500 {object} Error
+// @Router /admin/store/products/{id} [put]
+func (api Api) updateProduct(ctx *gin.Context) {
+	var id store.ProductIDInput
+	if err := ctx.ShouldBindUri(&id); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputParametersError))
+		return
+	}
+
+	var inp store.ProductInput
+	if err := ctx.BindJSON(&inp); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputBodyError))
+		return
+	}
+
+	product, err := api.services.Store.UpdateProduct(id.ID, inp)
+	if err != nil {
+		log.Println("UpdateProduct:", err)
+		handleProductError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, product)
+}
+
+// archiveProduct godoc
+// @Summary Архивирование товара
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Снимает товар с продажи без удаления, чтобы история покупок оставалась целой. Доступно только администраторам
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path int true "id товара"
+// @Success 200 {object} StatusResponse
+// @Failure 400,401,403,404 {object} Error
+// @Failure 500 {object} Error
+// @Router /admin/store/products/{id}/archive [post]
+func (api Api) archiveProduct(ctx *gin.Context) {
+	var id store.ProductIDInput
+	if err := ctx.ShouldBindUri(&id); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputParametersError))
+		return
+	}
+
+	err := api.services.Store.ArchiveProduct(id.ID)
+	if err != nil {
+		log.Println("ArchiveProduct:", err)
+		handleProductError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, StatusResponse{"ок"})
+}
+
+// reorderProducts godoc
+// @Summary Изменение порядка товаров
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Задает порядок показа товаров на витрине по позиции id в списке. Доступно только администраторам
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body store.ReorderProductsInput true "Входные параметры"
+// @Success 200 {object} StatusResponse
+// @Failure 400,401,403,404 {object} Error
+// @Failure 500 {object} Error
+// @Router /admin/store/products/order [put]
+func (api Api) reorderProducts(ctx *gin.Context) {
+	var inp store.ReorderProductsInput
+	if err := ctx.BindJSON(&inp); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputBodyError))
+		return
+	}
+
+	err := api.services.Store.ReorderProducts(inp)
+	if err != nil {
+		log.Println("ReorderProducts:", err)
+		handleProductError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, StatusResponse{"ок"})
+}
+
+// updateProductDropTable godoc
+// @Summary Изменение таблицы выпадения набора
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Задает веса редкостей, гарантированные слоты, квоты позиций и шанс звездного игрока. Доступно только администраторам
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path int true "id товара"
+// @Param data body store.DropTable true "Входные параметры"
+// @Success 200 {object} StatusResponse
+// @Failure 400,401,403,404 {object} Error
+// @Failure 500 {object} Error
+// @Router /admin/store/products/{id}/drop-table [put]
+func (api Api) updateProductDropTable(ctx *gin.Context) {
+	var id store.ProductIDInput
+	if err := ctx.ShouldBindUri(&id); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputParametersError))
+		return
+	}
+
+	var table store.DropTable
+	if err := ctx.BindJSON(&table); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputBodyError))
+		return
+	}
+
+	err := api.services.Store.UpdateProductDropTable(id.ID, table)
+	if err != nil {
+		log.Println("UpdateProductDropTable:", err)
+		handleProductError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, StatusResponse{"ок"})
+}
+
+// uploadProductPhoto godoc
+// @Summary Загрузка изображения товара
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Загрузка изображения товара (JPEG, PNG или GIF), ссылка сохраняется в photoLink. Доступно только администраторам
+// @Tags admin
+// @Accept mpfd
+// @Produce json
+// @Param id path int true "id товара"
+// @Param photo formData file true "Изображение"
+// @Success 200 {object} store.AdminProduct
+// @Failure 400,401,403,404 {object} Error
+// @Failure 500 {object} Error
+// @Router /admin/store/products/{id}/photo [post]
+func (api Api) uploadProductPhoto(ctx *gin.Context) {
+	var id store.ProductIDInput
+	if err := ctx.ShouldBindUri(&id); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputParametersError))
+		return
+	}
+
+	// Запас на служебные части multipart
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, api.cfg.Store.PhotoMaxSize+1<<20)
+
+	fileHeader, err := ctx.FormFile("photo")
+	if err != nil {
+		log.Println("UploadProductPhoto:", err)
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			ctx.JSON(http.StatusBadRequest, getBadRequestError(service.ProductPhotoTooLargeError))
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(ProductPhotoFileRequiredError))
+		return
+	}
+	if fileHeader.Size > api.cfg.Store.PhotoMaxSize {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(service.ProductPhotoTooLargeError))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		log.Println("UploadProductPhoto:", err)
+		ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+		return
+	}
+	defer file.Close()
+
+	product, err := api.services.Store.UploadProductPhoto(id.ID, file)
+	if err != nil {
+		log.Println("UploadProductPhoto:", err)
+		switch err {
+		case service.ProductPhotoTooLargeError,
+			service.InvalidProductPhotoTypeError:
+			ctx.JSON(http.StatusBadRequest, getBadRequestError(err))
+			return
+		default:
+			handleProductError(ctx, err)
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, product)
+}
+
+// getProductPhoto godoc
+// @Summary Получение изображения товара
+// @Schemes
+// @Description Отдает файл изображения товара по ссылке из photoLink
+// @Tags store
+// @Produce png,jpeg,gif
+// @Param name path string true "Имя файла"
+// @Success 200 {file} binary
+// @Failure 400,404 {object} Error
+// @Failure 500 {object} Error
+// @Router /files/products/{name} [get]
+func (api Api) getProductPhoto(ctx *gin.Context) {
+	var inp store.ProductPhotoInput
+	if err := ctx.ShouldBindUri(&inp); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputParametersError))
+		return
+	}
+
+	file, info, err := api.services.Store.GetProductPhoto(inp.Name)
+	if err != nil {
+		log.Println("GetProductPhoto:", err)
+		switch err {
+		case blobstore.BlobNotFoundError:
+			ctx.JSON(http.StatusNotFound, getNotFoundError())
+			return
+		case blobstore.InvalidKeyError:
+			ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputParametersError))
+			return
+		default:
+			ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+			return
+		}
+	}
+	defer file.Close()
+
+	// Имя файла уникально для каждой загрузки, поэтому содержимое по ссылке никогда не меняется
+	ctx.DataFromReader(http.StatusOK, info.Size, info.ContentType, file, map[string]string{
+		"Cache-Control": "public, max-age=31536000, immutable",
+	})
+}
+
+func handleProductError(ctx *gin.Context, err error) {
+	switch err {
+	case storage.IncorrectProductID:
+		ctx.JSON(http.StatusNotFound, getNotFoundError())
+	case service.InvalidProductLeagueError,
+		service.InvalidProductRarityError,
+		service.InvalidAvailabilityWindowError,
+		service.InvalidDropTableError,
+		service.DropTableTooManySlotsError:
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(err))
+	default:
+		ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+	}
+}
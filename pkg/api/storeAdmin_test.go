@@ -0,0 +1,198 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/store"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/tournaments"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service"
+	mock_service "github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service/mocks"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_updateProduct(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockStore)
+	active := true
+	inp := store.ProductInput{ProductName: "Набор КХЛ", Price: 500, League: tournaments.KHL, Rarity: store.Gold,
+		PlayerCardsCount: 3, Active: &active}
+	body := `{"productName":"Набор КХЛ","price":500,"league":2,"rarity":2,"playerCardsCount":3,"active":true}`
+
+	testTable := []struct {
+		name                 string
+		path                 string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "OK",
+			path:      "/admin/store/products/4",
+			inputBody: body,
+			mockBehavior: func(s *mock_service.MockStore) {
+				s.EXPECT().UpdateProduct(4, inp).Return(store.AdminProduct{
+					Product: store.Product{ID: 4, ProductName: "Набор КХЛ", Price: 500, League: tournaments.KHL,
+						LeagueName: "KHL", Rarity: store.Gold, RarityName: "Gold", PlayerCardsCount: 3},
+					Active: true, SortOrder: 2,
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"id":4,"productName":"Набор КХЛ","price":500,"league":2,"leagueName":"KHL","rarity":2,"rarityName":"Gold","playerCardsCount":3,"photoLink":"","active":true,"sortOrder":2}`,
+		},
+		{
+			name:               "Missing active",
+			path:               "/admin/store/products/4",
+			inputBody:          `{"productName":"Набор КХЛ","price":500,"league":2,"rarity":2,"playerCardsCount":3}`,
+			mockBehavior:       func(s *mock_service.MockStore) {},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, InvalidInputBodyError),
+		},
+		{
+			name:      "Invalid rarity",
+			path:      "/admin/store/products/4",
+			inputBody: body,
+			mockBehavior: func(s *mock_service.MockStore) {
+				s.EXPECT().UpdateProduct(4, inp).Return(store.AdminProduct{}, service.InvalidProductRarityError)
+			},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, service.InvalidProductRarityError),
+		},
+		{
+			name:      "Archived product",
+			path:      "/admin/store/products/4",
+			inputBody: body,
+			mockBehavior: func(s *mock_service.MockStore) {
+				s.EXPECT().UpdateProduct(4, inp).Return(store.AdminProduct{}, storage.IncorrectProductID)
+			},
+			expectedStatusCode: 404,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, NotFoundErrorMessage),
+		},
+		{
+			name:               "Invalid id",
+			path:               "/admin/store/products/abc",
+			inputBody:          body,
+			mockBehavior:       func(s *mock_service.MockStore) {},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, InvalidInputParametersError),
+		},
+		{
+			name:      "Service error",
+			path:      "/admin/store/products/4",
+			inputBody: body,
+			mockBehavior: func(s *mock_service.MockStore) {
+				s.EXPECT().UpdateProduct(4, inp).Return(store.AdminProduct{}, errors.New("something went wrong"))
+			},
+			expectedStatusCode: 500,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				InternalServerErrorTitle, InternalServerErrorMessage),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			storeService := mock_service.NewMockStore(c)
+			testCase.mockBehavior(storeService)
+
+			services := &service.Services{Store: storeService}
+			handler := Api{services: services}
+
+			r := gin.New()
+			r.PUT("/admin/store/products/:id", handler.updateProduct)
+
+			w := httptest.NewRecorder()
+
+			req := httptest.NewRequest("PUT", testCase.path, bytes.NewBufferString(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, w.Code, testCase.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), testCase.expectedResponseBody)
+		})
+	}
+}
+
+func TestHandler_reorderProducts(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockStore)
+
+	testTable := []struct {
+		name                 string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "OK",
+			inputBody: `{"ids":[3,1,2]}`,
+			mockBehavior: func(s *mock_service.MockStore) {
+				s.EXPECT().ReorderProducts(store.ReorderProductsInput{IDs: []int{3, 1, 2}}).Return(nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"ок"}`,
+		},
+		{
+			name:               "Empty list",
+			inputBody:          `{"ids":[]}`,
+			mockBehavior:       func(s *mock_service.MockStore) {},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, InvalidInputBodyError),
+		},
+		{
+			name:               "Duplicate ids",
+			inputBody:          `{"ids":[3,1,3]}`,
+			mockBehavior:       func(s *mock_service.MockStore) {},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, InvalidInputBodyError),
+		},
+		{
+			name:      "Unknown product",
+			inputBody: `{"ids":[3,99]}`,
+			mockBehavior: func(s *mock_service.MockStore) {
+				s.EXPECT().ReorderProducts(store.ReorderProductsInput{IDs: []int{3, 99}}).Return(storage.IncorrectProductID)
+			},
+			expectedStatusCode: 404,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, NotFoundErrorMessage),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			storeService := mock_service.NewMockStore(c)
+			testCase.mockBehavior(storeService)
+
+			services := &service.Services{Store: storeService}
+			handler := Api{services: services}
+
+			r := gin.New()
+			r.PUT("/admin/store/products/order", handler.reorderProducts)
+
+			w := httptest.NewRecorder()
+
+			req := httptest.NewRequest("PUT", "/admin/store/products/order", bytes.NewBufferString(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, w.Code, testCase.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), testCase.expectedResponseBody)
+		})
+	}
+}
@@ -87,6 +87,7 @@ func TestHandler_getAllProducts(t *testing.T) {
 func TestHandler_buyProduct(t *testing.T) {
 	type mockBehavior func(s *mock_service.MockStore, inp store.BuyProductModel)
 	userID, _ := uuid.Parse("6bc57ea9-c881-47d3-a293-b925ff1ddf72")
+	insufficientFunds := &storage.InsufficientFundsError{ProfileID: userID, Balance: 100, Required: 500}
 
 	testTable := []struct {
 		name                 string
@@ -127,11 +128,11 @@ func TestHandler_buyProduct(t *testing.T) {
 				ProfileID: userID,
 			},
 			mockBehavior: func(s *mock_service.MockStore, inp store.BuyProductModel) {
-				s.EXPECT().BuyProduct(inp).Return(storage.NotEnoughCoinsError)
+				s.EXPECT().BuyProduct(inp).Return(insufficientFunds)
 			},
 			expectedStatusCode: 400,
 			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
-				BadRequestErrorTitle, storage.NotEnoughCoinsError),
+				BadRequestErrorTitle, insufficientFunds),
 		},
 		{
 			name: "Service error",
@@ -178,3 +179,79 @@ func TestHandler_buyProduct(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_getProductOdds(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockStore)
+
+	testTable := []struct {
+		name                 string
+		path                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "OK",
+			path: "/store/products/1/odds",
+			mockBehavior: func(s *mock_service.MockStore) {
+				s.EXPECT().GetProductOdds(1).Return(store.ProductOdds{
+					ProductID:        1,
+					PlayerCardsCount: 5,
+					Rarities: []store.RarityOdds{
+						{Rarity: store.Silver, RarityName: "Silver", Chance: 90},
+						{Rarity: store.Gold, RarityName: "Gold", Chance: 10},
+					},
+					Guarantees:     []store.RarityGuarantee{{Rarity: store.Gold, Count: 1}},
+					PositionQuotas: []store.PositionQuota{},
+					StarCost:       8,
+					StarChance:     5,
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"productID":1,"playerCardsCount":5,"rarities":[{"rarity":1,"rarityName":"Silver","chance":90},{"rarity":2,"rarityName":"Gold","chance":10}],"guarantees":[{"rarity":2,"count":1}],"positionQuotas":[],"starCost":8,"starChance":5}`,
+		},
+		{
+			name: "Not on sale",
+			path: "/store/products/2/odds",
+			mockBehavior: func(s *mock_service.MockStore) {
+				s.EXPECT().GetProductOdds(2).Return(store.ProductOdds{}, storage.IncorrectProductID)
+			},
+			expectedStatusCode: 404,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, NotFoundErrorMessage),
+		},
+		{
+			name:               "Invalid id",
+			path:               "/store/products/abc/odds",
+			mockBehavior:       func(s *mock_service.MockStore) {},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, InvalidInputParametersError),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			store := mock_service.NewMockStore(c)
+			testCase.mockBehavior(store)
+
+			services := &service.Services{Store: store}
+			handler := Api{services: services}
+
+			r := gin.New()
+			r.GET("/store/products/:id/odds", handler.getProductOdds)
+
+			w := httptest.NewRecorder()
+
+			req := httptest.NewRequest("GET", testCase.path, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, w.Code, testCase.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), testCase.expectedResponseBody)
+		})
+	}
+}
@@ -24,6 +24,7 @@ import (
 // @Success 200
 // @Failure 400 {object} Error
 // @Failure 401 {object} Error
+// @Failure 403 {object} Error
 // @Router /tournament/create_team_nhl [get]
 func (api *Api) CreateTeamsNHL(ctx *gin.Context) {
 	_, err := parseUserIDFromContext(ctx)
@@ -77,6 +78,7 @@ func (api *Api) CreateTeamsNHL(ctx *gin.Context) {
 // @Success 200
 // @Failure 400 {object} Error
 // @Failure 401 {object} Error
+// @Failure 403 {object} Error
 // @Router /tournament/create_team_khl [get]
 func (api *Api) CreateTeamsKHL(ctx *gin.Context) {
 	_, err := parseUserIDFromContext(ctx)
@@ -265,8 +267,10 @@ func (api Api) getTournamentRoster(ctx *gin.Context) {
 // @Produce json
 // @Param tournamentID query int true "tournamentID"
 // @Param data body tournaments.UserTeamInput true "Входные параметры"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор с тем же ключом вернет сохраненный ответ"
 // @Success 200 {object} StatusResponse
 // @Failure 400,401 {object} Error
+// @Failure 409,422 {object} Error
 // @Failure 500 {object} Error
 // @Router /tournament/team/create [POST]
 func (api Api) createTournamentTeam(ctx *gin.Context) {
@@ -303,13 +307,15 @@ func (api Api) createTournamentTeam(ctx *gin.Context) {
 	err = api.services.Tournaments.CreateTournamentTeam(inp)
 	if err != nil {
 		log.Println("CreateTournamentTeam:", err)
+		if handleInsufficientFundsError(ctx, err) {
+			return
+		}
 		switch err {
 		case storage.IncorrectTournamentID,
 			service.TeamExpensiveError,
 			service.InvalidTournamentTeam,
 			service.InvalidTeamPositions,
 			service.JoinTimeExpiredError,
-			storage.NotEnoughCoinsError,
 			service.InvalidPlayersNumber,
 			service.TeamAlreadyCreatedError:
 			ctx.JSON(http.StatusBadRequest, getBadRequestError(err))
@@ -0,0 +1,261 @@
+package api
+
+import (
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/players"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/storage"
+	"github.com/gin-gonic/gin"
+	"log"
+	"net/http"
+)
+
+// getTradeOffers godoc
+// @Summary Предложения обмена пользователя
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Входящие и исходящие предложения обмена от новых к старым
+// @Tags trades
+// @Accept json
+// @Produce json
+// @Param box query string false "Ящик" Enums(incoming, outgoing)
+// @Param status query string false "Статус" Enums(pending, accepted, declined, countered, expired)
+// @Param limit query int false "Размер выборки, по умолчанию 50, максимум 100"
+// @Success 200 {array} players.TradeOffer
+// @Failure 400,401 {object} Error
+// @Failure 500 {object} Error
+// @Router /trades/offers [get]
+func (api Api) getTradeOffers(ctx *gin.Context) {
+	userID, err := parseUserIDFromContext(ctx)
+	if err != nil {
+		log.Println("GetTradeOffers:", err)
+		return
+	}
+
+	var filter players.TradeOffersFilter
+	if err = ctx.ShouldBindQuery(&filter); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputParametersError))
+		return
+	}
+
+	offers, err := api.services.Trades.GetTradeOffers(userID, filter)
+	if err != nil {
+		log.Println("GetTradeOffers:", err)
+		ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, offers)
+}
+
+// getTradeOffer godoc
+// @Summary Предложение обмена
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Предложение доступно только его отправителю и получателю
+// @Tags trades
+// @Accept json
+// @Produce json
+// @Param id path int true "id предложения"
+// @Success 200 {object} players.TradeOffer
+// @Failure 400,401,404 {object} Error
+// @Failure 500 {object} Error
+// @Router /trades/offers/{id} [get]
+func (api Api) getTradeOffer(ctx *gin.Context) {
+	userID, err := parseUserIDFromContext(ctx)
+	if err != nil {
+		log.Println("GetTradeOffer:", err)
+		return
+	}
+
+	var inp players.TradeOfferIDInput
+	if err = ctx.ShouldBindUri(&inp); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputParametersError))
+		return
+	}
+
+	offer, err := api.services.Trades.GetTradeOffer(userID, inp.ID)
+	if err != nil {
+		log.Println("GetTradeOffer:", err)
+		handleTradeOfferError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, offer)
+}
+
+// createTradeOffer godoc
+// @Summary Предложение обмена
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Предложить свои карточки и монеты в обмен на карточки другого пользователя. Все карточки должны быть распакованы и не стоять в составе незавершенного турнира
+// @Tags trades
+// @Accept json
+// @Produce json
+// @Param data body players.TradeOfferInput true "Входные параметры"
+// @Success 200 {object} players.TradeOffer
+// @Failure 400,401 {object} Error
+// @Failure 500 {object} Error
+// @Router /trades/offers [post]
+func (api Api) createTradeOffer(ctx *gin.Context) {
+	userID, err := parseUserIDFromContext(ctx)
+	if err != nil {
+		log.Println("CreateTradeOffer:", err)
+		return
+	}
+
+	var inp players.TradeOfferInput
+	if err = ctx.BindJSON(&inp); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputBodyError))
+		return
+	}
+
+	offer, err := api.services.Trades.CreateTradeOffer(userID, inp)
+	if err != nil {
+		log.Println("CreateTradeOffer:", err)
+		if handleInsufficientFundsError(ctx, err) {
+			return
+		}
+		handleTradeOfferError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, offer)
+}
+
+// counterTradeOffer godoc
+// @Summary Встречное предложение обмена
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Закрывает входящее предложение и отправляет его автору новое. В новом предложении offeredCards - ваши карточки, requestedCards - карточки автора исходного предложения
+// @Tags trades
+// @Accept json
+// @Produce json
+// @Param id path int true "id предложения"
+// @Param data body players.CounterTradeOfferInput true "Входные параметры"
+// @Success 200 {object} players.TradeOffer
+// @Failure 400,401,404 {object} Error
+// @Failure 500 {object} Error
+// @Router /trades/offers/{id}/counter [post]
+func (api Api) counterTradeOffer(ctx *gin.Context) {
+	userID, err := parseUserIDFromContext(ctx)
+	if err != nil {
+		log.Println("CounterTradeOffer:", err)
+		return
+	}
+
+	var uri players.TradeOfferIDInput
+	if err = ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputParametersError))
+		return
+	}
+
+	var inp players.CounterTradeOfferInput
+	if err = ctx.BindJSON(&inp); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputBodyError))
+		return
+	}
+
+	offer, err := api.services.Trades.CounterTradeOffer(userID, uri.ID, inp)
+	if err != nil {
+		log.Println("CounterTradeOffer:", err)
+		if handleInsufficientFundsError(ctx, err) {
+			return
+		}
+		handleTradeOfferError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, offer)
+}
+
+// acceptTradeOffer godoc
+// @Summary Принятие предложения обмена
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Владельцы всех карточек проверяются заново, монеты и карточки переходят сторонам в одной транзакции. Карточки обмена снимаются с продажи на рынке
+// @Tags trades
+// @Accept json
+// @Produce json
+// @Param id path int true "id предложения"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор с тем же ключом вернет сохраненный ответ"
+// @Success 200 {object} players.TradeOffer
+// @Failure 400,401,404 {object} Error
+// @Failure 409,422 {object} Error
+// @Failure 500 {object} Error
+// @Router /trades/offers/{id}/accept [post]
+func (api Api) acceptTradeOffer(ctx *gin.Context) {
+	userID, err := parseUserIDFromContext(ctx)
+	if err != nil {
+		log.Println("AcceptTradeOffer:", err)
+		return
+	}
+
+	var inp players.TradeOfferIDInput
+	if err = ctx.ShouldBindUri(&inp); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputParametersError))
+		return
+	}
+
+	offer, err := api.services.Trades.AcceptTradeOffer(userID, inp.ID)
+	if err != nil {
+		log.Println("AcceptTradeOffer:", err)
+		handleTradeOfferError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, offer)
+}
+
+// declineTradeOffer godoc
+// @Summary Отклонение предложения обмена
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Отклонить можно только действующее входящее предложение
+// @Tags trades
+// @Accept json
+// @Produce json
+// @Param id path int true "id предложения"
+// @Success 200 {object} players.TradeOffer
+// @Failure 400,401,404 {object} Error
+// @Failure 500 {object} Error
+// @Router /trades/offers/{id}/decline [post]
+func (api Api) declineTradeOffer(ctx *gin.Context) {
+	userID, err := parseUserIDFromContext(ctx)
+	if err != nil {
+		log.Println("DeclineTradeOffer:", err)
+		return
+	}
+
+	var inp players.TradeOfferIDInput
+	if err = ctx.ShouldBindUri(&inp); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputParametersError))
+		return
+	}
+
+	offer, err := api.services.Trades.DeclineTradeOffer(userID, inp.ID)
+	if err != nil {
+		log.Println("DeclineTradeOffer:", err)
+		handleTradeOfferError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, offer)
+}
+
+func handleTradeOfferError(ctx *gin.Context, err error) {
+	switch err {
+	case storage.TradeOfferNotFoundError:
+		ctx.JSON(http.StatusNotFound, getNotFoundError())
+	case storage.OwnTradeOfferError,
+		storage.EmptyTradeOfferError,
+		storage.TradeCardConflictError,
+		storage.TradeProposerFundsError,
+		storage.UserDoesNotExistError,
+		storage.PlayerCardNotFoundError,
+		storage.IncorrectPlayerCardUserID,
+		storage.CardNotUnpackedError,
+		storage.CardInActiveRosterError:
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(err))
+	default:
+		ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+	}
+}
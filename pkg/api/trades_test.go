@@ -0,0 +1,206 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/players"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service"
+	mock_service "github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service/mocks"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHandler_createTradeOffer(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockTrades)
+	userID, _ := uuid.Parse("6bc57ea9-c881-47d3-a293-b925ff1ddf72")
+	recipientID, _ := uuid.Parse("0f8fad5b-d9cb-469f-a165-70867728950e")
+	createdAt, _ := time.Parse(time.RFC3339, "2024-06-18T10:00:00Z")
+	insufficientFunds := &storage.InsufficientFundsError{ProfileID: userID, Balance: 10, Required: 100}
+
+	testTable := []struct {
+		name                 string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "OK",
+			inputBody: `{"recipientID":"0f8fad5b-d9cb-469f-a165-70867728950e","offeredCards":[1,2],"requestedCards":[30],"coins":100}`,
+			mockBehavior: func(s *mock_service.MockTrades) {
+				s.EXPECT().CreateTradeOffer(userID, players.TradeOfferInput{
+					RecipientID: recipientID, OfferedCards: []int{1, 2}, RequestedCards: []int{30}, Coins: 100,
+				}).Return(players.TradeOffer{
+					ID: 4, ProposerID: userID, ProposerNickname: "alice", RecipientID: recipientID, RecipientNickname: "bob",
+					OfferedCards: []int{1, 2}, RequestedCards: []int{30}, Coins: 100, Status: players.PendingTrade,
+					CreatedAt: createdAt, ExpiresAt: createdAt.Add(72 * time.Hour),
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"id":4,"proposerID":"6bc57ea9-c881-47d3-a293-b925ff1ddf72","proposerNickname":"alice","recipientID":"0f8fad5b-d9cb-469f-a165-70867728950e","recipientNickname":"bob","offeredCards":[1,2],"requestedCards":[30],"coins":100,"status":"pending","createdAt":"2024-06-18T10:00:00Z","expiresAt":"2024-06-21T10:00:00Z"}`,
+		},
+		{
+			name:      "Not enough coins",
+			inputBody: `{"recipientID":"0f8fad5b-d9cb-469f-a165-70867728950e","requestedCards":[30],"coins":100}`,
+			mockBehavior: func(s *mock_service.MockTrades) {
+				s.EXPECT().CreateTradeOffer(userID, players.TradeOfferInput{
+					RecipientID: recipientID, RequestedCards: []int{30}, Coins: 100,
+				}).Return(players.TradeOffer{}, insufficientFunds)
+			},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, insufficientFunds),
+		},
+		{
+			name:      "Card of another user",
+			inputBody: `{"recipientID":"0f8fad5b-d9cb-469f-a165-70867728950e","offeredCards":[1]}`,
+			mockBehavior: func(s *mock_service.MockTrades) {
+				s.EXPECT().CreateTradeOffer(userID, players.TradeOfferInput{
+					RecipientID: recipientID, OfferedCards: []int{1},
+				}).Return(players.TradeOffer{}, storage.IncorrectPlayerCardUserID)
+			},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, storage.IncorrectPlayerCardUserID),
+		},
+		{
+			name:               "Duplicate cards",
+			inputBody:          `{"recipientID":"0f8fad5b-d9cb-469f-a165-70867728950e","offeredCards":[1,1]}`,
+			mockBehavior:       func(s *mock_service.MockTrades) {},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, InvalidInputBodyError),
+		},
+		{
+			name:               "Negative coins",
+			inputBody:          `{"recipientID":"0f8fad5b-d9cb-469f-a165-70867728950e","offeredCards":[1],"coins":-5}`,
+			mockBehavior:       func(s *mock_service.MockTrades) {},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, InvalidInputBodyError),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			trades := mock_service.NewMockTrades(c)
+			testCase.mockBehavior(trades)
+
+			services := &service.Services{Trades: trades}
+			handler := Api{services: services}
+
+			r := gin.New()
+			r.POST("/trades/offers", func(ctx *gin.Context) {
+				ctx.Set("userID", userID.String())
+			}, handler.createTradeOffer)
+
+			w := httptest.NewRecorder()
+
+			req := httptest.NewRequest("POST", "/trades/offers", bytes.NewBufferString(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, w.Code, testCase.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), testCase.expectedResponseBody)
+		})
+	}
+}
+
+func TestHandler_acceptTradeOffer(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockTrades)
+	userID, _ := uuid.Parse("6bc57ea9-c881-47d3-a293-b925ff1ddf72")
+
+	testTable := []struct {
+		name                 string
+		path                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Card no longer owned",
+			path: "/trades/offers/4/accept",
+			mockBehavior: func(s *mock_service.MockTrades) {
+				s.EXPECT().AcceptTradeOffer(userID, int64(4)).Return(players.TradeOffer{}, storage.IncorrectPlayerCardUserID)
+			},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, storage.IncorrectPlayerCardUserID),
+		},
+		{
+			name: "Proposer lacks coins",
+			path: "/trades/offers/4/accept",
+			mockBehavior: func(s *mock_service.MockTrades) {
+				s.EXPECT().AcceptTradeOffer(userID, int64(4)).Return(players.TradeOffer{}, storage.TradeProposerFundsError)
+			},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, storage.TradeProposerFundsError),
+		},
+		{
+			name: "Offer closed",
+			path: "/trades/offers/4/accept",
+			mockBehavior: func(s *mock_service.MockTrades) {
+				s.EXPECT().AcceptTradeOffer(userID, int64(4)).Return(players.TradeOffer{}, storage.TradeOfferNotFoundError)
+			},
+			expectedStatusCode: 404,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, NotFoundErrorMessage),
+		},
+		{
+			name:               "Invalid id",
+			path:               "/trades/offers/0/accept",
+			mockBehavior:       func(s *mock_service.MockTrades) {},
+			expectedStatusCode: 400,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				BadRequestErrorTitle, InvalidInputParametersError),
+		},
+		{
+			name: "Service error",
+			path: "/trades/offers/4/accept",
+			mockBehavior: func(s *mock_service.MockTrades) {
+				s.EXPECT().AcceptTradeOffer(userID, int64(4)).Return(players.TradeOffer{}, errors.New("something went wrong"))
+			},
+			expectedStatusCode: 500,
+			expectedResponseBody: fmt.Sprintf(`{"error":"%s","message":"%s"}`,
+				InternalServerErrorTitle, InternalServerErrorMessage),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			trades := mock_service.NewMockTrades(c)
+			testCase.mockBehavior(trades)
+
+			services := &service.Services{Trades: trades}
+			handler := Api{services: services}
+
+			r := gin.New()
+			r.POST("/trades/offers/:id/accept", func(ctx *gin.Context) {
+				ctx.Set("userID", userID.String())
+			}, handler.acceptTradeOffer)
+
+			w := httptest.NewRecorder()
+
+			req := httptest.NewRequest("POST", testCase.path, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, w.Code, testCase.expectedStatusCode)
+			assert.Equal(t, w.Body.String(), testCase.expectedResponseBody)
+		})
+	}
+}
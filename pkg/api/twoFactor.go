@@ -0,0 +1,225 @@
+package api
+
+import (
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/storage"
+	"github.com/gin-gonic/gin"
+	"log"
+	"net/http"
+)
+
+// verifyTwoFactor godoc
+// @Summary Подтверждение входа кодом 2FA
+// @Schemes
+// @Description Обмен challenge, полученного при входе, и TOTP кода (или кода восстановления) на токены
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param data body user.TwoFactorVerifyInput true "Входные параметры"
+// @Success 200 {object} user.Tokens
+// @Failure 400 {object} Error
+// @Failure 429 {object} Error
+// @Failure 500 {object} Error
+// @Router /auth/2fa/verify [post]
+func (api Api) verifyTwoFactor(ctx *gin.Context) {
+	var inp user.TwoFactorVerifyInput
+	if err := ctx.BindJSON(&inp); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputBodyError))
+		return
+	}
+
+	tokens, err := api.services.User.VerifyTwoFactor(inp, getDeviceInfo(ctx))
+	if err != nil {
+		log.Println("VerifyTwoFactor:", err)
+		if handleRateLimitError(ctx, err) {
+			return
+		}
+		switch err {
+		case storage.TwoFactorChallengeError,
+			service.InvalidTwoFactorCodeError,
+			service.TwoFactorNotEnabledError:
+			ctx.JSON(http.StatusBadRequest, getBadRequestError(err))
+			return
+		default:
+			ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, tokens)
+}
+
+// enrollTwoFactor godoc
+// @Summary Подключение 2FA
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Генерация секрета TOTP и otpauth URI для QR кода. 2FA включится после подтверждения первым кодом
+// @Tags user
+// @Accept json
+// @Produce json
+// @Success 200 {object} user.TwoFactorEnrollment
+// @Failure 400,401 {object} Error
+// @Failure 500 {object} Error
+// @Router /user/2fa/enroll [post]
+func (api Api) enrollTwoFactor(ctx *gin.Context) {
+	userID, err := parseUserIDFromContext(ctx)
+	if err != nil {
+		log.Println("EnrollTwoFactor:", err)
+		return
+	}
+
+	enrollment, err := api.services.User.EnrollTwoFactor(userID)
+	if err != nil {
+		log.Println("EnrollTwoFactor:", err)
+		switch err {
+		case service.TwoFactorAlreadyEnabledError,
+			storage.UserDoesNotExistError:
+			ctx.JSON(http.StatusBadRequest, getBadRequestError(err))
+			return
+		default:
+			ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, enrollment)
+}
+
+// confirmTwoFactor godoc
+// @Summary Включение 2FA
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Подтверждение подключения 2FA первым кодом из приложения. Возвращает одноразовые коды восстановления
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param data body user.TwoFactorCodeInput true "Входные параметры"
+// @Success 200 {object} user.RecoveryCodes
+// @Failure 400,401 {object} Error
+// @Failure 500 {object} Error
+// @Router /user/2fa/confirm [post]
+func (api Api) confirmTwoFactor(ctx *gin.Context) {
+	userID, err := parseUserIDFromContext(ctx)
+	if err != nil {
+		log.Println("ConfirmTwoFactor:", err)
+		return
+	}
+
+	var inp user.TwoFactorCodeInput
+	if err = ctx.BindJSON(&inp); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputBodyError))
+		return
+	}
+
+	recoveryCodes, err := api.services.User.ConfirmTwoFactor(userID, inp.Code)
+	if err != nil {
+		log.Println("ConfirmTwoFactor:", err)
+		switch err {
+		case service.TwoFactorAlreadyEnabledError,
+			service.InvalidTwoFactorCodeError,
+			storage.TwoFactorNotFoundError:
+			ctx.JSON(http.StatusBadRequest, getBadRequestError(err))
+			return
+		default:
+			ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, recoveryCodes)
+}
+
+// disableTwoFactor godoc
+// @Summary Отключение 2FA
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Отключение 2FA. Требуется TOTP код или код восстановления
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param data body user.TwoFactorCodeInput true "Входные параметры"
+// @Success 200 {object} StatusResponse
+// @Failure 400,401 {object} Error
+// @Failure 429 {object} Error
+// @Failure 500 {object} Error
+// @Router /user/2fa/disable [post]
+func (api Api) disableTwoFactor(ctx *gin.Context) {
+	userID, err := parseUserIDFromContext(ctx)
+	if err != nil {
+		log.Println("DisableTwoFactor:", err)
+		return
+	}
+
+	var inp user.TwoFactorCodeInput
+	if err = ctx.BindJSON(&inp); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputBodyError))
+		return
+	}
+
+	err = api.services.User.DisableTwoFactor(userID, inp.Code)
+	if err != nil {
+		log.Println("DisableTwoFactor:", err)
+		if handleRateLimitError(ctx, err) {
+			return
+		}
+		switch err {
+		case service.TwoFactorNotEnabledError,
+			service.InvalidTwoFactorCodeError:
+			ctx.JSON(http.StatusBadRequest, getBadRequestError(err))
+			return
+		default:
+			ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, StatusResponse{"ок"})
+}
+
+// regenerateRecoveryCodes godoc
+// @Summary Новые коды восстановления 2FA
+// @Security ApiKeyAuth
+// @Schemes
+// @Description Генерация нового набора кодов восстановления, старые коды перестают действовать. Требуется TOTP код
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param data body user.TwoFactorCodeInput true "Входные параметры"
+// @Success 200 {object} user.RecoveryCodes
+// @Failure 400,401 {object} Error
+// @Failure 429 {object} Error
+// @Failure 500 {object} Error
+// @Router /user/2fa/recovery-codes [post]
+func (api Api) regenerateRecoveryCodes(ctx *gin.Context) {
+	userID, err := parseUserIDFromContext(ctx)
+	if err != nil {
+		log.Println("RegenerateRecoveryCodes:", err)
+		return
+	}
+
+	var inp user.TwoFactorCodeInput
+	if err = ctx.BindJSON(&inp); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputBodyError))
+		return
+	}
+
+	recoveryCodes, err := api.services.User.RegenerateRecoveryCodes(userID, inp.Code)
+	if err != nil {
+		log.Println("RegenerateRecoveryCodes:", err)
+		if handleRateLimitError(ctx, err) {
+			return
+		}
+		switch err {
+		case service.TwoFactorNotEnabledError,
+			service.InvalidTwoFactorCodeError:
+			ctx.JSON(http.StatusBadRequest, getBadRequestError(err))
+			return
+		default:
+			ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, recoveryCodes)
+}
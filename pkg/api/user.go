@@ -1,10 +1,13 @@
 package api
 
 import (
+	"bytes"
+	"errors"
 	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
 	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service"
 	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/storage"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"log"
 	"net/http"
 )
@@ -52,13 +55,15 @@ func (api Api) signUp(ctx *gin.Context) {
 // SignIn godoc
 // @Summary Авторизация
 // @Schemes
-// @Description Авторизация пользователя в системе
+// @Description Авторизация пользователя в системе. Если у пользователя включена 2FA, вместо токенов возвращается challenge для /auth/2fa/verify
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param data body user.SignInInput true "Входные параметры"
 // @Success 200 {object} user.Tokens
+// @Success 202 {object} user.TwoFactorChallenge
 // @Failure 400 {object} Error
+// @Failure 429 {object} Error
 // @Failure 500 {object} Error
 // @Router /auth/sign-in [post]
 func (api Api) signIn(ctx *gin.Context) {
@@ -68,9 +73,17 @@ func (api Api) signIn(ctx *gin.Context) {
 		return
 	}
 
-	tokens, err := api.services.User.SignIn(inp)
+	tokens, err := api.services.User.SignIn(inp, getDeviceInfo(ctx))
 	if err != nil {
 		log.Println("SignIn:", err)
+		if handleRateLimitError(ctx, err) {
+			return
+		}
+		var twoFactorErr *service.TwoFactorRequiredError
+		if errors.As(err, &twoFactorErr) {
+			ctx.JSON(http.StatusAccepted, twoFactorErr.Challenge)
+			return
+		}
 		switch err {
 		case storage.UserDoesNotExistError,
 			service.IncorrectPasswordError:
@@ -95,6 +108,7 @@ func (api Api) signIn(ctx *gin.Context) {
 // @Param data body user.EmailInput true "Входные параметры"
 // @Success 200 {object} StatusResponse
 // @Failure 400 {object} Error
+// @Failure 429 {object} Error
 // @Failure 500 {object} Error
 // @Router /auth/email/send-code [post]
 func (api Api) sendVerificationCode(ctx *gin.Context) {
@@ -107,6 +121,9 @@ func (api Api) sendVerificationCode(ctx *gin.Context) {
 	err := api.services.User.SendVerificationCode(inp.Email)
 	if err != nil {
 		log.Println("SendVerificationCode:", err)
+		if handleRateLimitError(ctx, err) {
+			return
+		}
 		switch err {
 		case service.UserAlreadyExistsError:
 			ctx.JSON(http.StatusBadRequest, getBadRequestError(err))
@@ -139,11 +156,12 @@ func (api Api) refreshTokens(ctx *gin.Context) {
 		return
 	}
 
-	tokens, err := api.services.User.RefreshTokens(inp.RefreshToken)
+	tokens, err := api.services.User.RefreshTokens(inp.RefreshToken, getDeviceInfo(ctx))
 	if err != nil {
 		log.Println("RefreshTokens:", err)
 		switch err {
 		case service.InvalidRefreshTokenError,
+			service.RefreshTokenReuseError,
 			storage.RefreshTokenNotFoundError:
 			ctx.JSON(http.StatusBadRequest, getBadRequestError(err))
 			return
@@ -179,7 +197,8 @@ func (api Api) logout(ctx *gin.Context) {
 	if err != nil {
 		log.Println("Logout:", err)
 		switch err {
-		case storage.RefreshTokenNotFoundError:
+		case storage.RefreshTokenNotFoundError,
+			service.RefreshTokenReuseError:
 			ctx.JSON(http.StatusBadRequest, getBadRequestError(err))
 			return
 		default:
@@ -327,6 +346,7 @@ func (api Api) changePassword(ctx *gin.Context) {
 // @Param data body user.EmailInput true "Входные параметры"
 // @Success 200 {object} StatusResponse
 // @Failure 400 {object} Error
+// @Failure 429 {object} Error
 // @Failure 500 {object} Error
 // @Router /user/password/forgot [post]
 func (api Api) forgotPassword(ctx *gin.Context) {
@@ -339,6 +359,9 @@ func (api Api) forgotPassword(ctx *gin.Context) {
 	err := api.services.User.ForgotPassword(inp.Email)
 	if err != nil {
 		log.Println("ForgotPassword:", err)
+		if handleRateLimitError(ctx, err) {
+			return
+		}
 		switch err {
 		case service.UserDoesNotExistError:
 			ctx.JSON(http.StatusBadRequest, getBadRequestError(err))
@@ -393,7 +416,7 @@ func (api Api) resetPassword(ctx *gin.Context) {
 // @Summary Удаление профиля
 // @Security ApiKeyAuth
 // @Schemes
-// @Description Удаление профиля пользователя
+// @Description Удаление профиля пользователя. Все сессии завершаются, аккаунт можно восстановить входом в течение срока из конфига
 // @Tags user
 // @Accept json
 // @Produce json
@@ -428,11 +451,19 @@ func (api Api) deleteProfile(ctx *gin.Context) {
 // @Summary Получение истории транзакций пользователя
 // @Security ApiKeyAuth
 // @Schemes
-// @Description Получение истории транзакций пользователя по access токену
+// @Description Страница истории транзакций от новых к старым и сводка по фильтру. Следующая страница запрашивается
+// @Description с cursor из nextCursor. С format=csv или заголовком Accept: text/csv отдается выписка по всему фильтру
 // @Tags user
 // @Accept json
-// @Produce json
-// @Success 200 {array} user.CoinTransactionsModel
+// @Produce json,text/csv
+// @Param cursor query string false "Курсор следующей страницы"
+// @Param limit query int false "Размер страницы, до 100" Example(20)
+// @Param from query string false "Начало периода, RFC3339" Example(2024-06-01T00:00:00Z)
+// @Param to query string false "Конец периода, не включается, RFC3339" Example(2024-07-01T00:00:00Z)
+// @Param sign query string false "Направление: credit - начисления, debit - списания" Enums(credit, debit)
+// @Param reason query string false "Тип транзакции" Enums(purchase, entry_fee, prize, refund, grant, adjustment, marketplace_sale, marketplace_fee, trade)
+// @Param format query string false "Формат ответа" Enums(json, csv)
+// @Success 200 {object} user.CoinTransactionsPage
 // @Failure 400,401 {object} Error
 // @Failure 500 {object} Error
 // @Router /user/transactions [get]
@@ -443,11 +474,24 @@ func (api Api) getCoinTransactions(ctx *gin.Context) {
 		return
 	}
 
-	transactions, err := api.services.User.GetCoinTransactions(userID)
+	var filter user.CoinTransactionsFilter
+	if err = ctx.ShouldBindQuery(&filter); err != nil {
+		ctx.JSON(http.StatusBadRequest, getBadRequestError(InvalidInputParametersError))
+		return
+	}
+
+	if filter.Format == "csv" || (filter.Format == "" && ctx.NegotiateFormat(gin.MIMEJSON, "text/csv") == "text/csv") {
+		api.getCoinTransactionsCSV(ctx, userID, filter)
+		return
+	}
+
+	page, err := api.services.User.GetCoinTransactions(userID, filter)
 	if err != nil {
 		log.Println("GetCoinTransactions:", err)
 		switch err {
-		case storage.UserDoesNotExistError:
+		case storage.UserDoesNotExistError,
+			service.InvalidTransactionsCursorError,
+			service.InvalidTransactionsPeriodError:
 			ctx.JSON(http.StatusBadRequest, getBadRequestError(err))
 			return
 		default:
@@ -456,5 +500,38 @@ func (api Api) getCoinTransactions(ctx *gin.Context) {
 		}
 	}
 
-	ctx.JSON(http.StatusOK, transactions)
+	ctx.JSON(http.StatusOK, page)
+}
+
+func (api Api) getCoinTransactionsCSV(ctx *gin.Context, userID uuid.UUID, filter user.CoinTransactionsFilter) {
+	// Выписка собирается целиком до отправки, чтобы при ошибке ответить 500, а не оборванным файлом
+	var buf bytes.Buffer
+	err := api.services.User.WriteCoinTransactionsCSV(userID, filter, &buf)
+	if err != nil {
+		log.Println("GetCoinTransactionsCSV:", err)
+		switch err {
+		case service.InvalidTransactionsPeriodError:
+			ctx.JSON(http.StatusBadRequest, getBadRequestError(err))
+			return
+		default:
+			ctx.JSON(http.StatusInternalServerError, getInternalServerError())
+			return
+		}
+	}
+
+	ctx.Header("Content-Disposition", `attachment; filename="frozen-fantasy-transactions.csv"`)
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+}
+
+// GetJWKS godoc
+// @Summary Публичные ключи подписи токенов
+// @Schemes
+// @Description Набор публичных ключей (JWKS) для проверки access токенов другими сервисами. HS256 ключи не публикуются
+// @Tags auth
+// @Produce json
+// @Success 200 {object} service.JWKSet
+// @Router /auth/jwks.json [get]
+func (api Api) getJWKS(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, api.services.TokenManager.JWKS())
 }
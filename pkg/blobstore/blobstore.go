@@ -0,0 +1,27 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+var (
+	BlobNotFoundError = errors.New("файл не найден")
+	InvalidKeyError   = errors.New("невалидный ключ файла")
+)
+
+type BlobInfo struct {
+	ContentType string
+	Size        int64
+	ModTime     time.Time
+}
+
+// BlobStore хранит пользовательские файлы (аватары и т.п.). Ключ - относительный путь вида "avatars/name.png"
+type BlobStore interface {
+	Put(ctx context.Context, key string, contentType string, data io.Reader) error
+	Get(ctx context.Context, key string) (io.ReadCloser, BlobInfo, error)
+	Delete(ctx context.Context, key string) error
+	URL(key string) string
+}
@@ -0,0 +1,112 @@
+package blobstore
+
+import (
+	"context"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/config"
+	"io"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// LocalBlobStore хранит файлы в каталоге на диске, отдаются они через API по URL из конфига
+type LocalBlobStore struct {
+	dir       string
+	publicURL string
+}
+
+func NewLocalBlobStore(cfg config.ServiceConfiguration) *LocalBlobStore {
+	return &LocalBlobStore{
+		dir:       cfg.BlobStore.LocalDir,
+		publicURL: strings.TrimRight(cfg.BlobStore.PublicURL, "/"),
+	}
+}
+
+func (s *LocalBlobStore) path(key string) (string, error) {
+	cleaned := path.Clean("/" + key)
+	if key == "" || cleaned != "/"+key || strings.HasSuffix(key, "/") {
+		return "", InvalidKeyError
+	}
+
+	return filepath.Join(s.dir, filepath.FromSlash(cleaned)), nil
+}
+
+func (s *LocalBlobStore) Put(ctx context.Context, key string, contentType string, data io.Reader) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return err
+	}
+
+	// Пишем во временный файл и переименовываем, чтобы не отдать недописанный файл
+	tmp, err := os.CreateTemp(filepath.Dir(p), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = io.Copy(tmp, data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), p)
+}
+
+func (s *LocalBlobStore) Get(ctx context.Context, key string) (io.ReadCloser, BlobInfo, error) {
+	var info BlobInfo
+
+	p, err := s.path(key)
+	if err != nil {
+		return nil, info, err
+	}
+
+	f, err := os.Open(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, info, BlobNotFoundError
+		}
+		return nil, info, err
+	}
+
+	stat, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, info, err
+	}
+
+	info.Size = stat.Size()
+	info.ModTime = stat.ModTime()
+	info.ContentType = mime.TypeByExtension(path.Ext(key))
+	if info.ContentType == "" {
+		info.ContentType = "application/octet-stream"
+	}
+
+	return f, info, nil
+}
+
+func (s *LocalBlobStore) Delete(ctx context.Context, key string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(p)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (s *LocalBlobStore) URL(key string) string {
+	return s.publicURL + "/" + key
+}
@@ -0,0 +1,40 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/config"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+func TestLocalBlobStore(t *testing.T) {
+	var cfg config.ServiceConfiguration
+	cfg.BlobStore.LocalDir = t.TempDir()
+	cfg.BlobStore.PublicURL = "http://localhost:8000/api/v1/files/"
+	store := NewLocalBlobStore(cfg)
+	ctx := context.Background()
+
+	err := store.Put(ctx, "avatars/test_64.png", "image/png", bytes.NewBufferString("data"))
+	assert.NoError(t, err)
+
+	r, info, err := store.Get(ctx, "avatars/test_64.png")
+	assert.NoError(t, err)
+	data, _ := io.ReadAll(r)
+	r.Close()
+	assert.Equal(t, "data", string(data))
+	assert.Equal(t, "image/png", info.ContentType)
+	assert.Equal(t, int64(4), info.Size)
+
+	assert.Equal(t, "http://localhost:8000/api/v1/files/avatars/test_64.png", store.URL("avatars/test_64.png"))
+
+	assert.NoError(t, store.Delete(ctx, "avatars/test_64.png"))
+	_, _, err = store.Get(ctx, "avatars/test_64.png")
+	assert.Equal(t, BlobNotFoundError, err)
+
+	for _, key := range []string{"", "../secret", "avatars/../../secret", "/etc/passwd", "avatars/"} {
+		_, _, err = store.Get(ctx, key)
+		assert.Equal(t, InvalidKeyError, err, key)
+	}
+}
@@ -4,8 +4,16 @@ import (
 	"context"
 	"github.com/Frozen-Fantasy/fantasy-backend.git/config"
 	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/api"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/blobstore"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/jobs/build_exports"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/jobs/expire_trade_offers"
 	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/jobs/get_events"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/jobs/purge_profiles"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/jobs/purge_sessions"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/jobs/reconcile_balances"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/jobs/send_emails"
 	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/jobs/update_events"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/mailer"
 	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service"
 	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service/events"
 	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/storage"
@@ -16,9 +24,17 @@ import (
 func Core() fx.Option {
 	return fx.Options(
 		fx.Provide(
-			fx.Annotate(storage.NewPostgresStorage, fx.As(new(service.UserStorage))),
-			fx.Annotate(storage.NewRedisStorage, fx.As(new(service.UserRStorage))),
-			fx.Annotate(storage.NewPostgresStorage, fx.As(new(events.EventsStorage))),
+			fx.Annotate(postgresStorage, fx.As(new(service.UserStorage))),
+			fx.Annotate(redisStorage, fx.As(new(service.UserRStorage))),
+			fx.Annotate(redisStorage, fx.As(new(service.RateLimitStorage))),
+			fx.Annotate(postgresStorage, fx.As(new(events.EventsStorage))),
+			fx.Annotate(postgresStorage, fx.As(new(service.OutboxStorage))),
+			fx.Annotate(postgresStorage, fx.As(new(service.ExportStorage))),
+			fx.Annotate(postgresStorage, fx.As(new(service.ReconciliationStorage))),
+			fx.Annotate(postgresStorage, fx.As(new(service.PreferencesStorage))),
+			fx.Annotate(postgresStorage, fx.As(new(service.TradeStorage))),
+			fx.Annotate(notificationService, fx.As(new(service.TradeNotifier))),
+			fx.Annotate(blobstore.NewLocalBlobStore, fx.As(new(blobstore.BlobStore))),
 		),
 		fx.Provide(
 			context.Background,
@@ -28,19 +44,76 @@ func Core() fx.Option {
 			gin.Default,
 			api.NewApi,
 			service.NewTokenManager,
+			newServiceDeps,
 			service.NewServices,
+			service.NewRateLimitService,
+			service.NewUserService,
+			service.NewReconciliationService,
+			service.NewNotificationService,
+			service.NewTradeService,
+			service.NewMailService,
+			mailer.NewMailer,
 			events.NewEventsService,
 			get_events.NewGetHokeyEvents,
 			update_events.NewUpdateHockeyEvents,
 			update_events.NewUpdateHockeyEventsKHL,
+			send_emails.NewSendEmails,
+			service.NewExportService,
+			build_exports.NewBuildExports,
+			purge_profiles.NewPurgeProfiles,
+			purge_sessions.NewPurgeSessions,
+			reconcile_balances.NewReconcileBalances,
+			expire_trade_offers.NewExpireTradeOffers,
 		),
 		fx.Invoke(restAPIHook),
 		fx.Invoke(getHokeyEventsHook),
 		fx.Invoke(updateHokeyEventsHook),
 		fx.Invoke(updateHokeyEventsHookKHL),
+		fx.Invoke(sendEmailsHook),
+		fx.Invoke(buildExportsHook),
+		fx.Invoke(purgeProfilesHook),
+		fx.Invoke(purgeSessionsHook),
+		fx.Invoke(reconcileBalancesHook),
+		fx.Invoke(expireTradeOffersHook),
 	)
 }
 
+// postgresStorage и redisStorage отдают единственные экземпляры хранилищ под интерфейсами сервисов,
+// чтобы у приложения был один пул соединений
+func postgresStorage(p *storage.PostgresStorage) *storage.PostgresStorage {
+	return p
+}
+
+func redisStorage(r *storage.RedisStorage) *storage.RedisStorage {
+	return r
+}
+
+// notificationService отдает сервис уведомлений под интерфейсами других сервисов
+func notificationService(n *service.NotificationService) *service.NotificationService {
+	return n
+}
+
+// newServiceDeps передает в service.NewServices те же экземпляры, с которыми работают фоновые задачи
+func newServiceDeps(cfg config.ServiceConfiguration, postgres *storage.PostgresStorage, redis *storage.RedisStorage,
+	jwt *service.Manager, mail *service.MailService, blobs blobstore.BlobStore, exports *service.ExportService,
+	limiter *service.RateLimitService, users *service.UserService, balances *service.ReconciliationService,
+	notifier *service.NotificationService, trades *service.TradeService) service.Deps {
+	return service.Deps{
+		Cfg:      cfg,
+		Storage:  postgres,
+		RStorage: redis,
+		Jwt:      jwt,
+		Mail:     mail,
+		Blobs:    blobs,
+		Exports:  exports,
+		Limiter:  limiter,
+		Users:    users,
+		Balances: balances,
+		Notifier: notifier,
+		Trades:   trades,
+	}
+}
+
 func restAPIHook(lifecycle fx.Lifecycle, api *api.Api) {
 	lifecycle.Append(
 		fx.Hook{
@@ -84,3 +157,79 @@ func updateHokeyEventsHookKHL(lifecycle fx.Lifecycle, job *update_events.UpdateH
 		},
 	)
 }
+
+func sendEmailsHook(lifecycle fx.Lifecycle, job *send_emails.SendEmails) {
+	lifecycle.Append(
+		fx.Hook{
+			OnStart: func(ctx context.Context) error {
+				go job.Start(context.Background())
+				return nil
+			},
+		},
+	)
+}
+
+func buildExportsHook(lifecycle fx.Lifecycle, job *build_exports.BuildExports) {
+	lifecycle.Append(
+		fx.Hook{
+			OnStart: func(ctx context.Context) error {
+				go job.Start(context.Background())
+				return nil
+			},
+		},
+	)
+}
+
+func purgeProfilesHook(lifecycle fx.Lifecycle, job *purge_profiles.PurgeProfiles) {
+	lifecycle.Append(
+		fx.Hook{
+			OnStart: func(ctx context.Context) error {
+				go job.Start(context.Background())
+				return nil
+			},
+		},
+	)
+}
+
+func purgeSessionsHook(lifecycle fx.Lifecycle, job *purge_sessions.PurgeSessions) {
+	jobCtx, cancel := context.WithCancel(context.Background())
+	lifecycle.Append(
+		fx.Hook{
+			OnStart: func(ctx context.Context) error {
+				go job.Start(jobCtx)
+				return nil
+			},
+			OnStop: func(ctx context.Context) error {
+				cancel()
+				return nil
+			},
+		},
+	)
+}
+
+func reconcileBalancesHook(lifecycle fx.Lifecycle, job *reconcile_balances.ReconcileBalances) {
+	lifecycle.Append(
+		fx.Hook{
+			OnStart: func(ctx context.Context) error {
+				go job.Start(context.Background())
+				return nil
+			},
+		},
+	)
+}
+
+func expireTradeOffersHook(lifecycle fx.Lifecycle, job *expire_trade_offers.ExpireTradeOffers) {
+	jobCtx, cancel := context.WithCancel(context.Background())
+	lifecycle.Append(
+		fx.Hook{
+			OnStart: func(ctx context.Context) error {
+				go job.Start(jobCtx)
+				return nil
+			},
+			OnStop: func(ctx context.Context) error {
+				cancel()
+				return nil
+			},
+		},
+	)
+}
@@ -0,0 +1,11 @@
+package core
+
+import (
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/fx"
+	"testing"
+)
+
+func TestCoreGraph(t *testing.T) {
+	assert.NoError(t, fx.ValidateApp(Core()))
+}
@@ -0,0 +1,58 @@
+package build_exports
+
+import (
+	"context"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/config"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service"
+	"log"
+	"time"
+)
+
+// exportsPerTick ограничивает число архивов, собираемых за один проход
+const exportsPerTick = 10
+
+func NewBuildExports(cfg config.ServiceConfiguration, exports *service.ExportService) *BuildExports {
+	interval := time.Duration(cfg.DataExport.Interval) * time.Second
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+
+	return &BuildExports{
+		interval: interval,
+		exports:  exports,
+	}
+}
+
+// BuildExports собирает запрошенные выгрузки данных и удаляет просроченные архивы
+type BuildExports struct {
+	interval time.Duration
+	exports  *service.ExportService
+}
+
+func (job *BuildExports) Start(ctx context.Context) {
+	ticker := time.NewTicker(job.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for i := 0; i < exportsPerTick; i++ {
+				found, err := job.exports.BuildPending(ctx)
+				if err != nil {
+					log.Println("Job BuildPending:", err)
+					break
+				}
+				if !found {
+					break
+				}
+			}
+
+			err := job.exports.PurgeExpired(ctx)
+			if err != nil {
+				log.Println("Job PurgeExpired:", err)
+			}
+		}
+	}
+}
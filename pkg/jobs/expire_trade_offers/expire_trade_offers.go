@@ -0,0 +1,47 @@
+package expire_trade_offers
+
+import (
+	"context"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/config"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service"
+	"log"
+	"time"
+)
+
+func NewExpireTradeOffers(cfg config.ServiceConfiguration, trades *service.TradeService) *ExpireTradeOffers {
+	interval := time.Duration(cfg.Trades.ExpireInterval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Minute
+	}
+
+	return &ExpireTradeOffers{
+		interval: interval,
+		trades:   trades,
+	}
+}
+
+// ExpireTradeOffers закрывает предложения обмена, на которые не ответили вовремя
+type ExpireTradeOffers struct {
+	interval time.Duration
+	trades   *service.TradeService
+}
+
+func (job *ExpireTradeOffers) Start(ctx context.Context) {
+	ticker := time.NewTicker(job.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			expired, err := job.trades.ExpireTradeOffers()
+			if err != nil {
+				log.Println("Job ExpireTradeOffers:", err)
+			}
+			if expired > 0 {
+				log.Println("Job ExpireTradeOffers: expired", expired)
+			}
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package purge_profiles
+
+import (
+	"context"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/config"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service"
+	"log"
+	"time"
+)
+
+func NewPurgeProfiles(cfg config.ServiceConfiguration, users *service.UserService) *PurgeProfiles {
+	interval := time.Duration(cfg.Profile.PurgeInterval) * time.Second
+	if interval <= 0 {
+		interval = time.Hour
+	}
+
+	return &PurgeProfiles{
+		interval: interval,
+		users:    users,
+	}
+}
+
+// PurgeProfiles окончательно удаляет аккаунты, у которых истек срок восстановления
+type PurgeProfiles struct {
+	interval time.Duration
+	users    *service.UserService
+}
+
+func (job *PurgeProfiles) Start(ctx context.Context) {
+	ticker := time.NewTicker(job.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := job.users.PurgeDeletedProfiles(ctx)
+			if err != nil {
+				log.Println("Job PurgeDeletedProfiles:", err)
+			}
+			if purged > 0 {
+				log.Println("Job PurgeDeletedProfiles: purged", purged)
+			}
+		}
+	}
+}
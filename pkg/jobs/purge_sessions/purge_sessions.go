@@ -0,0 +1,48 @@
+package purge_sessions
+
+import (
+	"context"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/config"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service"
+	"log"
+	"time"
+)
+
+func NewPurgeSessions(cfg config.ServiceConfiguration, users *service.UserService) *PurgeSessions {
+	interval := time.Duration(cfg.User.SessionPurgeInterval) * time.Second
+	if interval <= 0 {
+		interval = time.Hour
+	}
+
+	return &PurgeSessions{
+		interval: interval,
+		users:    users,
+	}
+}
+
+// PurgeSessions удаляет истекшие сессии вместе с использованными refresh токенами,
+// чтобы таблица refresh_sessions не росла бесконечно
+type PurgeSessions struct {
+	interval time.Duration
+	users    *service.UserService
+}
+
+func (job *PurgeSessions) Start(ctx context.Context) {
+	ticker := time.NewTicker(job.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := job.users.PurgeExpiredSessions(ctx)
+			if err != nil {
+				log.Println("Job PurgeExpiredSessions:", err)
+			}
+			if purged > 0 {
+				log.Println("Job PurgeExpiredSessions: purged", purged)
+			}
+		}
+	}
+}
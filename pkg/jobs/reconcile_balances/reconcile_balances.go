@@ -0,0 +1,46 @@
+package reconcile_balances
+
+import (
+	"context"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/config"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service"
+	"log"
+	"time"
+)
+
+func NewReconcileBalances(cfg config.ServiceConfiguration, reconciliation *service.ReconciliationService) *ReconcileBalances {
+	curTime := time.Now()
+	return &ReconcileBalances{
+		dailyRunTime:   time.Date(curTime.Year(), curTime.Month(), curTime.Day(), cfg.Reconciliation.Hour, 0, 0, 0, time.Local),
+		reconciliation: reconciliation,
+	}
+}
+
+// ReconcileBalances раз в сутки сверяет балансы пользователей с главной книгой и сохраняет отчет о расхождениях
+type ReconcileBalances struct {
+	dailyRunTime   time.Time
+	reconciliation *service.ReconciliationService
+}
+
+func (job *ReconcileBalances) Start(ctx context.Context) {
+	if time.Now().After(job.dailyRunTime) {
+		job.dailyRunTime = job.dailyRunTime.Add(24 * time.Hour)
+	}
+
+	timer := time.NewTimer(job.dailyRunTime.Sub(time.Now()))
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			_, err := job.reconciliation.ReconcileBalances()
+			if err != nil {
+				log.Println("Job ReconcileBalances:", err)
+			}
+
+			timer.Reset(24 * time.Hour)
+		}
+	}
+}
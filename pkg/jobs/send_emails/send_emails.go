@@ -0,0 +1,44 @@
+package send_emails
+
+import (
+	"context"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/config"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service"
+	"log"
+	"time"
+)
+
+func NewSendEmails(cfg config.ServiceConfiguration, mail *service.MailService) *SendEmails {
+	interval := time.Duration(cfg.Email.Outbox.Interval) * time.Second
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+
+	return &SendEmails{
+		interval: interval,
+		mail:     mail,
+	}
+}
+
+// SendEmails периодически отправляет письма из email_outbox
+type SendEmails struct {
+	interval time.Duration
+	mail     *service.MailService
+}
+
+func (job *SendEmails) Start(ctx context.Context) {
+	ticker := time.NewTicker(job.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, err := job.mail.DeliverPending(ctx)
+			if err != nil {
+				log.Println("Job DeliverPending:", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/config"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	HTML    string
+	Headers map[string]string
+}
+
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+const (
+	SMTPDriver   = "smtp"
+	FileDriver   = "file"
+	MemoryDriver = "memory"
+)
+
+// NewMailer выбирает реализацию по email.driver из конфига. По умолчанию письма отправляются через SMTP
+func NewMailer(cfg config.ServiceConfiguration) Mailer {
+	switch cfg.Email.Driver {
+	case "", SMTPDriver:
+		return NewSMTPMailer(cfg.Email)
+	case FileDriver:
+		return NewFileMailer(cfg.Email.From, cfg.Email.FileDir)
+	case MemoryDriver:
+		return NewMemoryMailer()
+	default:
+		panic(fmt.Sprintf("Unknown email driver %s", cfg.Email.Driver))
+	}
+}
@@ -0,0 +1,32 @@
+package mailer
+
+import (
+	"context"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/config"
+	"gopkg.in/gomail.v2"
+)
+
+type SMTPMailer struct {
+	from   string
+	dialer *gomail.Dialer
+}
+
+func NewSMTPMailer(cfg config.Email) *SMTPMailer {
+	return &SMTPMailer{
+		from:   cfg.From,
+		dialer: gomail.NewDialer(cfg.SMTPHost, cfg.SMTPPort, cfg.Login, cfg.Password),
+	}
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, msg Message) error {
+	gm := gomail.NewMessage()
+	gm.SetHeader("From", m.from)
+	gm.SetHeader("To", msg.To)
+	gm.SetHeader("Subject", msg.Subject)
+	for key, value := range msg.Headers {
+		gm.SetHeader(key, value)
+	}
+	gm.SetBody("text/html", msg.HTML)
+
+	return m.dialer.DialAndSend(gm)
+}
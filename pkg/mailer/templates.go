@@ -0,0 +1,78 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"html/template"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+const (
+	VerificationCodeTemplate = "verification_code"
+	ResetPasswordTemplate    = "reset_password"
+	EmailChangedTemplate     = "email_changed"
+	DataExportReadyTemplate  = "data_export_ready"
+	TradeOfferTemplate       = "trade_offer"
+
+	DefaultLanguage = "ru"
+)
+
+var UnknownTemplateError = errors.New("unknown email template")
+
+//go:embed templates
+var templateFiles embed.FS
+
+// Каждый шаблон определяет блоки subject и body
+type Templates struct {
+	templates map[string]*template.Template
+}
+
+func NewTemplates() (*Templates, error) {
+	t := &Templates{templates: map[string]*template.Template{}}
+
+	err := fs.WalkDir(templateFiles, "templates", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		lang := path.Base(path.Dir(p))
+		name := strings.TrimSuffix(path.Base(p), path.Ext(p))
+
+		tmpl, err := template.ParseFS(templateFiles, p)
+		if err != nil {
+			return err
+		}
+		t.templates[lang+"/"+name] = tmpl
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// Render возвращает тему и HTML письма. Если шаблона на нужном языке нет, используется русский
+func (t *Templates) Render(name string, lang string, data interface{}) (string, string, error) {
+	tmpl, ok := t.templates[lang+"/"+name]
+	if !ok {
+		tmpl, ok = t.templates[DefaultLanguage+"/"+name]
+		if !ok {
+			return "", "", UnknownTemplateError
+		}
+	}
+
+	var subject, body bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return "", "", err
+	}
+	if err := tmpl.ExecuteTemplate(&body, "body", data); err != nil {
+		return "", "", err
+	}
+
+	return strings.TrimSpace(subject.String()), body.String(), nil
+}
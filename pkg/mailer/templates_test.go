@@ -0,0 +1,70 @@
+package mailer
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTemplates_Render(t *testing.T) {
+	templates, err := NewTemplates()
+	assert.NoError(t, err)
+
+	testTable := []struct {
+		name            string
+		template        string
+		lang            string
+		data            interface{}
+		expectedSubject string
+		expectedBody    string
+		expectedError   error
+	}{
+		{
+			name:            "Russian",
+			template:        VerificationCodeTemplate,
+			lang:            "ru",
+			data:            map[string]interface{}{"Code": 123456, "TTLMinutes": 10},
+			expectedSubject: "Подтверждение email",
+			expectedBody:    "<strong>123456</strong>",
+		},
+		{
+			name:            "English",
+			template:        ResetPasswordTemplate,
+			lang:            "en",
+			data:            map[string]interface{}{"Link": "https://example.com/reset-password?id=abc", "TTLHours": 1},
+			expectedSubject: "Password reset",
+			expectedBody:    `<a href="https://example.com/reset-password?id=abc">`,
+		},
+		{
+			name:            "Fallback to default language",
+			template:        VerificationCodeTemplate,
+			lang:            "de",
+			data:            map[string]interface{}{"Code": 123456, "TTLMinutes": 10},
+			expectedSubject: "Подтверждение email",
+			expectedBody:    "<strong>123456</strong>",
+		},
+		{
+			name:     "Trade offer event",
+			template: TradeOfferTemplate,
+			lang:     "en",
+			data: map[string]interface{}{"Event": "declined", "OfferID": 4, "Nickname": "bob",
+				"UnsubscribeLink": "https://example.com/unsubscribe?token=abc"},
+			expectedSubject: "Trade offer declined",
+			expectedBody:    "<strong>bob</strong> declined trade offer #4.",
+		},
+		{
+			name:          "Unknown template",
+			template:      "unknown",
+			lang:          "ru",
+			expectedError: UnknownTemplateError,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			subject, body, err := templates.Render(testCase.template, testCase.lang, testCase.data)
+			assert.Equal(t, testCase.expectedError, err)
+			assert.Equal(t, testCase.expectedSubject, subject)
+			assert.Contains(t, body, testCase.expectedBody)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package players
+
+import (
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/store"
+	"time"
+)
+
+// Виды превращений карточек
+const (
+	DismantleConversion = "dismantle"
+	UpgradeConversion   = "upgrade"
+	CraftConversion     = "craft"
+)
+
+const DefaultCardConversionsLimit = 20
+
+// CardConversion - запись журнала крафта: какие карточки израсходованы, какая получена
+// и как изменился баланс пыли
+type CardConversion struct {
+	ID               int64     `json:"id"`
+	Kind             string    `json:"kind"`
+	ConsumedCards    []int     `json:"consumedCards"`
+	ProducedCardID   *int      `json:"producedCardID,omitempty"`
+	DustAmount       int       `json:"dustAmount"`
+	DustBalanceAfter int       `json:"dustBalanceAfter"`
+	CreatedAt        time.Time `json:"createdAt"`
+}
+
+type DismantleCardsInput struct {
+	CardIDs []int `json:"cardIDs" binding:"required,min=1,max=50,unique,dive,min=1"`
+}
+
+type UpgradeCardsInput struct {
+	CardIDs []int `json:"cardIDs" binding:"required,min=2,max=10,unique,dive,min=1"`
+}
+
+type CraftCardInput struct {
+	PlayerID int              `json:"playerID" binding:"required,min=1"`
+	Rarity   store.CardRarity `json:"rarity" binding:"required,oneof=1 2"`
+}
+
+type CardConversionsFilter struct {
+	Before int64 `form:"before" binding:"omitempty,min=1"`
+	Limit  int   `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type RarityDust struct {
+	Rarity     store.CardRarity `json:"rarity"`
+	RarityName string           `json:"rarityName"`
+	Dust       int              `json:"dust"`
+}
+
+// CraftingInfo - баланс пыли пользователя и действующие цены крафта
+type CraftingInfo struct {
+	Dust          int          `json:"dust"`
+	UpgradeCards  int          `json:"upgradeCards"`
+	DismantleDust []RarityDust `json:"dismantleDust"`
+	CraftPrice    []RarityDust `json:"craftPrice"`
+}
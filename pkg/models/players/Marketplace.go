@@ -0,0 +1,69 @@
+package players
+
+import (
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/store"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/tournaments"
+	"github.com/google/uuid"
+	"time"
+)
+
+// Статусы объявления на рынке карточек
+const (
+	ActiveListing    = "active"
+	SoldListing      = "sold"
+	CancelledListing = "cancelled"
+)
+
+const (
+	DefaultListingsLimit = 20
+	MaxListingsLimit     = 100
+)
+
+type CardListing struct {
+	ID        int64      `json:"id" db:"id"`
+	CardID    int        `json:"cardID" db:"card_id"`
+	SellerID  uuid.UUID  `json:"sellerID" db:"seller_id"`
+	Price     int        `json:"price" db:"price"`
+	Status    string     `json:"status" db:"status"`
+	BuyerID   *uuid.UUID `json:"buyerID,omitempty" db:"buyer_id"`
+	Fee       int        `json:"fee" db:"fee"`
+	CreatedAt time.Time  `json:"createdAt" db:"created_at"`
+	ClosedAt  *time.Time `json:"closedAt,omitempty" db:"closed_at"`
+}
+
+// CardListingResponse - объявление вместе с карточкой и игроком для витрины рынка
+type CardListingResponse struct {
+	CardListing
+	PlayerID       int                `json:"playerID" db:"player_id"`
+	Rarity         store.CardRarity   `json:"rarity" db:"rarity"`
+	RarityName     string             `json:"rarityName"`
+	Multiply       float32            `json:"multiply" db:"multiply"`
+	Name           string             `json:"name" db:"name"`
+	Photo          string             `json:"photo" db:"photo_link"`
+	TeamID         int                `json:"teamID" db:"team_id"`
+	TeamName       string             `json:"teamName" db:"team_name"`
+	Position       Position           `json:"position" db:"position"`
+	PositionName   string             `json:"positionName"`
+	League         tournaments.League `json:"league" db:"league"`
+	LeagueName     string             `json:"leagueName"`
+	SellerNickname string             `json:"sellerNickname" db:"seller_nickname"`
+}
+
+// CardListingsFilter - фильтры витрины. Before - id последнего объявления предыдущей страницы
+type CardListingsFilter struct {
+	League   tournaments.League `form:"league" binding:"omitempty,min=1,max=2"`
+	Position Position           `form:"position" binding:"omitempty,min=1,max=3"`
+	Rarity   store.CardRarity   `form:"rarity" binding:"omitempty,min=1,max=2"`
+	TeamID   int                `form:"team" binding:"omitempty,min=1"`
+	Before   int64              `form:"before" binding:"omitempty,min=1"`
+	Limit    int                `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type CreateListingInput struct {
+	CardID int `json:"cardID" binding:"required,min=1"`
+	Price  int `json:"price" binding:"required,min=1,max=1000000"`
+}
+
+type ListingIDInput struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
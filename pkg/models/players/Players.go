@@ -123,6 +123,7 @@ type PlayerCardResponse struct {
 	BonusMetricName string             `json:"bonusMetricName"`
 	Multiply        float32            `json:"multiply" db:"multiply"`
 	Unpacked        bool               `json:"unpacked" db:"unpacked"`
+	PackOpeningID   *int64             `json:"packOpeningID,omitempty" db:"pack_opening_id"`
 	Name            string             `json:"name" db:"name"`
 	SweaterNumber   int                `json:"sweaterNumber" db:"sweater_number"`
 	Photo           string             `json:"photo"  db:"photo_link"`
@@ -291,6 +292,7 @@ type UserRosterInfo struct {
 	FantasyPoints     float32            `json:"fantasyPoints" db:"points"`
 	Coins             int                `json:"coins" db:"coins"`
 	Place             int                `json:"place" db:"place"`
+	Nickname          string             `json:"nickname,omitempty" db:"nickname"`
 	PlayerIdNhl       int                `json:"playerIdNhl,omitempty"`
 	MatchIdLocal      int                `json:"matchIdLocal,omitempty"`
 	GameDate          time.Time          `json:"gameDate"`
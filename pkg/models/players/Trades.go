@@ -0,0 +1,65 @@
+package players
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Статусы предложения обмена. Встречное предложение закрывает исходное статусом countered
+const (
+	PendingTrade   = "pending"
+	AcceptedTrade  = "accepted"
+	DeclinedTrade  = "declined"
+	CounteredTrade = "countered"
+	ExpiredTrade   = "expired"
+)
+
+// Ящики предложений обмена для фильтра
+const (
+	IncomingTrades = "incoming"
+	OutgoingTrades = "outgoing"
+)
+
+const DefaultTradeOffersLimit = 50
+
+// TradeOffer - предложение обменять карточки OfferedCards отправителя и Coins монет
+// на карточки RequestedCards получателя
+type TradeOffer struct {
+	ID                int64      `json:"id"`
+	ProposerID        uuid.UUID  `json:"proposerID"`
+	ProposerNickname  string     `json:"proposerNickname"`
+	RecipientID       uuid.UUID  `json:"recipientID"`
+	RecipientNickname string     `json:"recipientNickname"`
+	OfferedCards      []int      `json:"offeredCards"`
+	RequestedCards    []int      `json:"requestedCards"`
+	Coins             int        `json:"coins"`
+	Status            string     `json:"status"`
+	ParentID          *int64     `json:"parentID,omitempty"`
+	CreatedAt         time.Time  `json:"createdAt"`
+	ExpiresAt         time.Time  `json:"expiresAt"`
+	ClosedAt          *time.Time `json:"closedAt,omitempty"`
+}
+
+type TradeOfferInput struct {
+	RecipientID    uuid.UUID `json:"recipientID" binding:"required"`
+	OfferedCards   []int     `json:"offeredCards" binding:"max=10,unique,dive,min=1"`
+	RequestedCards []int     `json:"requestedCards" binding:"max=10,unique,dive,min=1"`
+	Coins          int       `json:"coins" binding:"min=0,max=1000000"`
+}
+
+// CounterTradeOfferInput - встречное предложение. Получатель - отправитель исходного предложения
+type CounterTradeOfferInput struct {
+	OfferedCards   []int `json:"offeredCards" binding:"max=10,unique,dive,min=1"`
+	RequestedCards []int `json:"requestedCards" binding:"max=10,unique,dive,min=1"`
+	Coins          int   `json:"coins" binding:"min=0,max=1000000"`
+}
+
+type TradeOffersFilter struct {
+	Box    string `form:"box" binding:"omitempty,oneof=incoming outgoing"`
+	Status string `form:"status" binding:"omitempty,oneof=pending accepted declined countered expired"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type TradeOfferIDInput struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
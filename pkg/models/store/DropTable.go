@@ -0,0 +1,98 @@
+package store
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+)
+
+// DropTable - правила выпадения карточек в наборе. Сначала заполняются гарантированные слоты Guarantees,
+// редкость остальных слотов выбирается по весам RarityWeights. PositionQuotas задают минимум карточек
+// каждой позиции. Игрок считается звездным, если его player_cost не ниже StarCost, и звездный игрок
+// выпадает в слоте с вероятностью StarChance процентов
+type DropTable struct {
+	RarityWeights  []RarityWeight    `json:"rarityWeights" binding:"required,min=1,max=10,dive"`
+	Guarantees     []RarityGuarantee `json:"guarantees" binding:"max=10,dive"`
+	PositionQuotas []PositionQuota   `json:"positionQuotas" binding:"max=3,dive"`
+	StarCost       float32           `json:"starCost" binding:"min=0"`
+	StarChance     int               `json:"starChance" binding:"min=0,max=100"`
+}
+
+type RarityWeight struct {
+	Rarity CardRarity `json:"rarity" binding:"required"`
+	Weight int        `json:"weight" binding:"required,min=1,max=10000"`
+}
+
+type RarityGuarantee struct {
+	Rarity CardRarity `json:"rarity" binding:"required"`
+	Count  int        `json:"count" binding:"required,min=1"`
+}
+
+// PositionQuota - Position совпадает с players.Position
+type PositionQuota struct {
+	Position int8 `json:"position" binding:"required,min=1,max=3"`
+	Count    int  `json:"count" binding:"required,min=1"`
+}
+
+// DefaultDropTable повторяет поведение набора без настроек: все карточки одной редкости, без квот и звезд
+func DefaultDropTable(rarity CardRarity) DropTable {
+	return DropTable{RarityWeights: []RarityWeight{{Rarity: rarity, Weight: 1}}}
+}
+
+func (t DropTable) Value() (driver.Value, error) {
+	return json.Marshal(t)
+}
+
+func (t *DropTable) Scan(src interface{}) error {
+	b, ok := src.([]byte)
+	if !ok {
+		return errors.New("drop table must be jsonb")
+	}
+	return json.Unmarshal(b, t)
+}
+
+// Odds считает опубликованные шансы: вероятность каждой редкости для негарантированного слота в процентах
+func (t DropTable) Odds(product Product) ProductOdds {
+	odds := ProductOdds{
+		ProductID:        product.ID,
+		PlayerCardsCount: product.PlayerCardsCount,
+		Rarities:         []RarityOdds{},
+		Guarantees:       []RarityGuarantee{},
+		PositionQuotas:   []PositionQuota{},
+		StarCost:         t.StarCost,
+		StarChance:       t.StarChance,
+	}
+
+	var total int
+	for _, w := range t.RarityWeights {
+		total += w.Weight
+	}
+	for _, w := range t.RarityWeights {
+		odds.Rarities = append(odds.Rarities, RarityOdds{
+			Rarity:     w.Rarity,
+			RarityName: w.Rarity.GetCardRarityString(),
+			Chance:     float64(w.Weight) * 100 / float64(total),
+		})
+	}
+	odds.Guarantees = append(odds.Guarantees, t.Guarantees...)
+	odds.PositionQuotas = append(odds.PositionQuotas, t.PositionQuotas...)
+
+	return odds
+}
+
+// ProductOdds - раскрытые шансы выпадения карточек в наборе
+type ProductOdds struct {
+	ProductID        int               `json:"productID"`
+	PlayerCardsCount int               `json:"playerCardsCount"`
+	Rarities         []RarityOdds      `json:"rarities"`
+	Guarantees       []RarityGuarantee `json:"guarantees"`
+	PositionQuotas   []PositionQuota   `json:"positionQuotas"`
+	StarCost         float32           `json:"starCost"`
+	StarChance       int               `json:"starChance"`
+}
+
+type RarityOdds struct {
+	Rarity     CardRarity `json:"rarity"`
+	RarityName string     `json:"rarityName"`
+	Chance     float64    `json:"chance"`
+}
@@ -0,0 +1,84 @@
+package store
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"time"
+)
+
+// PackSeed - пара сидов пользователя для открытия наборов. До раскрытия пользователь видит только
+// ServerSeedHash, сам ServerSeed отдается после смены пары. Каждое открытие увеличивает Nonce
+type PackSeed struct {
+	ID             int64      `json:"-" db:"id"`
+	ProfileID      uuid.UUID  `json:"-" db:"profile_id"`
+	ServerSeed     string     `json:"-" db:"server_seed"`
+	ServerSeedHash string     `json:"serverSeedHash" db:"server_seed_hash"`
+	ClientSeed     string     `json:"clientSeed" db:"client_seed"`
+	Nonce          int        `json:"nonce" db:"nonce"`
+	CreatedAt      time.Time  `json:"createdAt" db:"created_at"`
+	RevealedAt     *time.Time `json:"revealedAt,omitempty" db:"revealed_at"`
+}
+
+type RevealedPackSeed struct {
+	ServerSeed     string    `json:"serverSeed"`
+	ServerSeedHash string    `json:"serverSeedHash"`
+	ClientSeed     string    `json:"clientSeed"`
+	Nonce          int       `json:"nonce"`
+	RevealedAt     time.Time `json:"revealedAt"`
+}
+
+type RotatePackSeedInput struct {
+	ClientSeed string `json:"clientSeed" binding:"required,min=1,max=64,printascii"`
+}
+
+// PackSeedRotation - раскрытая предыдущая пара (если она была) и новая активная пара
+type PackSeedRotation struct {
+	Previous *RevealedPackSeed `json:"previous,omitempty"`
+	Current  PackSeed          `json:"current"`
+}
+
+type PackCard struct {
+	PlayerID int        `json:"playerID"`
+	Rarity   CardRarity `json:"rarity"`
+	Position int8       `json:"position"`
+}
+
+type PackCards []PackCard
+
+func (c PackCards) Value() (driver.Value, error) {
+	if c == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(c)
+}
+
+func (c *PackCards) Scan(src interface{}) error {
+	b, ok := src.([]byte)
+	if !ok {
+		return errors.New("pack cards must be jsonb")
+	}
+	return json.Unmarshal(b, c)
+}
+
+type PackOpeningIDInput struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// PackVerification - пересчет набора по раскрытым сидам. Verified означает, что хэш сервера совпал
+// с опубликованным и пересчитанные карточки совпали с выданными
+type PackVerification struct {
+	OpeningID      int64     `json:"openingID"`
+	ProductID      int       `json:"productID"`
+	OpenedAt       time.Time `json:"openedAt"`
+	ServerSeed     string    `json:"serverSeed"`
+	ServerSeedHash string    `json:"serverSeedHash"`
+	ClientSeed     string    `json:"clientSeed"`
+	Nonce          int       `json:"nonce"`
+	Seed           string    `json:"seed"`
+	DropTable      DropTable `json:"dropTable"`
+	Cards          PackCards `json:"cards"`
+	DerivedCards   PackCards `json:"derivedCards"`
+	Verified       bool      `json:"verified"`
+}
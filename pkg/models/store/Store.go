@@ -3,6 +3,7 @@ package store
 import (
 	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/tournaments"
 	"github.com/google/uuid"
+	"time"
 )
 
 type CardRarity int8
@@ -44,6 +45,48 @@ type Product struct {
 	PhotoLink        string             `json:"photoLink" db:"photo_link"`
 }
 
+// AdminProduct - товар со служебными полями, которые видят только администраторы. В продаже товар,
+// если он активен, не в архиве и текущее время попадает в окно AvailableFrom - AvailableUntil
+type AdminProduct struct {
+	Product
+	Active         bool       `json:"active" db:"active"`
+	AvailableFrom  *time.Time `json:"availableFrom,omitempty" db:"available_from"`
+	AvailableUntil *time.Time `json:"availableUntil,omitempty" db:"available_until"`
+	SortOrder      int        `json:"sortOrder" db:"sort_order"`
+	ArchivedAt     *time.Time `json:"archivedAt,omitempty" db:"archived_at"`
+	DropTable      *DropTable `json:"dropTable,omitempty" db:"drop_table"`
+}
+
+type ProductInput struct {
+	ProductName      string             `json:"productName" binding:"required,min=3,max=100"`
+	Price            int                `json:"price" binding:"required,min=1,max=1000000"`
+	League           tournaments.League `json:"league" binding:"required"`
+	Rarity           CardRarity         `json:"rarity" binding:"required"`
+	PlayerCardsCount int                `json:"playerCardsCount" binding:"required,min=1,max=20"`
+	Active           *bool              `json:"active" binding:"required"`
+	AvailableFrom    *time.Time         `json:"availableFrom"`
+	AvailableUntil   *time.Time         `json:"availableUntil"`
+	// SortOrder 0 - при создании товар встает в конец витрины, при изменении порядок не меняется
+	SortOrder int `json:"sortOrder"`
+}
+
+type ProductIDInput struct {
+	ID int `uri:"id" binding:"required,min=1"`
+}
+
+type AdminProductsFilter struct {
+	IncludeArchived bool `form:"includeArchived"`
+}
+
+// ReorderProductsInput - id товаров в новом порядке показа
+type ReorderProductsInput struct {
+	IDs []int `json:"ids" binding:"required,min=1,max=500,unique,dive,min=1"`
+}
+
+type ProductPhotoInput struct {
+	Name string `uri:"name" binding:"required,max=64"`
+}
+
 type BuyProductModel struct {
 	ID               int `db:"id"`
 	ProfileID        uuid.UUID
@@ -52,6 +95,7 @@ type BuyProductModel struct {
 	League           tournaments.League `db:"league"`
 	Rarity           CardRarity         `db:"rarity"`
 	PlayerCardsCount int                `db:"player_cards_count"`
+	DropTable        DropTable
 }
 
 type BonusMetric int8
@@ -0,0 +1,28 @@
+package user
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// CoinAdjustmentInput - ручное начисление или списание монет администратором. Amount всегда положительный,
+// направление задает эндпоинт
+type CoinAdjustmentInput struct {
+	ProfileID uuid.UUID `json:"profileID" binding:"required"`
+	Amount    int       `json:"amount" binding:"required,min=1,max=1000000"`
+	Reason    string    `json:"reason" binding:"required,min=3,max=500"`
+}
+
+// CoinAdjustment - запись журнала ручных изменений баланса. Amount со знаком: списание отрицательное
+type CoinAdjustment struct {
+	ID        int64     `json:"id" db:"id"`
+	ProfileID uuid.UUID `json:"profileID" db:"profile_id"`
+	AdminID   uuid.UUID `json:"adminID" db:"admin_id"`
+	Amount    int       `json:"amount" db:"amount"`
+	Reason    string    `json:"reason" db:"reason"`
+	CreatedAt time.Time `json:"createdAt" db:"created_at"`
+}
+
+type CoinAdjustmentsInput struct {
+	ProfileID string `form:"profileID" binding:"required,uuid"`
+}
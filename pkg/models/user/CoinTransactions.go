@@ -5,14 +5,114 @@ import (
 	"time"
 )
 
-const SuccessTransaction = "Выполнено"
-const CancelTransaction = "Отменено"
-
-type CoinTransactionsModel struct {
-	ID                 int       `json:"id" db:"id"`
-	ProfileID          uuid.UUID `json:"profileID" db:"profile_id"`
-	TransactionDetails string    `json:"transactionDetails" db:"transaction_details"`
-	Amount             int       `json:"amount" db:"amount"`
-	TransactionDate    time.Time `json:"transactionDate" db:"transaction_date"`
-	Status             string    `json:"status" db:"status"`
+// Виды счетов главной книги
+const (
+	UserAccountKind      = "user"
+	PrizePoolAccountKind = "prize_pool"
+	HouseAccountKind     = "house"
+)
+
+// Причины движения монет
+const (
+	PurchaseReason   = "purchase"
+	EntryFeeReason   = "entry_fee"
+	PrizeReason      = "prize"
+	RefundReason     = "refund"
+	GrantReason      = "grant"
+	AdjustmentReason = "adjustment"
+	// MarketplaceSaleReason - оплата карточки продавцу, MarketplaceFeeReason - комиссия площадки с покупателя
+	MarketplaceSaleReason = "marketplace_sale"
+	MarketplaceFeeReason  = "marketplace_fee"
+	TradeReason           = "trade"
+)
+
+// Типы объектов, на которые ссылается транзакция
+const (
+	SignupReference     = "signup"
+	ProductReference    = "product"
+	TournamentReference = "tournament"
+	AdjustmentReference = "adjustment"
+	ListingReference    = "listing"
+	TradeOfferReference = "trade_offer"
+)
+
+// LedgerAccount указывает на счет: пользователя, призового фонда турнира или системный счет house
+type LedgerAccount struct {
+	Kind         string
+	ProfileID    uuid.UUID
+	TournamentID int
+}
+
+func UserAccount(profileID uuid.UUID) LedgerAccount {
+	return LedgerAccount{Kind: UserAccountKind, ProfileID: profileID}
+}
+
+func PrizePoolAccount(tournamentID int) LedgerAccount {
+	return LedgerAccount{Kind: PrizePoolAccountKind, TournamentID: tournamentID}
+}
+
+func HouseAccount() LedgerAccount {
+	return LedgerAccount{Kind: HouseAccountKind}
+}
+
+// LedgerTransfer - перевод Amount монет со счета From на счет To. Записывается двумя проводками
+type LedgerTransfer struct {
+	From          LedgerAccount
+	To            LedgerAccount
+	Amount        int
+	Reason        string
+	ReferenceType string
+	ReferenceID   string
+}
+
+// CoinTransaction - транзакция с точки зрения пользователя: сумма со знаком и баланс после нее
+type CoinTransaction struct {
+	ID               int64     `json:"id" db:"id"`
+	Reason           string    `json:"reason" db:"reason"`
+	Amount           int       `json:"amount" db:"amount"`
+	BalanceAfter     int       `json:"balanceAfter" db:"balance_after"`
+	CounterpartyKind string    `json:"counterpartyKind" db:"counterparty_kind"`
+	ReferenceType    *string   `json:"referenceType,omitempty" db:"reference_type"`
+	ReferenceID      *string   `json:"referenceID,omitempty" db:"reference_id"`
+	CreatedAt        time.Time `json:"createdAt" db:"created_at"`
+}
+
+// Направление движения монет для фильтра истории
+const (
+	CreditSign = "credit"
+	DebitSign  = "debit"
+)
+
+const (
+	DefaultCoinTransactionsLimit = 20
+	MaxCoinTransactionsLimit     = 100
+)
+
+// CoinTransactionsFilter - параметры запроса истории. From и To в формате RFC3339, To не включается.
+// Cursor - значение nextCursor из предыдущей страницы
+type CoinTransactionsFilter struct {
+	Cursor string    `form:"cursor" binding:"max=128"`
+	Limit  int       `form:"limit" binding:"omitempty,min=1,max=100"`
+	From   time.Time `form:"from"`
+	To     time.Time `form:"to"`
+	Sign   string    `form:"sign" binding:"omitempty,oneof=credit debit"`
+	Reason string    `form:"reason" binding:"omitempty,oneof=purchase entry_fee prize refund grant adjustment marketplace_sale marketplace_fee trade"`
+	Format string    `form:"format" binding:"omitempty,oneof=json csv"`
+}
+
+// CoinTransactionsCursor - позиция в истории: следующая страница начинается с транзакций старше этой
+type CoinTransactionsCursor struct {
+	CreatedAt time.Time
+	ID        int64
+}
+
+type CoinTransactionsSummary struct {
+	TotalSpent int `json:"totalSpent" db:"total_spent"`
+	TotalWon   int `json:"totalWon" db:"total_won"`
+}
+
+type CoinTransactionsPage struct {
+	Transactions []CoinTransaction       `json:"transactions"`
+	NextCursor   string                  `json:"nextCursor,omitempty"`
+	Summary      CoinTransactionsSummary `json:"summary"`
 }
@@ -0,0 +1,53 @@
+package user
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	PendingEmail = "pending"
+	SentEmail    = "sent"
+	FailedEmail  = "failed"
+)
+
+type EmailHeaders map[string]string
+
+func (h EmailHeaders) Value() (driver.Value, error) {
+	if h == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(h)
+}
+
+func (h *EmailHeaders) Scan(src interface{}) error {
+	b, ok := src.([]byte)
+	if !ok {
+		return errors.New("email headers must be jsonb")
+	}
+	return json.Unmarshal(b, h)
+}
+
+type OutboxEmail struct {
+	ID            int          `db:"id"`
+	Recipient     string       `db:"recipient"`
+	Subject       string       `db:"subject"`
+	Body          string       `db:"body"`
+	Headers       EmailHeaders `db:"headers"`
+	Status        string       `db:"status"`
+	Attempts      int          `db:"attempts"`
+	LastError     *string      `db:"last_error"`
+	NextAttemptAt time.Time    `db:"next_attempt_at"`
+	CreatedAt     time.Time    `db:"created_at"`
+	SentAt        *time.Time   `db:"sent_at"`
+}
+
+// EmailChangeUndo хранится в Redis по токену из письма на старый адрес
+type EmailChangeUndo struct {
+	ProfileID uuid.UUID `json:"profileID"`
+	OldEmail  string    `json:"oldEmail"`
+	NewEmail  string    `json:"newEmail"`
+}
@@ -0,0 +1,51 @@
+package user
+
+import (
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/players"
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	PendingExport    = "pending"
+	ProcessingExport = "processing"
+	ReadyExport      = "ready"
+	FailedExport     = "failed"
+	ExpiredExport    = "expired"
+)
+
+type DataExport struct {
+	ID          uuid.UUID  `json:"id" db:"id"`
+	ProfileID   uuid.UUID  `json:"-" db:"profile_id"`
+	Status      string     `json:"status" db:"status"`
+	BlobKey     *string    `json:"-" db:"blob_key"`
+	Size        int64      `json:"size" db:"size"`
+	LastError   *string    `json:"-" db:"last_error"`
+	ClaimedAt   *time.Time `json:"-" db:"claimed_at"`
+	CreatedAt   time.Time  `json:"createdAt" db:"created_at"`
+	CompletedAt *time.Time `json:"completedAt,omitempty" db:"completed_at"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty" db:"expires_at"`
+	DownloadURL string     `json:"downloadURL,omitempty"`
+}
+
+type DataExportIDInput struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+// DataExportDownloadInput - параметры подписанной ссылки на архив
+type DataExportDownloadInput struct {
+	ID        string `form:"id" binding:"required,uuid"`
+	Expires   int64  `form:"expires" binding:"required"`
+	Signature string `form:"signature" binding:"required,max=128"`
+}
+
+// PersonalData - содержимое архива. Каждое поле пишется в отдельный JSON файл
+type PersonalData struct {
+	Profile                 UserInfoModel                `json:"profile"`
+	Roles                   []string                     `json:"roles"`
+	NotificationPreferences NotificationPreferences      `json:"notificationPreferences"`
+	CoinTransactions        []CoinTransaction            `json:"coinTransactions"`
+	PlayerCards             []players.PlayerCardResponse `json:"playerCards"`
+	Rosters                 []players.UserRosterInfo     `json:"rosters"`
+	Sessions                []SessionInfo                `json:"sessions"`
+}
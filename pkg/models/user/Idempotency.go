@@ -0,0 +1,16 @@
+package user
+
+const (
+	IdempotencyInProgress = "in_progress"
+	IdempotencyCompleted  = "completed"
+)
+
+// IdempotentRequest - запись по Idempotency-Key. Пока запрос выполняется, хранит только отпечаток,
+// после завершения - ответ, который отдается повторным запросам
+type IdempotentRequest struct {
+	Status      string `json:"status"`
+	Fingerprint string `json:"fingerprint"`
+	StatusCode  int    `json:"statusCode,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
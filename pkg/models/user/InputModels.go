@@ -10,6 +10,7 @@ type SignUpInput struct {
 type SignInInput struct {
 	Email    string `json:"email" binding:"required,email,max=64"`
 	Password string `json:"password" binding:"required,min=8,max=64"`
+	Device   string `json:"device" binding:"omitempty,max=100"`
 }
 
 type EmailInput struct {
@@ -25,11 +26,28 @@ type RefreshInput struct {
 	RefreshToken string `json:"refreshToken" binding:"required,min=64,max=64"`
 }
 
+type SessionIDInput struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
 type ChangePasswordInput struct {
 	OldPassword string `json:"oldPassword" binding:"required,min=8,max=64"`
 	NewPassword string `json:"newPassword" binding:"required,min=8,max=64"`
 }
 
+type ChangeEmailInput struct {
+	NewEmail string `json:"newEmail" binding:"required,email,max=64"`
+	Password string `json:"password" binding:"required,min=8,max=64"`
+}
+
+type ConfirmEmailChangeInput struct {
+	Code int `json:"code" binding:"required"`
+}
+
+type UndoEmailChangeInput struct {
+	Token string `json:"token" binding:"required,min=64,max=64"`
+}
+
 type ResetPasswordInput struct {
 	Hash        string `json:"hash" binding:"required,min=32,max=32"`
 	NewPassword string `json:"newPassword" binding:"required,min=8,max=64"`
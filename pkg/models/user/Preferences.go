@@ -0,0 +1,63 @@
+package user
+
+import "github.com/google/uuid"
+
+// Категории необязательных писем. Письма с кодами, сбросом пароля и сменой email отправляются всегда
+const (
+	TournamentRemindersCategory = "tournament_reminders"
+	TournamentResultsCategory   = "tournament_results"
+	StoreOffersCategory         = "store_offers"
+	SecurityAlertsCategory      = "security_alerts"
+	TradeOffersCategory         = "trade_offers"
+)
+
+var NotificationCategories = []string{
+	TournamentRemindersCategory,
+	TournamentResultsCategory,
+	StoreOffersCategory,
+	SecurityAlertsCategory,
+	TradeOffersCategory,
+}
+
+type NotificationPreferences struct {
+	TournamentReminders bool `json:"tournamentReminders" db:"tournament_reminders"`
+	TournamentResults   bool `json:"tournamentResults" db:"tournament_results"`
+	StoreOffers         bool `json:"storeOffers" db:"store_offers"`
+	SecurityAlerts      bool `json:"securityAlerts" db:"security_alerts"`
+	TradeOffers         bool `json:"tradeOffers" db:"trade_offers"`
+}
+
+// Enabled сообщает, подписан ли пользователь на категорию
+func (p NotificationPreferences) Enabled(category string) bool {
+	switch category {
+	case TournamentRemindersCategory:
+		return p.TournamentReminders
+	case TournamentResultsCategory:
+		return p.TournamentResults
+	case StoreOffersCategory:
+		return p.StoreOffers
+	case SecurityAlertsCategory:
+		return p.SecurityAlerts
+	case TradeOffersCategory:
+		return p.TradeOffers
+	}
+	return false
+}
+
+type NotificationPreferencesInput struct {
+	TournamentReminders *bool `json:"tournamentReminders" binding:"required"`
+	TournamentResults   *bool `json:"tournamentResults" binding:"required"`
+	StoreOffers         *bool `json:"storeOffers" binding:"required"`
+	SecurityAlerts      *bool `json:"securityAlerts" binding:"required"`
+	// TradeOffers не обязателен: если не передан, настройка не меняется
+	TradeOffers *bool `json:"tradeOffers"`
+}
+
+type UnsubscribeInput struct {
+	Token string `form:"token" binding:"required,max=256"`
+}
+
+type UnsubscribeToken struct {
+	ProfileID uuid.UUID
+	Category  string
+}
@@ -0,0 +1,19 @@
+package user
+
+type UpdateProfileInput struct {
+	Nickname string `json:"nickname" binding:"required,min=4,max=64"`
+}
+
+type AvatarInput struct {
+	Name string `uri:"name" binding:"required,max=128"`
+}
+
+type AvatarSize struct {
+	Size int    `json:"size"`
+	URL  string `json:"url"`
+}
+
+type Avatar struct {
+	PhotoLink string       `json:"photoLink"`
+	Sizes     []AvatarSize `json:"sizes"`
+}
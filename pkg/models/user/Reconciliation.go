@@ -0,0 +1,42 @@
+package user
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Виды расхождений, которые находит сверка балансов
+const (
+	BalanceDiscrepancy               = "balance"
+	UnbalancedTransactionDiscrepancy = "unbalanced_transaction"
+	NegativePrizePoolDiscrepancy     = "negative_prize_pool"
+)
+
+type ReconciliationRun struct {
+	ID              int64      `json:"id" db:"id"`
+	StartedAt       time.Time  `json:"startedAt" db:"started_at"`
+	FinishedAt      *time.Time `json:"finishedAt,omitempty" db:"finished_at"`
+	CheckedProfiles int        `json:"checkedProfiles" db:"checked_profiles"`
+	Discrepancies   int        `json:"discrepancies" db:"discrepancies"`
+}
+
+// ReconciliationDiscrepancy - найденное расхождение. Для balance Expected - сумма проводок счета, Actual -
+// user_profile.coins. Для unbalanced_transaction Expected равен нулю, Actual - сумма проводок транзакции
+type ReconciliationDiscrepancy struct {
+	ID            int64      `json:"id" db:"id"`
+	Kind          string     `json:"kind" db:"kind"`
+	ProfileID     *uuid.UUID `json:"profileID,omitempty" db:"profile_id"`
+	TournamentID  *int64     `json:"tournamentID,omitempty" db:"tournament_id"`
+	TransactionID *int64     `json:"transactionID,omitempty" db:"transaction_id"`
+	Expected      int64      `json:"expected" db:"expected"`
+	Actual        int64      `json:"actual" db:"actual"`
+}
+
+type ReconciliationReport struct {
+	ReconciliationRun
+	Items []ReconciliationDiscrepancy `json:"items"`
+}
+
+type ReconciliationReportInput struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
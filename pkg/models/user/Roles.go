@@ -0,0 +1,27 @@
+package user
+
+import "github.com/google/uuid"
+
+const (
+	AdminRole = "admin"
+)
+
+type RoleInput struct {
+	ProfileID uuid.UUID `json:"profileID" binding:"required"`
+	Role      string    `json:"role" binding:"required,oneof=admin"`
+}
+
+type AccessTokenClaims struct {
+	UserID string
+	Roles  []string
+}
+
+func (c AccessTokenClaims) HasRole(role string) bool {
+	for _, r := range c.Roles {
+		if r == role {
+			return true
+		}
+	}
+
+	return false
+}
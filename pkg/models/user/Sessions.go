@@ -5,6 +5,14 @@ import (
 	"time"
 )
 
+const (
+	RefreshTokenReuseEvent = "refresh_token_reuse"
+	EmailChangedEvent      = "email_changed"
+	EmailChangeUndoneEvent = "email_change_undone"
+	ProfileDeletedEvent    = "profile_deleted"
+	ProfileRestoredEvent   = "profile_restored"
+)
+
 type Tokens struct {
 	AccessToken  string `json:"accessToken"`
 	RefreshToken string `json:"refreshToken"`
@@ -12,8 +20,33 @@ type Tokens struct {
 }
 
 type RefreshSession struct {
-	ProfileID    uuid.UUID `json:"profileID" db:"profile_id"`
-	RefreshToken string    `json:"refreshToken" db:"refresh_token_id"`
-	IssuedAt     time.Time `json:"issuedAt" db:"issued_at"`
-	ExpiresAt    time.Time `json:"expiresAt" db:"expires_in"`
+	ProfileID    uuid.UUID  `json:"profileID" db:"profile_id"`
+	FamilyID     uuid.UUID  `json:"familyID" db:"family_id"`
+	RefreshToken string     `json:"refreshToken" db:"refresh_token_id"`
+	IssuedAt     time.Time  `json:"issuedAt" db:"issued_at"`
+	ExpiresAt    time.Time  `json:"expiresAt" db:"expires_in"`
+	RotatedAt    *time.Time `json:"rotatedAt" db:"rotated_at"`
+	DeviceInfo
+}
+
+type DeviceInfo struct {
+	UserAgent string `json:"userAgent" db:"user_agent"`
+	IP        string `json:"ip" db:"ip"`
+	Device    string `json:"device" db:"device"`
+}
+
+type SessionInfo struct {
+	ID         uuid.UUID `json:"id" db:"family_id"`
+	CreatedAt  time.Time `json:"createdAt" db:"created_at"`
+	LastUsedAt time.Time `json:"lastUsedAt" db:"last_used_at"`
+	ExpiresAt  time.Time `json:"expiresAt" db:"expires_in"`
+	DeviceInfo
+}
+
+type SecurityEvent struct {
+	ID        int       `json:"id" db:"id"`
+	ProfileID uuid.UUID `json:"profileID" db:"profile_id"`
+	EventType string    `json:"eventType" db:"event_type"`
+	Details   string    `json:"details" db:"details"`
+	CreatedAt time.Time `json:"createdAt" db:"created_at"`
 }
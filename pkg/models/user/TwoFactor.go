@@ -0,0 +1,44 @@
+package user
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type TwoFactor struct {
+	ProfileID    uuid.UUID  `db:"profile_id"`
+	Secret       string     `db:"secret"`
+	Enabled      bool       `db:"enabled"`
+	LastUsedStep int64      `db:"last_used_step"`
+	CreatedAt    time.Time  `db:"created_at"`
+	ConfirmedAt  *time.Time `db:"confirmed_at"`
+}
+
+type TwoFactorEnrollment struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauthURI"`
+}
+
+type RecoveryCodes struct {
+	Codes []string `json:"codes"`
+}
+
+// TwoFactorChallenge выдается при входе вместо токенов, если у пользователя включена 2FA
+type TwoFactorChallenge struct {
+	Challenge string `json:"challenge"`
+	ExpiresIn int64  `json:"expiresIn"`
+}
+
+type TwoFactorChallengeData struct {
+	ProfileID uuid.UUID `json:"profileID"`
+	Device    string    `json:"device"`
+}
+
+type TwoFactorCodeInput struct {
+	Code string `json:"code" binding:"required,min=6,max=10"`
+}
+
+type TwoFactorVerifyInput struct {
+	Challenge string `json:"challenge" binding:"required,min=64,max=64"`
+	Code      string `json:"code" binding:"required,min=6,max=10"`
+}
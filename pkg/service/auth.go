@@ -3,10 +3,12 @@ package service
 import (
 	"errors"
 	"github.com/Frozen-Fantasy/fantasy-backend.git/config"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/blobstore"
 	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"log"
+	"strings"
 	"time"
 )
 
@@ -15,6 +17,7 @@ var (
 	AuthHeaderError          = errors.New("пустой заголовок авторизации")
 	InvalidAuthHeaderError   = errors.New("невалидный заголовок авторизации")
 	EmptyTokenError          = errors.New("пустой access токен")
+	RefreshTokenReuseError   = errors.New("refresh токен уже был использован, сессия завершена")
 )
 
 const startBalance = 1000
@@ -31,14 +34,44 @@ type UserStorage interface {
 	CreateSession(session user.RefreshSession) error
 	GetSessionByRefreshToken(refreshTokenID string) (user.RefreshSession, error)
 	DeleteSessionByRefreshToken(refreshTokenID string) error
+	RotateSession(refreshTokenID string) (bool, error)
+	DeleteSessionsByFamilyID(familyID uuid.UUID) error
+	DeleteExpiredSessionFamilies(limit int) (int, error)
+	CreateSecurityEvent(event user.SecurityEvent) error
+	GetActiveSessionsByProfileID(profileID uuid.UUID) ([]user.SessionInfo, error)
+	DeleteSessionFamily(profileID uuid.UUID, familyID uuid.UUID) error
+	DeleteOtherSessions(profileID uuid.UUID, currentFamilyID uuid.UUID) error
+	RevokeAllSessions(profileID uuid.UUID) error
 	GetUserInfo(userID uuid.UUID) (user.UserInfoModel, error)
 	ChangePassword(inp user.ChangePasswordModel) error
 	UpdatePassword(tx *sqlx.Tx, inp user.ChangePasswordModel) error
+	UpdatePasswordHash(inp user.ChangePasswordModel) error
 	DeleteProfile(profileID uuid.UUID) error
 	DeleteAllSessionsByProfileID(tx *sqlx.Tx, profileID uuid.UUID) error
-	CreateCoinTransaction(tx *sqlx.Tx, u user.CoinTransactionsModel) error
-	GetCoinTransactionsByProfileID(profileID uuid.UUID) ([]user.CoinTransactionsModel, error)
-	UpdateBalance(tx *sqlx.Tx, profileID uuid.UUID, coins int) error
+	GetCoinTransactionsByProfileID(profileID uuid.UUID) ([]user.CoinTransaction, error)
+	GetCoinTransactionsPage(profileID uuid.UUID, filter user.CoinTransactionsFilter, after *user.CoinTransactionsCursor, limit int) ([]user.CoinTransaction, error)
+	GetCoinTransactionsSummary(profileID uuid.UUID, filter user.CoinTransactionsFilter) (user.CoinTransactionsSummary, error)
+	CreateCoinAdjustment(adj user.CoinAdjustment) (user.CoinAdjustment, error)
+	GetCoinAdjustments(profileID uuid.UUID) ([]user.CoinAdjustment, error)
+	GetProfileRoles(profileID uuid.UUID) ([]string, error)
+	AddProfileRole(profileID uuid.UUID, role string) error
+	RemoveProfileRole(profileID uuid.UUID, role string) error
+	GetTwoFactor(profileID uuid.UUID) (user.TwoFactor, error)
+	SaveTwoFactorSecret(profileID uuid.UUID, secret string) error
+	UseTwoFactorStep(profileID uuid.UUID, step int64) (bool, error)
+	EnableTwoFactor(profileID uuid.UUID, recoveryCodeHashes []string) error
+	DisableTwoFactor(profileID uuid.UUID) error
+	ReplaceRecoveryCodes(profileID uuid.UUID, recoveryCodeHashes []string) error
+	UseRecoveryCode(profileID uuid.UUID, codeHash string) (bool, error)
+	GetNicknameChangedAt(profileID uuid.UUID) (*time.Time, error)
+	UpdateNickname(profileID uuid.UUID, nickname string) error
+	UpdatePhotoLink(profileID uuid.UUID, photoLink string) error
+	GetProfileDeletedAt(profileID uuid.UUID) (*time.Time, error)
+	RestoreProfile(profileID uuid.UUID) error
+	GetProfilesToPurge(deletedBefore time.Time, limit int) ([]user.UserInfoModel, error)
+	PurgeProfile(profileID uuid.UUID, anonymousNickname string) error
+	GetEmailByProfileID(profileID uuid.UUID) (string, error)
+	ChangeEmail(profileID uuid.UUID, oldEmail string, newEmail string) error
 }
 
 type UserRStorage interface {
@@ -46,22 +79,40 @@ type UserRStorage interface {
 	GetVerificationCode(email string) (int, error)
 	CreateResetPasswordHash(email string) (string, error)
 	GetEmailByResetPasswordHash(resetHash string) (string, error)
+	CreateTwoFactorChallenge(data user.TwoFactorChallengeData) (user.TwoFactorChallenge, error)
+	GetTwoFactorChallenge(challenge string) (user.TwoFactorChallengeData, error)
+	DeleteTwoFactorChallenge(challenge string) error
+	CreateEmailChange(profileID uuid.UUID, newEmail string) error
+	GetEmailChange(profileID uuid.UUID) (string, error)
+	DeleteEmailChange(profileID uuid.UUID) error
+	CreateEmailChangeUndoToken(data user.EmailChangeUndo, ttl time.Duration) (string, error)
+	GetEmailChangeUndo(token string) (user.EmailChangeUndo, error)
+	DeleteEmailChangeUndoToken(token string) error
 }
 
-func NewUserService(storage UserStorage, rStorage UserRStorage, jwt *Manager, cfg config.ServiceConfiguration) *UserService {
+func NewUserService(storage UserStorage, rStorage UserRStorage, limiter *RateLimitService, mail *MailService,
+	blobs blobstore.BlobStore, jwt *Manager, cfg config.ServiceConfiguration) *UserService {
 	return &UserService{
 		storage:  storage,
 		rStorage: rStorage,
+		limiter:  limiter,
+		mail:     mail,
+		blobs:    blobs,
 		Jwt:      jwt,
 		cfg:      cfg,
+		hasher:   NewArgon2idHasher(DefaultArgon2Params),
 	}
 }
 
 type UserService struct {
 	storage  UserStorage
 	rStorage UserRStorage
+	limiter  *RateLimitService
+	mail     *MailService
+	blobs    blobstore.BlobStore
 	Jwt      *Manager
 	cfg      config.ServiceConfiguration
+	hasher   PasswordHasher
 }
 
 func (s *UserService) SignUp(input user.SignUpInput) error {
@@ -101,14 +152,7 @@ func (s *UserService) SignUp(input user.SignUpInput) error {
 		return err
 	}
 
-	salt, err := GenerateSalt()
-	if err != nil {
-		log.Println("Service. GenerateSalt:", err)
-		return err
-	}
-
-	hasher := NewSHA1Hasher(salt)
-	passwordHash, err := hasher.Hash(input.Password)
+	passwordHash, err := s.hasher.Hash(input.Password)
 	if err != nil {
 		log.Println("Service. Hash:", err)
 		return err
@@ -117,7 +161,6 @@ func (s *UserService) SignUp(input user.SignUpInput) error {
 		Nickname:        input.Nickname,
 		Email:           input.Email,
 		PasswordEncoded: passwordHash,
-		PasswordSalt:    hasher.salt,
 		Coins:           startBalance,
 	}
 	err = s.storage.SignUp(userInfo)
@@ -129,9 +172,15 @@ func (s *UserService) SignUp(input user.SignUpInput) error {
 	return nil
 }
 
-func (s *UserService) SignIn(input user.SignInInput) (user.Tokens, error) {
+func (s *UserService) SignIn(input user.SignInInput, device user.DeviceInfo) (user.Tokens, error) {
 	var tokens user.Tokens
 
+	failedSignInKey := "failed_sign_in_" + strings.ToLower(input.Email)
+	err := s.limiter.CheckLocked(failedSignInKey, s.cfg.RateLimits.FailedSignIn)
+	if err != nil {
+		return tokens, err
+	}
+
 	profileID, err := s.storage.GetProfileIDByEmail(input.Email)
 	if err != nil {
 		log.Println("Service. GetProfileIDByEmail:", err)
@@ -147,10 +196,38 @@ func (s *UserService) SignIn(input user.SignInInput) (user.Tokens, error) {
 	err = ComparePasswords(userData.PasswordEncoded, input.Password, userData.PasswordSalt)
 	if err != nil {
 		log.Println("Service. ComparePasswords:", err)
+		if err == IncorrectPasswordError {
+			if lockErr := s.limiter.RegisterFailure(failedSignInKey, s.cfg.RateLimits.FailedSignIn); lockErr != nil {
+				log.Println("Service. RegisterFailure:", lockErr)
+			}
+		}
+		return tokens, err
+	}
+
+	if err = s.limiter.Reset(failedSignInKey); err != nil {
+		log.Println("Service. Reset:", err)
+	}
+
+	err = s.checkDeletionGracePeriod(profileID)
+	if err != nil {
+		return tokens, err
+	}
+
+	err = s.rehashPasswordIfNeeded(userData, input.Password)
+	if err != nil {
+		log.Println("Service. RehashPasswordIfNeeded:", err)
+	}
+
+	if device.Device == "" {
+		device.Device = input.Device
+	}
+
+	err = s.requireTwoFactor(profileID, device.Device)
+	if err != nil {
 		return tokens, err
 	}
 
-	tokens, err = s.CreateSession(profileID)
+	tokens, err = s.CreateSession(profileID, device)
 	if err != nil {
 		log.Println("Service. CreateSession:", err)
 		return tokens, err
@@ -159,7 +236,7 @@ func (s *UserService) SignIn(input user.SignInInput) (user.Tokens, error) {
 	return tokens, nil
 }
 
-func (s *UserService) RefreshTokens(refreshTokenID string) (user.Tokens, error) {
+func (s *UserService) RefreshTokens(refreshTokenID string, device user.DeviceInfo) (user.Tokens, error) {
 	var tokens user.Tokens
 
 	session, err := s.storage.GetSessionByRefreshToken(refreshTokenID)
@@ -168,17 +245,30 @@ func (s *UserService) RefreshTokens(refreshTokenID string) (user.Tokens, error)
 		return tokens, err
 	}
 
-	err = s.storage.DeleteSessionByRefreshToken(refreshTokenID)
-	if err != nil {
-		log.Println("Service. DeleteSessionByRefreshToken:", err)
-		return tokens, err
+	if session.RotatedAt != nil {
+		return tokens, s.revokeSessionFamily(session)
 	}
 
 	if session.ExpiresAt.Before(time.Now()) {
+		err = s.storage.DeleteSessionByRefreshToken(refreshTokenID)
+		if err != nil {
+			log.Println("Service. DeleteSessionByRefreshToken:", err)
+			return tokens, err
+		}
 		return tokens, InvalidRefreshTokenError
 	}
 
-	tokens, err = s.CreateSession(session.ProfileID)
+	rotated, err := s.storage.RotateSession(refreshTokenID)
+	if err != nil {
+		log.Println("Service. RotateSession:", err)
+		return tokens, err
+	}
+	if !rotated {
+		return tokens, s.revokeSessionFamily(session)
+	}
+
+	device.Device = session.Device
+	tokens, err = s.createSession(session.ProfileID, session.FamilyID, device)
 	if err != nil {
 		log.Println("Service. CreateSession:", err)
 		return tokens, err
@@ -187,13 +277,54 @@ func (s *UserService) RefreshTokens(refreshTokenID string) (user.Tokens, error)
 	return tokens, nil
 }
 
-func (s *UserService) CreateSession(userID uuid.UUID) (user.Tokens, error) {
+// revokeSessionFamily вызывается при повторном предъявлении уже обновленного refresh токена:
+// токен мог быть украден, поэтому завершаются все сессии, выросшие из того же входа
+func (s *UserService) revokeSessionFamily(session user.RefreshSession) error {
+	err := s.storage.DeleteSessionsByFamilyID(session.FamilyID)
+	if err != nil {
+		log.Println("Service. DeleteSessionsByFamilyID:", err)
+		return err
+	}
+
+	err = s.storage.CreateSecurityEvent(user.SecurityEvent{
+		ProfileID: session.ProfileID,
+		EventType: user.RefreshTokenReuseEvent,
+		Details:   "family " + session.FamilyID.String(),
+	})
+	if err != nil {
+		log.Println("Service. CreateSecurityEvent:", err)
+		return err
+	}
+
+	return RefreshTokenReuseError
+}
+
+func (s *UserService) CreateSession(userID uuid.UUID, device user.DeviceInfo) (user.Tokens, error) {
+	if device.Device == "" {
+		device.Device = deviceLabel(device.UserAgent)
+	}
+
+	err := s.restoreProfile(userID)
+	if err != nil {
+		return user.Tokens{}, err
+	}
+
+	return s.createSession(userID, uuid.New(), device)
+}
+
+func (s *UserService) createSession(userID uuid.UUID, familyID uuid.UUID, device user.DeviceInfo) (user.Tokens, error) {
 	var (
 		pair user.Tokens
 		err  error
 	)
 
-	pair.ExpiresIn, pair.AccessToken, err = s.Jwt.CreateJWT(userID.String())
+	roles, err := s.storage.GetProfileRoles(userID)
+	if err != nil {
+		log.Println("Service. GetProfileRoles:", err)
+		return pair, err
+	}
+
+	pair.ExpiresIn, pair.AccessToken, err = s.Jwt.CreateJWT(userID.String(), roles)
 	if err != nil {
 		log.Println("Service. CreateJWT:", err)
 		return pair, err
@@ -207,8 +338,10 @@ func (s *UserService) CreateSession(userID uuid.UUID) (user.Tokens, error) {
 
 	session := user.RefreshSession{
 		ProfileID:    userID,
+		FamilyID:     familyID,
 		RefreshToken: pair.RefreshToken,
 		ExpiresAt:    time.Now().Add(s.Jwt.RefreshTokenLifetime),
+		DeviceInfo:   device,
 	}
 
 	err = s.storage.CreateSession(session)
@@ -217,9 +350,19 @@ func (s *UserService) CreateSession(userID uuid.UUID) (user.Tokens, error) {
 }
 
 func (s *UserService) Logout(refreshTokenID string) error {
-	err := s.storage.DeleteSessionByRefreshToken(refreshTokenID)
+	session, err := s.storage.GetSessionByRefreshToken(refreshTokenID)
+	if err != nil {
+		log.Println("Service. GetSessionByRefreshToken:", err)
+		return err
+	}
+
+	if session.RotatedAt != nil {
+		return s.revokeSessionFamily(session)
+	}
+
+	err = s.storage.DeleteSessionsByFamilyID(session.FamilyID)
 	if err != nil {
-		log.Println("Service. DeleteSessionByRefreshToken:", err)
+		log.Println("Service. DeleteSessionsByFamilyID:", err)
 		return err
 	}
 
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"image"
+	"image/color"
+)
+
+// resizeSquare вырезает из центра изображения квадрат и масштабирует его до size x size.
+// При уменьшении цвет пикселя - среднее по соответствующей области исходного изображения
+func resizeSquare(src image.Image, size int) *image.NRGBA {
+	bounds := src.Bounds()
+	side := bounds.Dx()
+	if bounds.Dy() < side {
+		side = bounds.Dy()
+	}
+	x0 := bounds.Min.X + (bounds.Dx()-side)/2
+	y0 := bounds.Min.Y + (bounds.Dy()-side)/2
+
+	dst := image.NewNRGBA(image.Rect(0, 0, size, size))
+	scale := float64(side) / float64(size)
+
+	for y := 0; y < size; y++ {
+		sy0 := y0 + int(float64(y)*scale)
+		sy1 := y0 + int(float64(y+1)*scale)
+		if sy1 <= sy0 {
+			sy1 = sy0 + 1
+		}
+
+		for x := 0; x < size; x++ {
+			sx0 := x0 + int(float64(x)*scale)
+			sx1 := x0 + int(float64(x+1)*scale)
+			if sx1 <= sx0 {
+				sx1 = sx0 + 1
+			}
+
+			var r, g, b, a, n uint64
+			for sy := sy0; sy < sy1; sy++ {
+				for sx := sx0; sx < sx1; sx++ {
+					c := color.NRGBA64Model.Convert(src.At(sx, sy)).(color.NRGBA64)
+					r += uint64(c.R)
+					g += uint64(c.G)
+					b += uint64(c.B)
+					a += uint64(c.A)
+					n++
+				}
+			}
+
+			dst.SetNRGBA(x, y, color.NRGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(b / n >> 8),
+				A: uint8(a / n >> 8),
+			})
+		}
+	}
+
+	return dst
+}
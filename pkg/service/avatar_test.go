@@ -0,0 +1,29 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestResizeSquare(t *testing.T) {
+	// Прямоугольник 300x100: слева и справа красные поля по 100px, в центре синий квадрат
+	src := image.NewNRGBA(image.Rect(0, 0, 300, 100))
+	for y := 0; y < 100; y++ {
+		for x := 0; x < 300; x++ {
+			c := color.NRGBA{R: 255, A: 255}
+			if x >= 100 && x < 200 {
+				c = color.NRGBA{B: 255, A: 255}
+			}
+			src.SetNRGBA(x, y, c)
+		}
+	}
+
+	for _, size := range []int{256, 64, 1} {
+		dst := resizeSquare(src, size)
+		assert.Equal(t, image.Rect(0, 0, size, size), dst.Bounds())
+		assert.Equal(t, color.NRGBA{B: 255, A: 255}, dst.NRGBAAt(0, 0))
+		assert.Equal(t, color.NRGBA{B: 255, A: 255}, dst.NRGBAAt(size-1, size-1))
+	}
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"errors"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/google/uuid"
+	"log"
+	"strings"
+)
+
+var SelfAdjustmentError = errors.New("администратор не может изменять собственный баланс")
+
+func (s *UserService) GrantCoins(adminID uuid.UUID, inp user.CoinAdjustmentInput) (user.CoinAdjustment, error) {
+	return s.adjustCoins(adminID, inp, inp.Amount)
+}
+
+func (s *UserService) DeductCoins(adminID uuid.UUID, inp user.CoinAdjustmentInput) (user.CoinAdjustment, error) {
+	return s.adjustCoins(adminID, inp, -inp.Amount)
+}
+
+func (s *UserService) adjustCoins(adminID uuid.UUID, inp user.CoinAdjustmentInput, amount int) (user.CoinAdjustment, error) {
+	if adminID == inp.ProfileID {
+		return user.CoinAdjustment{}, SelfAdjustmentError
+	}
+
+	adj, err := s.storage.CreateCoinAdjustment(user.CoinAdjustment{
+		ProfileID: inp.ProfileID,
+		AdminID:   adminID,
+		Amount:    amount,
+		Reason:    strings.TrimSpace(inp.Reason),
+	})
+	if err != nil {
+		log.Println("Service. CreateCoinAdjustment:", err)
+		return adj, err
+	}
+
+	return adj, nil
+}
+
+func (s *UserService) GetCoinAdjustments(profileID uuid.UUID) ([]user.CoinAdjustment, error) {
+	adjustments, err := s.storage.GetCoinAdjustments(profileID)
+	if err != nil {
+		log.Println("Service. GetCoinAdjustments:", err)
+		return adjustments, err
+	}
+
+	return adjustments, nil
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type adjustmentStorage struct {
+	UserStorage
+	created []user.CoinAdjustment
+}
+
+func (s *adjustmentStorage) CreateCoinAdjustment(adj user.CoinAdjustment) (user.CoinAdjustment, error) {
+	s.created = append(s.created, adj)
+	return adj, nil
+}
+
+func TestUserService_adjustCoins(t *testing.T) {
+	storage := &adjustmentStorage{}
+	s := &UserService{storage: storage}
+	adminID, profileID := uuid.New(), uuid.New()
+	inp := user.CoinAdjustmentInput{ProfileID: profileID, Amount: 300, Reason: "  Компенсация  "}
+
+	adj, err := s.GrantCoins(adminID, inp)
+	assert.NoError(t, err)
+	assert.Equal(t, user.CoinAdjustment{ProfileID: profileID, AdminID: adminID, Amount: 300, Reason: "Компенсация"}, adj)
+
+	adj, err = s.DeductCoins(adminID, inp)
+	assert.NoError(t, err)
+	assert.Equal(t, -300, adj.Amount)
+
+	_, err = s.GrantCoins(profileID, inp)
+	assert.Equal(t, SelfAdjustmentError, err)
+	assert.Len(t, storage.created, 2)
+}
@@ -1,18 +1,149 @@
 package service
 
 import (
+	"encoding/base64"
+	"encoding/csv"
+	"errors"
+	"fmt"
 	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
 	"github.com/google/uuid"
+	"io"
 	"log"
+	"strconv"
+	"strings"
+	"time"
 )
 
-func (s *UserService) GetCoinTransactions(profileID uuid.UUID) ([]user.CoinTransactionsModel, error) {
+var (
+	InvalidTransactionsCursorError = errors.New("некорректный курсор истории транзакций")
+	InvalidTransactionsPeriodError = errors.New("начало периода должно быть раньше конца")
+)
+
+// encodeTransactionsCursor кодирует позицию последней транзакции страницы в непрозрачную строку
+func encodeTransactionsCursor(tr user.CoinTransaction) string {
+	raw := fmt.Sprintf("%d:%d", tr.CreatedAt.UnixNano(), tr.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
 
-	transactions, err := s.storage.GetCoinTransactionsByProfileID(profileID)
+func decodeTransactionsCursor(cursor string) (*user.CoinTransactionsCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
 	if err != nil {
-		log.Println("Service. GetCoinTransactions:", err)
-		return transactions, err
+		return nil, InvalidTransactionsCursorError
+	}
+
+	parts := strings.Split(string(raw), ":")
+	if len(parts) != 2 {
+		return nil, InvalidTransactionsCursorError
+	}
+	nanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, InvalidTransactionsCursorError
+	}
+	id, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, InvalidTransactionsCursorError
+	}
+
+	return &user.CoinTransactionsCursor{CreatedAt: time.Unix(0, nanos).UTC(), ID: id}, nil
+}
+
+func checkTransactionsPeriod(filter user.CoinTransactionsFilter) error {
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return InvalidTransactionsPeriodError
+	}
+
+	return nil
+}
+
+// GetCoinTransactions возвращает страницу истории от новых транзакций к старым и сводку по всему фильтру
+func (s *UserService) GetCoinTransactions(profileID uuid.UUID, filter user.CoinTransactionsFilter) (user.CoinTransactionsPage, error) {
+	var page user.CoinTransactionsPage
+
+	if err := checkTransactionsPeriod(filter); err != nil {
+		return page, err
+	}
+
+	var after *user.CoinTransactionsCursor
+	if filter.Cursor != "" {
+		var err error
+		after, err = decodeTransactionsCursor(filter.Cursor)
+		if err != nil {
+			return page, err
+		}
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = user.DefaultCoinTransactionsLimit
+	}
+	if limit > user.MaxCoinTransactionsLimit {
+		limit = user.MaxCoinTransactionsLimit
+	}
+
+	// Запрашиваем на одну транзакцию больше, чтобы понять, есть ли следующая страница
+	transactions, err := s.storage.GetCoinTransactionsPage(profileID, filter, after, limit+1)
+	if err != nil {
+		log.Println("Service. GetCoinTransactionsPage:", err)
+		return page, err
+	}
+	if len(transactions) > limit {
+		transactions = transactions[:limit]
+		page.NextCursor = encodeTransactionsCursor(transactions[limit-1])
+	}
+	page.Transactions = transactions
+
+	page.Summary, err = s.storage.GetCoinTransactionsSummary(profileID, filter)
+	if err != nil {
+		log.Println("Service. GetCoinTransactionsSummary:", err)
+		return page, err
+	}
+
+	return page, nil
+}
+
+// WriteCoinTransactionsCSV пишет в w выписку по всем транзакциям фильтра, курсор и limit не учитываются
+func (s *UserService) WriteCoinTransactionsCSV(profileID uuid.UUID, filter user.CoinTransactionsFilter, w io.Writer) error {
+	if err := checkTransactionsPeriod(filter); err != nil {
+		return err
+	}
+
+	transactions, err := s.storage.GetCoinTransactionsPage(profileID, filter, nil, 0)
+	if err != nil {
+		log.Println("Service. GetCoinTransactionsPage:", err)
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	err = writer.Write([]string{"id", "created_at", "reason", "amount", "balance_after", "counterparty",
+		"reference_type", "reference_id"})
+	if err != nil {
+		return err
+	}
+
+	for _, tr := range transactions {
+		var referenceType, referenceID string
+		if tr.ReferenceType != nil {
+			referenceType = *tr.ReferenceType
+		}
+		if tr.ReferenceID != nil {
+			referenceID = *tr.ReferenceID
+		}
+
+		err = writer.Write([]string{
+			strconv.FormatInt(tr.ID, 10),
+			tr.CreatedAt.UTC().Format(time.RFC3339),
+			tr.Reason,
+			strconv.Itoa(tr.Amount),
+			strconv.Itoa(tr.BalanceAfter),
+			tr.CounterpartyKind,
+			referenceType,
+			referenceID,
+		})
+		if err != nil {
+			return err
+		}
 	}
 
-	return transactions, err
+	writer.Flush()
+	return writer.Error()
 }
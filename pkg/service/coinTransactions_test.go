@@ -0,0 +1,123 @@
+package service
+
+import (
+	"bytes"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// historyStorage отдает историю из памяти так же, как GetCoinTransactionsPage: от новых к старым после курсора
+type historyStorage struct {
+	UserStorage
+	transactions []user.CoinTransaction
+}
+
+func (s *historyStorage) GetCoinTransactionsPage(profileID uuid.UUID, filter user.CoinTransactionsFilter,
+	after *user.CoinTransactionsCursor, limit int) ([]user.CoinTransaction, error) {
+	res := []user.CoinTransaction{}
+	for _, tr := range s.transactions {
+		if after != nil && !(tr.CreatedAt.Before(after.CreatedAt) ||
+			tr.CreatedAt.Equal(after.CreatedAt) && tr.ID < after.ID) {
+			continue
+		}
+		if limit > 0 && len(res) == limit {
+			break
+		}
+		res = append(res, tr)
+	}
+
+	return res, nil
+}
+
+func (s *historyStorage) GetCoinTransactionsSummary(profileID uuid.UUID, filter user.CoinTransactionsFilter) (user.CoinTransactionsSummary, error) {
+	return user.CoinTransactionsSummary{TotalSpent: 300, TotalWon: 150}, nil
+}
+
+func TestTransactionsCursor(t *testing.T) {
+	tr := user.CoinTransaction{ID: 42, CreatedAt: time.Date(2024, 6, 1, 12, 30, 0, 123456000, time.UTC)}
+
+	cursor, err := decodeTransactionsCursor(encodeTransactionsCursor(tr))
+	assert.NoError(t, err)
+	assert.Equal(t, &user.CoinTransactionsCursor{CreatedAt: tr.CreatedAt, ID: 42}, cursor)
+
+	for _, invalid := range []string{"!!!", "MTIz", "YTpi"} {
+		_, err = decodeTransactionsCursor(invalid)
+		assert.Equal(t, InvalidTransactionsCursorError, err, invalid)
+	}
+}
+
+func TestUserService_GetCoinTransactionsPages(t *testing.T) {
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	storage := &historyStorage{}
+	// Две транзакции с одним временем проверяют, что курсор различает их по id
+	for i := 5; i >= 1; i-- {
+		createdAt := start.Add(time.Duration(i/2) * time.Hour)
+		storage.transactions = append(storage.transactions, user.CoinTransaction{ID: int64(i), CreatedAt: createdAt})
+	}
+	s := &UserService{storage: storage}
+
+	var ids []int64
+	filter := user.CoinTransactionsFilter{Limit: 2}
+	for pages := 0; ; pages++ {
+		assert.Less(t, pages, 3)
+		page, err := s.GetCoinTransactions(uuid.New(), filter)
+		assert.NoError(t, err)
+		assert.Equal(t, user.CoinTransactionsSummary{TotalSpent: 300, TotalWon: 150}, page.Summary)
+
+		for _, tr := range page.Transactions {
+			ids = append(ids, tr.ID)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		filter.Cursor = page.NextCursor
+	}
+
+	assert.Equal(t, []int64{5, 4, 3, 2, 1}, ids)
+}
+
+func TestUserService_GetCoinTransactionsPeriod(t *testing.T) {
+	s := &UserService{storage: &historyStorage{}}
+	now := time.Now()
+
+	_, err := s.GetCoinTransactions(uuid.New(), user.CoinTransactionsFilter{From: now, To: now.Add(-time.Hour)})
+	assert.Equal(t, InvalidTransactionsPeriodError, err)
+
+	err = s.WriteCoinTransactionsCSV(uuid.New(), user.CoinTransactionsFilter{From: now, To: now}, &bytes.Buffer{})
+	assert.Equal(t, InvalidTransactionsPeriodError, err)
+}
+
+func TestUserService_WriteCoinTransactionsCSV(t *testing.T) {
+	productID := "7"
+	productReference := user.ProductReference
+	s := &UserService{storage: &historyStorage{transactions: []user.CoinTransaction{
+		{
+			ID:               2,
+			Reason:           user.PurchaseReason,
+			Amount:           -500,
+			BalanceAfter:     500,
+			CounterpartyKind: user.HouseAccountKind,
+			ReferenceType:    &productReference,
+			ReferenceID:      &productID,
+			CreatedAt:        time.Date(2024, 6, 2, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			ID:               1,
+			Reason:           user.GrantReason,
+			Amount:           1000,
+			BalanceAfter:     1000,
+			CounterpartyKind: user.HouseAccountKind,
+			CreatedAt:        time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC),
+		},
+	}}}
+
+	var buf bytes.Buffer
+	err := s.WriteCoinTransactionsCSV(uuid.New(), user.CoinTransactionsFilter{}, &buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "id,created_at,reason,amount,balance_after,counterparty,reference_type,reference_id\n"+
+		"2,2024-06-02T10:00:00Z,purchase,-500,500,house,product,7\n"+
+		"1,2024-06-01T10:00:00Z,grant,1000,1000,house,,\n", buf.String())
+}
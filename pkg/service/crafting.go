@@ -0,0 +1,114 @@
+package service
+
+import (
+	"errors"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/config"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/players"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/store"
+	"github.com/google/uuid"
+	"log"
+)
+
+var UpgradeCardsCountError = errors.New("неверное количество карточек для улучшения")
+
+type CraftingStorage interface {
+	DismantleCards(profileID uuid.UUID, cardIDs []int, dust map[store.CardRarity]int) (players.CardConversion, error)
+	UpgradeCards(profileID uuid.UUID, cardIDs []int) (players.CardConversion, error)
+	CraftCard(profileID uuid.UUID, inp players.CraftCardInput, price int) (players.CardConversion, error)
+	GetDustBalance(profileID uuid.UUID) (int, error)
+	GetCardConversions(profileID uuid.UUID, filter players.CardConversionsFilter) ([]players.CardConversion, error)
+}
+
+func NewCraftingService(storage CraftingStorage, cfg config.ServiceConfiguration) *CraftingService {
+	return &CraftingService{
+		storage: storage,
+		cfg:     cfg,
+	}
+}
+
+// CraftingService - разбор лишних карточек на пыль, улучшение редкости и создание карточек за пыль.
+// Цены берутся из конфига
+type CraftingService struct {
+	storage CraftingStorage
+	cfg     config.ServiceConfiguration
+}
+
+func rarityPrices(prices config.RarityPrices) map[store.CardRarity]int {
+	return map[store.CardRarity]int{
+		store.Silver: prices.Silver,
+		store.Gold:   prices.Gold,
+	}
+}
+
+func rarityDust(prices config.RarityPrices) []players.RarityDust {
+	byRarity := rarityPrices(prices)
+	return []players.RarityDust{
+		{Rarity: store.Silver, RarityName: store.PlayerCardsRarityTitles[store.Silver], Dust: byRarity[store.Silver]},
+		{Rarity: store.Gold, RarityName: store.PlayerCardsRarityTitles[store.Gold], Dust: byRarity[store.Gold]},
+	}
+}
+
+func (s *CraftingService) GetCraftingInfo(profileID uuid.UUID) (players.CraftingInfo, error) {
+	dust, err := s.storage.GetDustBalance(profileID)
+	if err != nil {
+		log.Println("Service. GetDustBalance:", err)
+		return players.CraftingInfo{}, err
+	}
+
+	return players.CraftingInfo{
+		Dust:          dust,
+		UpgradeCards:  s.cfg.Crafting.UpgradeCards,
+		DismantleDust: rarityDust(s.cfg.Crafting.DismantleDust),
+		CraftPrice:    rarityDust(s.cfg.Crafting.CraftPrice),
+	}, nil
+}
+
+func (s *CraftingService) DismantleCards(profileID uuid.UUID, inp players.DismantleCardsInput) (players.CardConversion, error) {
+	conversion, err := s.storage.DismantleCards(profileID, inp.CardIDs, rarityPrices(s.cfg.Crafting.DismantleDust))
+	if err != nil {
+		log.Println("Service. DismantleCards:", err)
+		return conversion, err
+	}
+
+	return conversion, nil
+}
+
+func (s *CraftingService) UpgradeCards(profileID uuid.UUID, inp players.UpgradeCardsInput) (players.CardConversion, error) {
+	if len(inp.CardIDs) != s.cfg.Crafting.UpgradeCards {
+		return players.CardConversion{}, UpgradeCardsCountError
+	}
+
+	conversion, err := s.storage.UpgradeCards(profileID, inp.CardIDs)
+	if err != nil {
+		log.Println("Service. UpgradeCards:", err)
+		return conversion, err
+	}
+
+	return conversion, nil
+}
+
+func (s *CraftingService) CraftCard(profileID uuid.UUID, inp players.CraftCardInput) (players.CardConversion, error) {
+	price := rarityPrices(s.cfg.Crafting.CraftPrice)[inp.Rarity]
+
+	conversion, err := s.storage.CraftCard(profileID, inp, price)
+	if err != nil {
+		log.Println("Service. CraftCard:", err)
+		return conversion, err
+	}
+
+	return conversion, nil
+}
+
+func (s *CraftingService) GetCardConversions(profileID uuid.UUID, filter players.CardConversionsFilter) ([]players.CardConversion, error) {
+	if filter.Limit == 0 {
+		filter.Limit = players.DefaultCardConversionsLimit
+	}
+
+	conversions, err := s.storage.GetCardConversions(profileID, filter)
+	if err != nil {
+		log.Println("Service. GetCardConversions:", err)
+		return conversions, err
+	}
+
+	return conversions, nil
+}
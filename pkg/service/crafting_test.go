@@ -0,0 +1,87 @@
+package service
+
+import (
+	"github.com/Frozen-Fantasy/fantasy-backend.git/config"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/players"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/store"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type craftingStorage struct {
+	CraftingStorage
+	dust     map[store.CardRarity]int
+	price    int
+	upgraded []int
+}
+
+func (s *craftingStorage) DismantleCards(profileID uuid.UUID, cardIDs []int, dust map[store.CardRarity]int) (players.CardConversion, error) {
+	s.dust = dust
+	return players.CardConversion{Kind: players.DismantleConversion, ConsumedCards: cardIDs}, nil
+}
+
+func (s *craftingStorage) UpgradeCards(profileID uuid.UUID, cardIDs []int) (players.CardConversion, error) {
+	s.upgraded = cardIDs
+	return players.CardConversion{Kind: players.UpgradeConversion, ConsumedCards: cardIDs}, nil
+}
+
+func (s *craftingStorage) CraftCard(profileID uuid.UUID, inp players.CraftCardInput, price int) (players.CardConversion, error) {
+	s.price = price
+	return players.CardConversion{Kind: players.CraftConversion, DustAmount: -price}, nil
+}
+
+func (s *craftingStorage) GetDustBalance(profileID uuid.UUID) (int, error) {
+	return 75, nil
+}
+
+func testCraftingConfig() config.ServiceConfiguration {
+	return config.ServiceConfiguration{Crafting: config.Crafting{
+		UpgradeCards:  3,
+		DismantleDust: config.RarityPrices{Silver: 10, Gold: 40},
+		CraftPrice:    config.RarityPrices{Silver: 50, Gold: 200},
+	}}
+}
+
+func TestCraftingService_Prices(t *testing.T) {
+	storage := &craftingStorage{}
+	s := NewCraftingService(storage, testCraftingConfig())
+	profileID := uuid.New()
+
+	_, err := s.DismantleCards(profileID, players.DismantleCardsInput{CardIDs: []int{1, 2}})
+	assert.NoError(t, err)
+	assert.Equal(t, map[store.CardRarity]int{store.Silver: 10, store.Gold: 40}, storage.dust)
+
+	conversion, err := s.CraftCard(profileID, players.CraftCardInput{PlayerID: 5, Rarity: store.Gold})
+	assert.NoError(t, err)
+	assert.Equal(t, 200, storage.price)
+	assert.Equal(t, -200, conversion.DustAmount)
+
+	info, err := s.GetCraftingInfo(profileID)
+	assert.NoError(t, err)
+	assert.Equal(t, players.CraftingInfo{
+		Dust:         75,
+		UpgradeCards: 3,
+		DismantleDust: []players.RarityDust{
+			{Rarity: store.Silver, RarityName: "Silver", Dust: 10},
+			{Rarity: store.Gold, RarityName: "Gold", Dust: 40},
+		},
+		CraftPrice: []players.RarityDust{
+			{Rarity: store.Silver, RarityName: "Silver", Dust: 50},
+			{Rarity: store.Gold, RarityName: "Gold", Dust: 200},
+		},
+	}, info)
+}
+
+func TestCraftingService_UpgradeCards(t *testing.T) {
+	storage := &craftingStorage{}
+	s := NewCraftingService(storage, testCraftingConfig())
+
+	_, err := s.UpgradeCards(uuid.New(), players.UpgradeCardsInput{CardIDs: []int{1, 2}})
+	assert.Equal(t, UpgradeCardsCountError, err)
+	assert.Nil(t, storage.upgraded)
+
+	_, err = s.UpgradeCards(uuid.New(), players.UpgradeCardsInput{CardIDs: []int{1, 2, 3}})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, storage.upgraded)
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/storage"
+	"github.com/google/uuid"
+	"log"
+	"time"
+)
+
+var ProfileDeletedError = errors.New("аккаунт удален")
+
+const (
+	// DeletedUserNickname показывается в прошлых результатах турниров вместо никнейма удаленного пользователя
+	DeletedUserNickname = "Удаленный пользователь"
+	purgeBatchSize      = 100
+)
+
+// DeleteProfile помечает аккаунт удаленным и завершает все сессии. В течение deletion_grace_period дней
+// аккаунт восстанавливается обычным входом, после этого его окончательно удаляет PurgeDeletedProfiles
+func (s *UserService) DeleteProfile(userID uuid.UUID) error {
+
+	err := s.storage.DeleteProfile(userID)
+	if err != nil {
+		log.Println("Service. DeleteProfile:", err)
+		return err
+	}
+
+	err = s.storage.CreateSecurityEvent(user.SecurityEvent{
+		ProfileID: userID,
+		EventType: user.ProfileDeletedEvent,
+	})
+	if err != nil {
+		log.Println("Service. CreateSecurityEvent:", err)
+	}
+
+	return nil
+}
+
+// CheckProfileActive не пускает запросы от удаленного аккаунта: выданные до удаления access токены
+// остаются валидными до истечения срока
+func (s *UserService) CheckProfileActive(userID uuid.UUID) error {
+	deletedAt, err := s.storage.GetProfileDeletedAt(userID)
+	if err != nil {
+		log.Println("Service. GetProfileDeletedAt:", err)
+		return err
+	}
+
+	if deletedAt != nil {
+		return ProfileDeletedError
+	}
+
+	return nil
+}
+
+func (s *UserService) deletionGracePeriod() time.Duration {
+	return time.Duration(s.cfg.Profile.DeletionGracePeriod) * 24 * time.Hour
+}
+
+// checkDeletionGracePeriod не дает войти в аккаунт, срок восстановления которого уже истек
+func (s *UserService) checkDeletionGracePeriod(userID uuid.UUID) error {
+	deletedAt, err := s.storage.GetProfileDeletedAt(userID)
+	if err != nil {
+		log.Println("Service. GetProfileDeletedAt:", err)
+		return err
+	}
+
+	if deletedAt != nil && time.Since(*deletedAt) > s.deletionGracePeriod() {
+		return storage.UserDoesNotExistError
+	}
+
+	return nil
+}
+
+// restoreProfile снимает пометку об удалении. Вызывается при создании сессии, то есть после всех проверок входа
+func (s *UserService) restoreProfile(userID uuid.UUID) error {
+	deletedAt, err := s.storage.GetProfileDeletedAt(userID)
+	if err != nil {
+		log.Println("Service. GetProfileDeletedAt:", err)
+		return err
+	}
+	if deletedAt == nil {
+		return nil
+	}
+
+	err = s.storage.RestoreProfile(userID)
+	if err != nil {
+		log.Println("Service. RestoreProfile:", err)
+		return err
+	}
+
+	err = s.storage.CreateSecurityEvent(user.SecurityEvent{
+		ProfileID: userID,
+		EventType: user.ProfileRestoredEvent,
+	})
+	if err != nil {
+		log.Println("Service. CreateSecurityEvent:", err)
+	}
+
+	return nil
+}
+
+// PurgeDeletedProfiles окончательно удаляет аккаунты, срок восстановления которых истек
+func (s *UserService) PurgeDeletedProfiles(ctx context.Context) (int, error) {
+	profiles, err := s.storage.GetProfilesToPurge(time.Now().Add(-s.deletionGracePeriod()), purgeBatchSize)
+	if err != nil {
+		log.Println("Service. GetProfilesToPurge:", err)
+		return 0, err
+	}
+
+	purged := 0
+	for _, profile := range profiles {
+		if ctx.Err() != nil {
+			return purged, ctx.Err()
+		}
+
+		err = s.storage.PurgeProfile(profile.ProfileID, DeletedUserNickname)
+		if err != nil {
+			log.Println("Service. PurgeProfile:", err)
+			return purged, err
+		}
+		s.deleteAvatar(profile.PhotoLink)
+		purged++
+	}
+
+	return purged, nil
+}
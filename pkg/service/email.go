@@ -2,15 +2,20 @@ package service
 
 import (
 	"errors"
-	"fmt"
-	"gopkg.in/gomail.v2"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/mailer"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/storage"
+	"github.com/google/uuid"
 	"log"
+	"net/url"
 	"strings"
+	"time"
 )
 
 var (
 	UserAlreadyExistsError       = errors.New("пользователь уже существует")
 	InvalidVerificationCodeError = errors.New("неверный код верификации")
+	SameEmailError               = errors.New("новый email совпадает с текущим")
 )
 
 func (s *UserService) SendVerificationCode(email string) error {
@@ -25,22 +30,27 @@ func (s *UserService) SendVerificationCode(email string) error {
 		return UserAlreadyExistsError
 	}
 
+	err = s.limiter.Cooldown("verification_code_cooldown_"+email, s.cfg.RateLimits.VerificationCodeCooldown)
+	if err != nil {
+		return err
+	}
+	err = s.limiter.Allow("send_code_"+email, s.cfg.RateLimits.SendCode)
+	if err != nil {
+		return err
+	}
+
 	code, err := s.rStorage.CreateVerificationCode(email)
 	if err != nil {
 		log.Println("Service. CreateVerificationCode:", err)
 		return err
 	}
 
-	m := gomail.NewMessage()
-	m.SetHeader("From", "frozen-fantasy@mail.ru")
-	m.SetHeader("To", email)
-	m.SetHeader("Subject", "Email verification")
-	m.SetBody("text/html", fmt.Sprintf("<p>Hi,</p>\n<p>We just need to verify your email address before you can access Frozen-Fantasy.</p>\n<p>Your verification code: <strong>%d</strong></p>\n<p>You have <strong>10 minutes</strong> to activate it</p>\n<p>Thanks! &ndash; Frozen-Fantasy team</p>", code))
-
-	d := gomail.NewDialer("smtp.mail.ru", 465, s.cfg.Email.Login, s.cfg.Email.Password)
-
-	if err = d.DialAndSend(m); err != nil {
-		log.Println("Service. DialAndSend:", err)
+	err = s.mail.Enqueue(email, mailer.VerificationCodeTemplate, map[string]interface{}{
+		"Code":       code,
+		"TTLMinutes": 10,
+	})
+	if err != nil {
+		log.Println("Service. Enqueue:", err)
 		return err
 	}
 
@@ -70,3 +80,165 @@ func (s *UserService) CheckEmailExists(email string) (bool, error) {
 
 	return exists, nil
 }
+
+// RequestEmailChange отправляет код подтверждения на новый адрес. Сам email меняется только после ConfirmEmailChange
+func (s *UserService) RequestEmailChange(userID uuid.UUID, inp user.ChangeEmailInput) error {
+	newEmail := strings.ToLower(inp.NewEmail)
+
+	userData, err := s.storage.GetUserDataByID(userID)
+	if err != nil {
+		log.Println("Service. GetUserDataByID:", err)
+		return err
+	}
+
+	err = ComparePasswords(userData.PasswordEncoded, inp.Password, userData.PasswordSalt)
+	if err != nil {
+		log.Println("Service. ComparePasswords:", err)
+		return err
+	}
+
+	oldEmail, err := s.storage.GetEmailByProfileID(userID)
+	if err != nil {
+		log.Println("Service. GetEmailByProfileID:", err)
+		return err
+	}
+	if strings.ToLower(oldEmail) == newEmail {
+		return SameEmailError
+	}
+
+	exists, err := s.CheckEmailExists(newEmail)
+	if err != nil {
+		return err
+	}
+	if exists == true {
+		return storage.EmailTakenError
+	}
+
+	err = s.limiter.Cooldown("verification_code_cooldown_"+newEmail, s.cfg.RateLimits.VerificationCodeCooldown)
+	if err != nil {
+		return err
+	}
+	err = s.limiter.Allow("send_code_"+newEmail, s.cfg.RateLimits.SendCode)
+	if err != nil {
+		return err
+	}
+
+	code, err := s.rStorage.CreateVerificationCode(newEmail)
+	if err != nil {
+		log.Println("Service. CreateVerificationCode:", err)
+		return err
+	}
+
+	err = s.rStorage.CreateEmailChange(userID, newEmail)
+	if err != nil {
+		log.Println("Service. CreateEmailChange:", err)
+		return err
+	}
+
+	err = s.mail.Enqueue(newEmail, mailer.VerificationCodeTemplate, map[string]interface{}{
+		"Code":       code,
+		"TTLMinutes": 10,
+	})
+	if err != nil {
+		log.Println("Service. Enqueue:", err)
+		return err
+	}
+
+	return nil
+}
+
+// ConfirmEmailChange меняет email и завершает все сессии. На старый адрес уходит письмо со ссылкой отмены
+func (s *UserService) ConfirmEmailChange(userID uuid.UUID, code int) error {
+	newEmail, err := s.rStorage.GetEmailChange(userID)
+	if err != nil {
+		log.Println("Service. GetEmailChange:", err)
+		return err
+	}
+
+	err = s.CheckEmailVerification(newEmail, code)
+	if err != nil {
+		log.Println("Service. CheckEmailVerification:", err)
+		return err
+	}
+
+	oldEmail, err := s.storage.GetEmailByProfileID(userID)
+	if err != nil {
+		log.Println("Service. GetEmailByProfileID:", err)
+		return err
+	}
+
+	err = s.storage.ChangeEmail(userID, oldEmail, newEmail)
+	if err != nil {
+		log.Println("Service. ChangeEmail:", err)
+		return err
+	}
+
+	err = s.rStorage.DeleteEmailChange(userID)
+	if err != nil {
+		log.Println("Service. DeleteEmailChange:", err)
+	}
+
+	err = s.storage.CreateSecurityEvent(user.SecurityEvent{
+		ProfileID: userID,
+		EventType: user.EmailChangedEvent,
+		Details:   oldEmail + " -> " + newEmail,
+	})
+	if err != nil {
+		log.Println("Service. CreateSecurityEvent:", err)
+	}
+
+	undoTTL := time.Duration(s.cfg.Profile.EmailChangeUndoTTL) * time.Hour
+	token, err := s.rStorage.CreateEmailChangeUndoToken(user.EmailChangeUndo{
+		ProfileID: userID,
+		OldEmail:  oldEmail,
+		NewEmail:  newEmail,
+	}, undoTTL)
+	if err != nil {
+		log.Println("Service. CreateEmailChangeUndoToken:", err)
+		return err
+	}
+
+	err = s.mail.Enqueue(oldEmail, mailer.EmailChangedTemplate, map[string]interface{}{
+		"NewEmail": newEmail,
+		"Link":     strings.TrimRight(s.cfg.Email.BaseURL, "/") + "/undo-email-change?token=" + url.QueryEscape(token),
+		"TTLHours": s.cfg.Profile.EmailChangeUndoTTL,
+	})
+	if err != nil {
+		log.Println("Service. Enqueue:", err)
+		return err
+	}
+
+	return nil
+}
+
+// UndoEmailChange возвращает старый email по ссылке из письма. Сессии завершаются еще раз,
+// чтобы выкинуть того, кто сменил адрес
+func (s *UserService) UndoEmailChange(token string) error {
+	data, err := s.rStorage.GetEmailChangeUndo(token)
+	if err != nil {
+		log.Println("Service. GetEmailChangeUndo:", err)
+		return err
+	}
+
+	err = s.storage.ChangeEmail(data.ProfileID, data.NewEmail, data.OldEmail)
+	if err != nil {
+		log.Println("Service. ChangeEmail:", err)
+		return err
+	}
+
+	err = s.rStorage.DeleteEmailChangeUndoToken(token)
+	if err != nil {
+		log.Println("Service. DeleteEmailChangeUndoToken:", err)
+	}
+
+	err = s.storage.CreateSecurityEvent(user.SecurityEvent{
+		ProfileID: data.ProfileID,
+		EventType: user.EmailChangeUndoneEvent,
+		Details:   data.NewEmail + " -> " + data.OldEmail,
+	})
+	if err != nil {
+		log.Println("Service. CreateSecurityEvent:", err)
+	}
+
+	return nil
+}
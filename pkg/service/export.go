@@ -0,0 +1,339 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/config"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/blobstore"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/mailer"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/players"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/storage"
+	"github.com/google/uuid"
+	"io"
+	"log"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+var (
+	DataExportNotReadyError  = errors.New("выгрузка данных еще не готова")
+	InvalidDownloadLinkError = errors.New("ссылка на скачивание невалидна или истекла")
+)
+
+const (
+	exportKeyPrefix   = "exports/"
+	expiredExportsMax = 100
+)
+
+type ExportStorage interface {
+	CreateDataExport(profileID uuid.UUID) (user.DataExport, error)
+	GetDataExport(id uuid.UUID) (user.DataExport, error)
+	GetActiveDataExport(profileID uuid.UUID) (user.DataExport, error)
+	ClaimDataExport() (user.DataExport, error)
+	MarkDataExportReady(id uuid.UUID, blobKey string, size int64, expiresAt time.Time) error
+	MarkDataExportFailed(id uuid.UUID, exportErr string) error
+	GetExpiredDataExports(limit int) ([]user.DataExport, error)
+	MarkDataExportExpired(id uuid.UUID) error
+	GetUserInfo(userID uuid.UUID) (user.UserInfoModel, error)
+	GetProfileRoles(profileID uuid.UUID) ([]string, error)
+	GetNotificationPreferences(profileID uuid.UUID) (user.NotificationPreferences, error)
+	GetCoinTransactionsByProfileID(profileID uuid.UUID) ([]user.CoinTransaction, error)
+	GetPlayerCards(filter players.PlayerCardsFilter) ([]players.PlayerCardResponse, error)
+	GetUserTournamentIDs(userID uuid.UUID) ([]int, error)
+	GetAllUserRosterInfo(userID uuid.UUID, tournamentID int) (players.UserRosterInfo, error)
+	GetActiveSessionsByProfileID(profileID uuid.UUID) ([]user.SessionInfo, error)
+}
+
+func NewExportService(storage ExportStorage, blobs blobstore.BlobStore, mail *MailService, cfg config.ServiceConfiguration) *ExportService {
+	return &ExportService{
+		storage: storage,
+		blobs:   blobs,
+		mail:    mail,
+		cfg:     cfg,
+	}
+}
+
+// ExportService собирает архив со всеми данными пользователя. Сборка идет в воркере build_exports,
+// архив хранится в BlobStore под закрытым префиксом и отдается только по подписанной ссылке
+type ExportService struct {
+	storage ExportStorage
+	blobs   blobstore.BlobStore
+	mail    *MailService
+	cfg     config.ServiceConfiguration
+}
+
+// RequestDataExport ставит выгрузку в очередь. Если предыдущая еще собирается, возвращается она
+func (s *ExportService) RequestDataExport(userID uuid.UUID) (user.DataExport, error) {
+	export, err := s.storage.GetActiveDataExport(userID)
+	if err == nil {
+		return export, nil
+	}
+	if err != storage.DataExportNotFoundError {
+		log.Println("Service. GetActiveDataExport:", err)
+		return export, err
+	}
+
+	export, err = s.storage.CreateDataExport(userID)
+	if err != nil {
+		log.Println("Service. CreateDataExport:", err)
+		return export, err
+	}
+
+	return export, nil
+}
+
+// GetDataExport возвращает статус выгрузки, а для готовой - свежую ссылку на скачивание
+func (s *ExportService) GetDataExport(userID uuid.UUID, exportID uuid.UUID) (user.DataExport, error) {
+	export, err := s.storage.GetDataExport(exportID)
+	if err != nil {
+		log.Println("Service. GetDataExport:", err)
+		return export, err
+	}
+	if export.ProfileID != userID {
+		return user.DataExport{}, storage.DataExportNotFoundError
+	}
+
+	if export.Status == user.ReadyExport && export.ExpiresAt != nil {
+		expires := time.Now().Add(time.Duration(s.cfg.DataExport.LinkTTL) * time.Minute)
+		if expires.After(*export.ExpiresAt) {
+			expires = *export.ExpiresAt
+		}
+		export.DownloadURL = s.downloadURL(export.ID, expires)
+	}
+
+	return export, nil
+}
+
+// OpenDataExport проверяет подпись ссылки и открывает архив
+func (s *ExportService) OpenDataExport(inp user.DataExportDownloadInput) (io.ReadCloser, blobstore.BlobInfo, error) {
+	var info blobstore.BlobInfo
+
+	exportID, err := uuid.Parse(inp.ID)
+	if err != nil {
+		return nil, info, InvalidDownloadLinkError
+	}
+
+	expires := time.Unix(inp.Expires, 0)
+	signature, err := hex.DecodeString(inp.Signature)
+	if err != nil || !hmac.Equal(signature, s.sign(exportID, expires)) || time.Now().After(expires) {
+		return nil, info, InvalidDownloadLinkError
+	}
+
+	export, err := s.storage.GetDataExport(exportID)
+	if err != nil {
+		log.Println("Service. GetDataExport:", err)
+		return nil, info, err
+	}
+	if export.Status != user.ReadyExport || export.BlobKey == nil {
+		return nil, info, InvalidDownloadLinkError
+	}
+
+	file, info, err := s.blobs.Get(context.Background(), *export.BlobKey)
+	if err != nil {
+		log.Println("Service. GetBlob:", err)
+		if err == blobstore.BlobNotFoundError {
+			return nil, info, InvalidDownloadLinkError
+		}
+		return nil, info, err
+	}
+
+	return file, info, nil
+}
+
+// BuildPending собирает очередную выгрузку из очереди. Возвращает false, если очередь пуста
+func (s *ExportService) BuildPending(ctx context.Context) (bool, error) {
+	export, err := s.storage.ClaimDataExport()
+	if err != nil {
+		if err == storage.DataExportNotFoundError {
+			return false, nil
+		}
+		log.Println("Service. ClaimDataExport:", err)
+		return false, err
+	}
+
+	err = s.build(ctx, export)
+	if err != nil {
+		log.Println("Service. BuildDataExport:", err)
+		markErr := s.storage.MarkDataExportFailed(export.ID, err.Error())
+		if markErr != nil {
+			log.Println("Service. MarkDataExportFailed:", markErr)
+			return true, markErr
+		}
+	}
+
+	return true, nil
+}
+
+func (s *ExportService) build(ctx context.Context, export user.DataExport) error {
+	data, err := s.collect(export.ProfileID)
+	if err != nil {
+		return err
+	}
+
+	archive, err := buildExportArchive(data)
+	if err != nil {
+		return err
+	}
+	size := int64(len(archive))
+
+	key := exportKeyPrefix + export.ProfileID.String() + "/" + export.ID.String() + ".zip"
+	err = s.blobs.Put(ctx, key, "application/zip", bytes.NewReader(archive))
+	if err != nil {
+		return err
+	}
+
+	expiresAt := time.Now().Add(time.Duration(s.cfg.DataExport.Retention) * time.Hour)
+	err = s.storage.MarkDataExportReady(export.ID, key, size, expiresAt)
+	if err != nil {
+		return err
+	}
+
+	err = s.mail.Enqueue(data.Profile.Email, mailer.DataExportReadyTemplate, map[string]interface{}{
+		"Link":     s.downloadURL(export.ID, expiresAt),
+		"TTLHours": s.cfg.DataExport.Retention,
+	})
+	if err != nil {
+		log.Println("Service. Enqueue:", err)
+	}
+
+	return nil
+}
+
+// collect читает данные пользователя теми же методами, что и обычные эндпоинты
+func (s *ExportService) collect(userID uuid.UUID) (user.PersonalData, error) {
+	var data user.PersonalData
+	var err error
+
+	data.Profile, err = s.storage.GetUserInfo(userID)
+	if err != nil {
+		return data, fmt.Errorf("GetUserInfo: %w", err)
+	}
+
+	data.Roles, err = s.storage.GetProfileRoles(userID)
+	if err != nil {
+		return data, fmt.Errorf("GetProfileRoles: %w", err)
+	}
+
+	data.NotificationPreferences, err = s.storage.GetNotificationPreferences(userID)
+	if err != nil {
+		return data, fmt.Errorf("GetNotificationPreferences: %w", err)
+	}
+
+	data.CoinTransactions, err = s.storage.GetCoinTransactionsByProfileID(userID)
+	if err != nil {
+		return data, fmt.Errorf("GetCoinTransactionsByProfileID: %w", err)
+	}
+
+	data.PlayerCards, err = s.storage.GetPlayerCards(players.PlayerCardsFilter{ProfileID: userID})
+	if err != nil {
+		return data, fmt.Errorf("GetPlayerCards: %w", err)
+	}
+
+	tournamentIDs, err := s.storage.GetUserTournamentIDs(userID)
+	if err != nil {
+		return data, fmt.Errorf("GetUserTournamentIDs: %w", err)
+	}
+	data.Rosters = []players.UserRosterInfo{}
+	for _, tournamentID := range tournamentIDs {
+		roster, err := s.storage.GetAllUserRosterInfo(userID, tournamentID)
+		if err != nil {
+			return data, fmt.Errorf("GetAllUserRosterInfo: %w", err)
+		}
+		data.Rosters = append(data.Rosters, roster)
+	}
+
+	data.Sessions, err = s.storage.GetActiveSessionsByProfileID(userID)
+	if err != nil {
+		return data, fmt.Errorf("GetActiveSessionsByProfileID: %w", err)
+	}
+
+	return data, nil
+}
+
+// PurgeExpired удаляет архивы, срок хранения которых истек
+func (s *ExportService) PurgeExpired(ctx context.Context) error {
+	exports, err := s.storage.GetExpiredDataExports(expiredExportsMax)
+	if err != nil {
+		log.Println("Service. GetExpiredDataExports:", err)
+		return err
+	}
+
+	for _, export := range exports {
+		if export.BlobKey != nil {
+			err = s.blobs.Delete(ctx, *export.BlobKey)
+			if err != nil && err != blobstore.BlobNotFoundError {
+				log.Println("Service. DeleteBlob:", err)
+				return err
+			}
+		}
+
+		err = s.storage.MarkDataExportExpired(export.ID)
+		if err != nil {
+			log.Println("Service. MarkDataExportExpired:", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *ExportService) downloadURL(exportID uuid.UUID, expires time.Time) string {
+	query := url.Values{}
+	query.Set("id", exportID.String())
+	query.Set("expires", strconv.FormatInt(expires.Unix(), 10))
+	query.Set("signature", hex.EncodeToString(s.sign(exportID, expires)))
+
+	return s.cfg.DataExport.DownloadURL + "?" + query.Encode()
+}
+
+func (s *ExportService) sign(exportID uuid.UUID, expires time.Time) []byte {
+	mac := hmac.New(sha256.New, []byte(s.cfg.DataExport.Secret))
+	mac.Write([]byte("data_export:" + exportID.String() + ":" + strconv.FormatInt(expires.Unix(), 10)))
+	return mac.Sum(nil)
+}
+
+// buildExportArchive раскладывает данные по JSON файлам внутри zip архива
+func buildExportArchive(data user.PersonalData) ([]byte, error) {
+	files := []struct {
+		name string
+		data interface{}
+	}{
+		{"profile.json", data.Profile},
+		{"roles.json", data.Roles},
+		{"notification_preferences.json", data.NotificationPreferences},
+		{"coin_transactions.json", data.CoinTransactions},
+		{"player_cards.json", data.PlayerCards},
+		{"rosters.json", data.Rosters},
+		{"sessions.json", data.Sessions},
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, file := range files {
+		w, err := zw.Create(file.name)
+		if err != nil {
+			return nil, err
+		}
+
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err = enc.Encode(file.data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
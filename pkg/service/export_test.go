@@ -0,0 +1,80 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/config"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestBuildExportArchive(t *testing.T) {
+	archive, err := buildExportArchive(user.PersonalData{
+		Profile: user.UserInfoModel{Nickname: "skater", Email: "skater@mail.ru"},
+		Roles:   []string{user.AdminRole},
+	})
+	assert.NoError(t, err)
+
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	assert.NoError(t, err)
+
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	assert.Equal(t, []string{"profile.json", "roles.json", "notification_preferences.json", "coin_transactions.json",
+		"player_cards.json", "rosters.json", "sessions.json"}, names)
+}
+
+func TestExportService_OpenDataExportLink(t *testing.T) {
+	var cfg config.ServiceConfiguration
+	cfg.DataExport.Secret = "secret"
+	cfg.DataExport.DownloadURL = "http://localhost/download"
+	s := NewExportService(nil, nil, nil, cfg)
+
+	exportID := uuid.MustParse("2d4f1a5e-8c1a-4b6f-9f7e-3c2d1b0a9e8f")
+	link, err := url.Parse(s.downloadURL(exportID, time.Now().Add(time.Hour)))
+	assert.NoError(t, err)
+	query := link.Query()
+
+	testTable := []struct {
+		name string
+		inp  user.DataExportDownloadInput
+	}{
+		{
+			name: "Expired",
+			inp: user.DataExportDownloadInput{
+				ID:        exportID.String(),
+				Expires:   time.Now().Add(-time.Minute).Unix(),
+				Signature: query.Get("signature"),
+			},
+		},
+		{
+			name: "Foreign export",
+			inp: user.DataExportDownloadInput{
+				ID:        uuid.New().String(),
+				Expires:   time.Now().Add(time.Hour).Unix(),
+				Signature: query.Get("signature"),
+			},
+		},
+		{
+			name: "Malformed signature",
+			inp: user.DataExportDownloadInput{
+				ID:        exportID.String(),
+				Expires:   time.Now().Add(time.Hour).Unix(),
+				Signature: "xyz",
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, _, err := s.OpenDataExport(testCase.inp)
+			assert.Equal(t, InvalidDownloadLinkError, err)
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"golang.org/x/crypto/argon2"
+	"strings"
+)
+
+var (
+	UnknownHashFormatError = errors.New("неизвестный формат хеша пароля")
+)
+
+const argon2idPrefix = "$argon2id$"
+
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	Compare(encodedHash string, password string) error
+	NeedsRehash(encodedHash string) bool
+}
+
+type Argon2Params struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// Параметры по рекомендации OWASP для argon2id
+var DefaultArgon2Params = Argon2Params{
+	Memory:      19 * 1024,
+	Iterations:  2,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+type Argon2idHasher struct {
+	params Argon2Params
+}
+
+func NewArgon2idHasher(params Argon2Params) *Argon2idHasher {
+	return &Argon2idHasher{params: params}
+}
+
+// Hash возвращает хеш в формате PHC: $argon2id$v=19$m=...,t=...,p=...$salt$hash
+func (h *Argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.params.Iterations, h.params.Memory, h.params.Parallelism, h.params.KeyLength)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s", argon2idPrefix, argon2.Version,
+		h.params.Memory, h.params.Iterations, h.params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (h *Argon2idHasher) Compare(encodedHash string, password string) error {
+	params, salt, key, err := decodeArgon2idHash(encodedHash)
+	if err != nil {
+		return err
+	}
+
+	inputKey := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+	if subtle.ConstantTimeCompare(key, inputKey) != 1 {
+		return IncorrectPasswordError
+	}
+
+	return nil
+}
+
+func (h *Argon2idHasher) NeedsRehash(encodedHash string) bool {
+	params, _, _, err := decodeArgon2idHash(encodedHash)
+	if err != nil {
+		return true
+	}
+
+	return params.Memory != h.params.Memory ||
+		params.Iterations != h.params.Iterations ||
+		params.Parallelism != h.params.Parallelism ||
+		params.KeyLength != h.params.KeyLength
+}
+
+func decodeArgon2idHash(encodedHash string) (Argon2Params, []byte, []byte, error) {
+	var params Argon2Params
+
+	parts := strings.Split(encodedHash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return params, nil, nil, UnknownHashFormatError
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, UnknownHashFormatError
+	}
+
+	_, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism)
+	if err != nil {
+		return params, nil, nil, UnknownHashFormatError
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, UnknownHashFormatError
+	}
+	params.SaltLength = uint32(len(salt))
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return params, nil, nil, UnknownHashFormatError
+	}
+	params.KeyLength = uint32(len(key))
+
+	return params, salt, key, nil
+}
+
+// SHA1Hasher оставлен только для проверки старых паролей, новые хеши им не создаются
+type SHA1Hasher struct {
+	salt string
+}
+
+func NewSHA1Hasher(salt string) *SHA1Hasher {
+	return &SHA1Hasher{salt: salt}
+}
+
+func (h *SHA1Hasher) Hash(password string) (string, error) {
+	hash := sha1.New()
+
+	if _, err := hash.Write([]byte(password)); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%x", hash.Sum([]byte(h.salt))), nil
+}
+
+func (h *SHA1Hasher) Compare(encodedHash string, password string) error {
+	inputPasswordHash, err := h.Hash(password)
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(inputPasswordHash), []byte(encodedHash)) != 1 {
+		return IncorrectPasswordError
+	}
+
+	return nil
+}
+
+func (h *SHA1Hasher) NeedsRehash(encodedHash string) bool {
+	return true
+}
+
+func isLegacyPasswordHash(encodedHash string) bool {
+	return !strings.HasPrefix(encodedHash, "$")
+}
+
+// ComparePasswords проверяет пароль по сохраненному хешу. Алгоритм определяется по самому хешу:
+// хеши без префикса $ считаются старыми SHA-1 хешами с солью из user_data.password_salt
+func ComparePasswords(currentPassword string, passwordInput string, passwordSalt string) error {
+	switch {
+	case isLegacyPasswordHash(currentPassword):
+		return NewSHA1Hasher(passwordSalt).Compare(currentPassword, passwordInput)
+	case strings.HasPrefix(currentPassword, argon2idPrefix):
+		return NewArgon2idHasher(DefaultArgon2Params).Compare(currentPassword, passwordInput)
+	default:
+		return UnknownHashFormatError
+	}
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestArgon2idHasher(t *testing.T) {
+	hasher := NewArgon2idHasher(DefaultArgon2Params)
+
+	hash, err := hasher.Hash("TestPassword1")
+	assert.NoError(t, err)
+	assert.Contains(t, hash, "$argon2id$v=19$m=19456,t=2,p=1$")
+
+	assert.NoError(t, hasher.Compare(hash, "TestPassword1"))
+	assert.Equal(t, IncorrectPasswordError, hasher.Compare(hash, "TestPassword2"))
+	assert.False(t, hasher.NeedsRehash(hash))
+
+	otherHash, err := hasher.Hash("TestPassword1")
+	assert.NoError(t, err)
+	assert.NotEqual(t, hash, otherHash)
+
+	weakHasher := NewArgon2idHasher(Argon2Params{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32})
+	weakHash, err := weakHasher.Hash("TestPassword1")
+	assert.NoError(t, err)
+	assert.True(t, hasher.NeedsRehash(weakHash))
+	assert.NoError(t, ComparePasswords(weakHash, "TestPassword1", ""))
+}
+
+func TestComparePasswords(t *testing.T) {
+	legacyHash, err := NewSHA1Hasher("salt").Hash("TestPassword1")
+	assert.NoError(t, err)
+	argonHash, err := NewArgon2idHasher(DefaultArgon2Params).Hash("TestPassword1")
+	assert.NoError(t, err)
+
+	testTable := []struct {
+		name          string
+		hash          string
+		salt          string
+		password      string
+		expectedError error
+		needsRehash   bool
+	}{
+		{
+			name:        "Legacy SHA-1",
+			hash:        legacyHash,
+			salt:        "salt",
+			password:    "TestPassword1",
+			needsRehash: true,
+		},
+		{
+			name:          "Legacy SHA-1 wrong password",
+			hash:          legacyHash,
+			salt:          "salt",
+			password:      "TestPassword2",
+			expectedError: IncorrectPasswordError,
+			needsRehash:   true,
+		},
+		{
+			name:     "Argon2id",
+			hash:     argonHash,
+			password: "TestPassword1",
+		},
+		{
+			name:          "Argon2id wrong password",
+			hash:          argonHash,
+			password:      "TestPassword2",
+			expectedError: IncorrectPasswordError,
+		},
+		{
+			name:          "Unknown format",
+			hash:          "$2a$10$abcdef",
+			password:      "TestPassword1",
+			expectedError: UnknownHashFormatError,
+			needsRehash:   true,
+		},
+	}
+
+	hasher := NewArgon2idHasher(DefaultArgon2Params)
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := ComparePasswords(testCase.hash, testCase.password, testCase.salt)
+			assert.Equal(t, testCase.expectedError, err)
+			assert.Equal(t, testCase.needsRehash, hasher.NeedsRehash(testCase.hash))
+		})
+	}
+}
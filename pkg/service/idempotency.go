@@ -0,0 +1,121 @@
+package service
+
+import (
+	"errors"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/config"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/google/uuid"
+	"log"
+	"time"
+)
+
+const maxIdempotencyKeyLength = 255
+
+var (
+	InvalidIdempotencyKeyError    = errors.New("Idempotency-Key должен быть непустой строкой не длиннее 255 символов")
+	IdempotencyKeyInProgressError = errors.New("запрос с этим Idempotency-Key еще выполняется")
+	IdempotencyKeyMismatchError   = errors.New("Idempotency-Key уже использован для другого запроса")
+)
+
+type IdempotencyStorage interface {
+	ReserveIdempotencyKey(key string, fingerprint string, ttl time.Duration) (bool, user.IdempotentRequest, error)
+	ExtendIdempotencyKey(key string, fingerprint string, ttl time.Duration) error
+	SaveIdempotentResponse(key string, resp user.IdempotentRequest, ttl time.Duration) error
+	ReleaseIdempotencyKey(key string) error
+}
+
+func NewIdempotencyService(storage IdempotencyStorage, cfg config.ServiceConfiguration) *IdempotencyService {
+	return &IdempotencyService{
+		storage: storage,
+		ttl:     time.Duration(cfg.Idempotency.TTL) * time.Hour,
+		lockTTL: time.Duration(cfg.Idempotency.LockTTL) * time.Second,
+	}
+}
+
+type IdempotencyService struct {
+	storage IdempotencyStorage
+	ttl     time.Duration
+	lockTTL time.Duration
+}
+
+func idempotencyStorageKey(userID uuid.UUID, key string) string {
+	return userID.String() + "_" + key
+}
+
+// Begin занимает ключ пользователя под запрос с отпечатком fingerprint. Если по ключу уже есть
+// завершенный запрос с тем же отпечатком, возвращает его ответ для повтора
+func (s *IdempotencyService) Begin(userID uuid.UUID, key string, fingerprint string) (*user.IdempotentRequest, error) {
+	if key == "" || len(key) > maxIdempotencyKeyLength {
+		return nil, InvalidIdempotencyKeyError
+	}
+
+	ok, existing, err := s.storage.ReserveIdempotencyKey(idempotencyStorageKey(userID, key), fingerprint, s.lockTTL)
+	if err != nil {
+		log.Println("Service. ReserveIdempotencyKey:", err)
+		return nil, err
+	}
+	if ok {
+		return nil, nil
+	}
+
+	if existing.Fingerprint != fingerprint {
+		return nil, IdempotencyKeyMismatchError
+	}
+	if existing.Status != user.IdempotencyCompleted {
+		return nil, IdempotencyKeyInProgressError
+	}
+
+	return &existing, nil
+}
+
+// Hold продлевает занятый ключ, пока выполняется запрос: обработчик дольше lock_ttl иначе освободил бы
+// ключ для дубля. Продление прекращается вызовом stop
+func (s *IdempotencyService) Hold(userID uuid.UUID, key string, fingerprint string) (stop func()) {
+	if s.lockTTL <= 0 {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(s.lockTTL / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				err := s.storage.ExtendIdempotencyKey(idempotencyStorageKey(userID, key), fingerprint, s.lockTTL)
+				if err != nil {
+					log.Println("Service. ExtendIdempotencyKey:", err)
+				}
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
+
+// Complete сохраняет ответ, который будет отдаваться повторным запросам с тем же ключом
+func (s *IdempotencyService) Complete(userID uuid.UUID, key string, resp user.IdempotentRequest) error {
+	resp.Status = user.IdempotencyCompleted
+
+	err := s.storage.SaveIdempotentResponse(idempotencyStorageKey(userID, key), resp, s.ttl)
+	if err != nil {
+		log.Println("Service. SaveIdempotentResponse:", err)
+		return err
+	}
+
+	return nil
+}
+
+// Release освобождает ключ, чтобы запрос можно было повторить, например после ошибки сервера
+func (s *IdempotencyService) Release(userID uuid.UUID, key string) error {
+	err := s.storage.ReleaseIdempotencyKey(idempotencyStorageKey(userID, key))
+	if err != nil {
+		log.Println("Service. ReleaseIdempotencyKey:", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"github.com/Frozen-Fantasy/fantasy-backend.git/config"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type memoryIdempotencyStorage struct {
+	mu       sync.Mutex
+	requests map[string]user.IdempotentRequest
+	extended int
+}
+
+func (s *memoryIdempotencyStorage) ReserveIdempotencyKey(key string, fingerprint string, ttl time.Duration) (bool, user.IdempotentRequest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.requests[key]; ok {
+		return false, existing, nil
+	}
+	s.requests[key] = user.IdempotentRequest{Status: user.IdempotencyInProgress, Fingerprint: fingerprint}
+	return true, user.IdempotentRequest{}, nil
+}
+
+func (s *memoryIdempotencyStorage) ExtendIdempotencyKey(key string, fingerprint string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.extended++
+	return nil
+}
+
+func (s *memoryIdempotencyStorage) SaveIdempotentResponse(key string, resp user.IdempotentRequest, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests[key] = resp
+	return nil
+}
+
+func (s *memoryIdempotencyStorage) ReleaseIdempotencyKey(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.requests, key)
+	return nil
+}
+
+func newTestIdempotencyService(lockTTL int) (*IdempotencyService, *memoryIdempotencyStorage) {
+	storage := &memoryIdempotencyStorage{requests: map[string]user.IdempotentRequest{}}
+	cfg := config.ServiceConfiguration{}
+	cfg.Idempotency.TTL = 24
+	cfg.Idempotency.LockTTL = lockTTL
+
+	return NewIdempotencyService(storage, cfg), storage
+}
+
+func TestIdempotencyService_Begin(t *testing.T) {
+	s, _ := newTestIdempotencyService(60)
+	userID, otherID := uuid.New(), uuid.New()
+
+	_, err := s.Begin(userID, "", "fingerprint")
+	assert.Equal(t, InvalidIdempotencyKeyError, err)
+	_, err = s.Begin(userID, strings.Repeat("k", maxIdempotencyKeyLength+1), "fingerprint")
+	assert.Equal(t, InvalidIdempotencyKeyError, err)
+
+	saved, err := s.Begin(userID, "key", "fingerprint")
+	assert.NoError(t, err)
+	assert.Nil(t, saved)
+
+	_, err = s.Begin(userID, "key", "fingerprint")
+	assert.Equal(t, IdempotencyKeyInProgressError, err)
+	_, err = s.Begin(userID, "key", "other")
+	assert.Equal(t, IdempotencyKeyMismatchError, err)
+
+	// Ключи разных пользователей не пересекаются
+	saved, err = s.Begin(otherID, "key", "other")
+	assert.NoError(t, err)
+	assert.Nil(t, saved)
+
+	resp := user.IdempotentRequest{Fingerprint: "fingerprint", StatusCode: 200, ContentType: "application/json", Body: []byte(`{}`)}
+	assert.NoError(t, s.Complete(userID, "key", resp))
+
+	saved, err = s.Begin(userID, "key", "fingerprint")
+	assert.NoError(t, err)
+	resp.Status = user.IdempotencyCompleted
+	assert.Equal(t, &resp, saved)
+
+	assert.NoError(t, s.Release(otherID, "key"))
+	saved, err = s.Begin(otherID, "key", "fingerprint")
+	assert.NoError(t, err)
+	assert.Nil(t, saved)
+}
+
+func TestIdempotencyService_Hold(t *testing.T) {
+	s, storage := newTestIdempotencyService(60)
+	s.lockTTL = 30 * time.Millisecond
+	userID := uuid.New()
+
+	_, err := s.Begin(userID, "key", "fingerprint")
+	assert.NoError(t, err)
+
+	stop := s.Hold(userID, "key", "fingerprint")
+	time.Sleep(100 * time.Millisecond)
+	stop()
+
+	storage.mu.Lock()
+	extended := storage.extended
+	storage.mu.Unlock()
+	assert.GreaterOrEqual(t, extended, 2)
+
+	time.Sleep(50 * time.Millisecond)
+	storage.mu.Lock()
+	assert.Equal(t, extended, storage.extended)
+	storage.mu.Unlock()
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/config"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/mailer"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"log"
+	"time"
+)
+
+type OutboxStorage interface {
+	CreateOutboxEmail(email user.OutboxEmail) error
+	ClaimOutboxEmails(limit int) ([]user.OutboxEmail, error)
+	MarkOutboxEmailSent(id int) error
+	MarkOutboxEmailFailed(id int, sendErr string, nextAttemptAt time.Time, final bool) error
+}
+
+func NewMailService(storage OutboxStorage, m mailer.Mailer, cfg config.ServiceConfiguration) *MailService {
+	templates, err := mailer.NewTemplates()
+	if err != nil {
+		panic(err)
+	}
+
+	return &MailService{
+		storage:   storage,
+		mailer:    m,
+		templates: templates,
+		cfg:       cfg,
+	}
+}
+
+// MailService складывает письма в outbox, откуда их отправляет воркер send_emails.
+// Так медленный SMTP сервер не блокирует HTTP запрос
+type MailService struct {
+	storage   OutboxStorage
+	mailer    mailer.Mailer
+	templates *mailer.Templates
+	cfg       config.ServiceConfiguration
+}
+
+func (s *MailService) Enqueue(to string, templateName string, data interface{}) error {
+	return s.EnqueueWithHeaders(to, templateName, data, nil)
+}
+
+func (s *MailService) EnqueueWithHeaders(to string, templateName string, data interface{}, headers map[string]string) error {
+	subject, body, err := s.templates.Render(templateName, s.cfg.Email.Language, data)
+	if err != nil {
+		log.Println("Service. Render:", err)
+		return err
+	}
+
+	err = s.storage.CreateOutboxEmail(user.OutboxEmail{
+		Recipient: to,
+		Subject:   subject,
+		Body:      body,
+		Headers:   headers,
+	})
+	if err != nil {
+		log.Println("Service. CreateOutboxEmail:", err)
+		return err
+	}
+
+	return nil
+}
+
+// DeliverPending отправляет очередную пачку писем. Неудачные попытки повторяются с растущей задержкой,
+// после max_attempts письмо помечается как failed
+func (s *MailService) DeliverPending(ctx context.Context) (int, error) {
+	emails, err := s.storage.ClaimOutboxEmails(s.cfg.Email.Outbox.BatchSize)
+	if err != nil {
+		log.Println("Service. ClaimOutboxEmails:", err)
+		return 0, err
+	}
+
+	sent := 0
+	for _, email := range emails {
+		err = s.mailer.Send(ctx, mailer.Message{
+			To:      email.Recipient,
+			Subject: email.Subject,
+			HTML:    email.Body,
+			Headers: email.Headers,
+		})
+		if err != nil {
+			log.Println("Service. Send:", err)
+
+			attempt := email.Attempts + 1
+			final := attempt >= s.cfg.Email.Outbox.MaxAttempts
+			markErr := s.storage.MarkOutboxEmailFailed(email.ID, err.Error(), time.Now().Add(outboxBackoff(attempt)), final)
+			if markErr != nil {
+				log.Println("Service. MarkOutboxEmailFailed:", markErr)
+				return sent, markErr
+			}
+			continue
+		}
+
+		err = s.storage.MarkOutboxEmailSent(email.ID)
+		if err != nil {
+			log.Println("Service. MarkOutboxEmailSent:", err)
+			return sent, err
+		}
+		sent++
+	}
+
+	return sent, nil
+}
+
+func outboxBackoff(attempt int) time.Duration {
+	delay := 30 * time.Second
+	for i := 1; i < attempt && delay < time.Hour; i++ {
+		delay *= 2
+	}
+	if delay > time.Hour {
+		delay = time.Hour
+	}
+
+	return delay
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/config"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/mailer"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type memoryOutbox struct {
+	emails []user.OutboxEmail
+}
+
+func (o *memoryOutbox) CreateOutboxEmail(email user.OutboxEmail) error {
+	email.ID = len(o.emails) + 1
+	email.Status = user.PendingEmail
+	o.emails = append(o.emails, email)
+	return nil
+}
+
+func (o *memoryOutbox) ClaimOutboxEmails(limit int) ([]user.OutboxEmail, error) {
+	var claimed []user.OutboxEmail
+	for _, email := range o.emails {
+		if email.Status == user.PendingEmail && !email.NextAttemptAt.After(time.Now()) && len(claimed) < limit {
+			claimed = append(claimed, email)
+		}
+	}
+	return claimed, nil
+}
+
+func (o *memoryOutbox) MarkOutboxEmailSent(id int) error {
+	o.emails[id-1].Status = user.SentEmail
+	o.emails[id-1].Attempts++
+	return nil
+}
+
+func (o *memoryOutbox) MarkOutboxEmailFailed(id int, sendErr string, nextAttemptAt time.Time, final bool) error {
+	email := &o.emails[id-1]
+	email.Attempts++
+	email.LastError = &sendErr
+	email.NextAttemptAt = nextAttemptAt
+	if final {
+		email.Status = user.FailedEmail
+	}
+	return nil
+}
+
+type failingMailer struct{}
+
+func (failingMailer) Send(ctx context.Context, msg mailer.Message) error {
+	return errors.New("smtp unavailable")
+}
+
+func newTestMailConfig() config.ServiceConfiguration {
+	var cfg config.ServiceConfiguration
+	cfg.Email.Language = "en"
+	cfg.Email.Outbox = config.EmailOutbox{BatchSize: 10, MaxAttempts: 2}
+	return cfg
+}
+
+func TestMailService_DeliverPending(t *testing.T) {
+	outbox := &memoryOutbox{}
+	memoryMailer := mailer.NewMemoryMailer()
+	mail := NewMailService(outbox, memoryMailer, newTestMailConfig())
+
+	err := mail.Enqueue("test@test.test", mailer.VerificationCodeTemplate, map[string]interface{}{"Code": 123456, "TTLMinutes": 10})
+	assert.NoError(t, err)
+	assert.Len(t, memoryMailer.Messages(), 0)
+
+	sent, err := mail.DeliverPending(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Equal(t, user.SentEmail, outbox.emails[0].Status)
+
+	messages := memoryMailer.Messages()
+	assert.Len(t, messages, 1)
+	assert.Equal(t, "test@test.test", messages[0].To)
+	assert.Equal(t, "Email verification", messages[0].Subject)
+	assert.Contains(t, messages[0].HTML, "123456")
+}
+
+func TestMailService_DeliverPendingRetries(t *testing.T) {
+	outbox := &memoryOutbox{}
+	mail := NewMailService(outbox, failingMailer{}, newTestMailConfig())
+
+	err := mail.Enqueue("test@test.test", mailer.VerificationCodeTemplate, map[string]interface{}{"Code": 123456, "TTLMinutes": 10})
+	assert.NoError(t, err)
+
+	sent, err := mail.DeliverPending(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sent)
+	assert.Equal(t, user.PendingEmail, outbox.emails[0].Status)
+	assert.True(t, outbox.emails[0].NextAttemptAt.After(time.Now()))
+
+	outbox.emails[0].NextAttemptAt = time.Now()
+	_, err = mail.DeliverPending(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, user.FailedEmail, outbox.emails[0].Status)
+	assert.Equal(t, 2, outbox.emails[0].Attempts)
+	assert.Equal(t, "smtp unavailable", *outbox.emails[0].LastError)
+}
+
+func TestOutboxBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, outboxBackoff(1))
+	assert.Equal(t, 2*time.Minute, outboxBackoff(3))
+	assert.Equal(t, time.Hour, outboxBackoff(20))
+}
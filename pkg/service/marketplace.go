@@ -0,0 +1,73 @@
+package service
+
+import (
+	"github.com/Frozen-Fantasy/fantasy-backend.git/config"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/players"
+	"github.com/google/uuid"
+	"log"
+)
+
+type MarketplaceStorage interface {
+	CreateCardListing(sellerID uuid.UUID, inp players.CreateListingInput) (players.CardListing, error)
+	CancelCardListing(sellerID uuid.UUID, id int64) error
+	GetCardListings(filter players.CardListingsFilter) ([]players.CardListingResponse, error)
+	BuyCardListing(buyerID uuid.UUID, id int64, feePercent int) (players.CardListing, error)
+}
+
+func NewMarketplaceService(storage MarketplaceStorage, cfg config.ServiceConfiguration) *MarketplaceService {
+	return &MarketplaceService{
+		storage: storage,
+		cfg:     cfg,
+	}
+}
+
+// MarketplaceService - рынок карточек между пользователями
+type MarketplaceService struct {
+	storage MarketplaceStorage
+	cfg     config.ServiceConfiguration
+}
+
+func (s *MarketplaceService) CreateListing(sellerID uuid.UUID, inp players.CreateListingInput) (players.CardListing, error) {
+	listing, err := s.storage.CreateCardListing(sellerID, inp)
+	if err != nil {
+		log.Println("Service. CreateCardListing:", err)
+		return listing, err
+	}
+
+	return listing, nil
+}
+
+func (s *MarketplaceService) CancelListing(sellerID uuid.UUID, id int64) error {
+	err := s.storage.CancelCardListing(sellerID, id)
+	if err != nil {
+		log.Println("Service. CancelCardListing:", err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *MarketplaceService) GetListings(filter players.CardListingsFilter) ([]players.CardListingResponse, error) {
+	if filter.Limit == 0 {
+		filter.Limit = players.DefaultListingsLimit
+	}
+
+	listings, err := s.storage.GetCardListings(filter)
+	if err != nil {
+		log.Println("Service. GetCardListings:", err)
+		return listings, err
+	}
+
+	return listings, nil
+}
+
+// BuyListing покупает карточку по цене объявления. Комиссия площадки из конфига удерживается из суммы продавцу
+func (s *MarketplaceService) BuyListing(buyerID uuid.UUID, id int64) (players.CardListing, error) {
+	listing, err := s.storage.BuyCardListing(buyerID, id, s.cfg.Marketplace.FeePercent)
+	if err != nil {
+		log.Println("Service. BuyCardListing:", err)
+		return listing, err
+	}
+
+	return listing, nil
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"github.com/Frozen-Fantasy/fantasy-backend.git/config"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/players"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type marketplaceStorage struct {
+	MarketplaceStorage
+	filter     players.CardListingsFilter
+	feePercent int
+}
+
+func (s *marketplaceStorage) GetCardListings(filter players.CardListingsFilter) ([]players.CardListingResponse, error) {
+	s.filter = filter
+	return []players.CardListingResponse{}, nil
+}
+
+func (s *marketplaceStorage) BuyCardListing(buyerID uuid.UUID, id int64, feePercent int) (players.CardListing, error) {
+	s.feePercent = feePercent
+	return players.CardListing{ID: id, Status: players.SoldListing, BuyerID: &buyerID}, nil
+}
+
+func TestMarketplaceService(t *testing.T) {
+	storage := &marketplaceStorage{}
+	s := NewMarketplaceService(storage, config.ServiceConfiguration{Marketplace: config.Marketplace{FeePercent: 7}})
+
+	_, err := s.GetListings(players.CardListingsFilter{Rarity: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, players.CardListingsFilter{Rarity: 2, Limit: players.DefaultListingsLimit}, storage.filter)
+
+	_, err = s.GetListings(players.CardListingsFilter{Limit: 50})
+	assert.NoError(t, err)
+	assert.Equal(t, 50, storage.filter.Limit)
+
+	listing, err := s.BuyListing(uuid.New(), 3)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), listing.ID)
+	assert.Equal(t, 7, storage.feePercent)
+}
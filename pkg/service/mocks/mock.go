@@ -6,11 +6,16 @@ package mock_service
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 
+	config "github.com/Frozen-Fantasy/fantasy-backend.git/config"
+	blobstore "github.com/Frozen-Fantasy/fantasy-backend.git/pkg/blobstore"
+	players "github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/players"
 	store "github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/store"
 	tournaments "github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/tournaments"
 	user "github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	service "github.com/Frozen-Fantasy/fantasy-backend.git/pkg/service"
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
 )
@@ -96,6 +101,20 @@ func (mr *MockUserMockRecorder) CheckNicknameExists(nickname interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckNicknameExists", reflect.TypeOf((*MockUser)(nil).CheckNicknameExists), nickname)
 }
 
+// CheckProfileActive mocks base method.
+func (m *MockUser) CheckProfileActive(userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckProfileActive", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckProfileActive indicates an expected call of CheckProfileActive.
+func (mr *MockUserMockRecorder) CheckProfileActive(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckProfileActive", reflect.TypeOf((*MockUser)(nil).CheckProfileActive), userID)
+}
+
 // CheckUserDataExists mocks base method.
 func (m *MockUser) CheckUserDataExists(inp user.UserExistsDataInput) error {
 	m.ctrl.T.Helper()
@@ -110,19 +129,63 @@ func (mr *MockUserMockRecorder) CheckUserDataExists(inp interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckUserDataExists", reflect.TypeOf((*MockUser)(nil).CheckUserDataExists), inp)
 }
 
+// ConfirmEmailChange mocks base method.
+func (m *MockUser) ConfirmEmailChange(userID uuid.UUID, code int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmEmailChange", userID, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmEmailChange indicates an expected call of ConfirmEmailChange.
+func (mr *MockUserMockRecorder) ConfirmEmailChange(userID, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmEmailChange", reflect.TypeOf((*MockUser)(nil).ConfirmEmailChange), userID, code)
+}
+
+// ConfirmTwoFactor mocks base method.
+func (m *MockUser) ConfirmTwoFactor(userID uuid.UUID, code string) (user.RecoveryCodes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTwoFactor", userID, code)
+	ret0, _ := ret[0].(user.RecoveryCodes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmTwoFactor indicates an expected call of ConfirmTwoFactor.
+func (mr *MockUserMockRecorder) ConfirmTwoFactor(userID, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTwoFactor", reflect.TypeOf((*MockUser)(nil).ConfirmTwoFactor), userID, code)
+}
+
 // CreateSession mocks base method.
-func (m *MockUser) CreateSession(userID uuid.UUID) (user.Tokens, error) {
+func (m *MockUser) CreateSession(userID uuid.UUID, device user.DeviceInfo) (user.Tokens, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateSession", userID)
+	ret := m.ctrl.Call(m, "CreateSession", userID, device)
 	ret0, _ := ret[0].(user.Tokens)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateSession indicates an expected call of CreateSession.
-func (mr *MockUserMockRecorder) CreateSession(userID interface{}) *gomock.Call {
+func (mr *MockUserMockRecorder) CreateSession(userID, device interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockUser)(nil).CreateSession), userID, device)
+}
+
+// DeductCoins mocks base method.
+func (m *MockUser) DeductCoins(adminID uuid.UUID, inp user.CoinAdjustmentInput) (user.CoinAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeductCoins", adminID, inp)
+	ret0, _ := ret[0].(user.CoinAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeductCoins indicates an expected call of DeductCoins.
+func (mr *MockUserMockRecorder) DeductCoins(adminID, inp interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockUser)(nil).CreateSession), userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeductCoins", reflect.TypeOf((*MockUser)(nil).DeductCoins), adminID, inp)
 }
 
 // DeleteProfile mocks base method.
@@ -139,6 +202,35 @@ func (mr *MockUserMockRecorder) DeleteProfile(userID interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProfile", reflect.TypeOf((*MockUser)(nil).DeleteProfile), userID)
 }
 
+// DisableTwoFactor mocks base method.
+func (m *MockUser) DisableTwoFactor(userID uuid.UUID, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableTwoFactor", userID, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableTwoFactor indicates an expected call of DisableTwoFactor.
+func (mr *MockUserMockRecorder) DisableTwoFactor(userID, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTwoFactor", reflect.TypeOf((*MockUser)(nil).DisableTwoFactor), userID, code)
+}
+
+// EnrollTwoFactor mocks base method.
+func (m *MockUser) EnrollTwoFactor(userID uuid.UUID) (user.TwoFactorEnrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollTwoFactor", userID)
+	ret0, _ := ret[0].(user.TwoFactorEnrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrollTwoFactor indicates an expected call of EnrollTwoFactor.
+func (mr *MockUserMockRecorder) EnrollTwoFactor(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollTwoFactor", reflect.TypeOf((*MockUser)(nil).EnrollTwoFactor), userID)
+}
+
 // ForgotPassword mocks base method.
 func (m *MockUser) ForgotPassword(email string) error {
 	m.ctrl.T.Helper()
@@ -153,19 +245,65 @@ func (mr *MockUserMockRecorder) ForgotPassword(email interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgotPassword", reflect.TypeOf((*MockUser)(nil).ForgotPassword), email)
 }
 
+// GetAvatar mocks base method.
+func (m *MockUser) GetAvatar(name string) (io.ReadCloser, blobstore.BlobInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAvatar", name)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(blobstore.BlobInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAvatar indicates an expected call of GetAvatar.
+func (mr *MockUserMockRecorder) GetAvatar(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvatar", reflect.TypeOf((*MockUser)(nil).GetAvatar), name)
+}
+
+// GetCoinAdjustments mocks base method.
+func (m *MockUser) GetCoinAdjustments(profileID uuid.UUID) ([]user.CoinAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCoinAdjustments", profileID)
+	ret0, _ := ret[0].([]user.CoinAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCoinAdjustments indicates an expected call of GetCoinAdjustments.
+func (mr *MockUserMockRecorder) GetCoinAdjustments(profileID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCoinAdjustments", reflect.TypeOf((*MockUser)(nil).GetCoinAdjustments), profileID)
+}
+
 // GetCoinTransactions mocks base method.
-func (m *MockUser) GetCoinTransactions(profileID uuid.UUID) ([]user.CoinTransactionsModel, error) {
+func (m *MockUser) GetCoinTransactions(profileID uuid.UUID, filter user.CoinTransactionsFilter) (user.CoinTransactionsPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCoinTransactions", profileID)
-	ret0, _ := ret[0].([]user.CoinTransactionsModel)
+	ret := m.ctrl.Call(m, "GetCoinTransactions", profileID, filter)
+	ret0, _ := ret[0].(user.CoinTransactionsPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCoinTransactions indicates an expected call of GetCoinTransactions.
-func (mr *MockUserMockRecorder) GetCoinTransactions(profileID interface{}) *gomock.Call {
+func (mr *MockUserMockRecorder) GetCoinTransactions(profileID, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCoinTransactions", reflect.TypeOf((*MockUser)(nil).GetCoinTransactions), profileID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCoinTransactions", reflect.TypeOf((*MockUser)(nil).GetCoinTransactions), profileID, filter)
+}
+
+// GetSessions mocks base method.
+func (m *MockUser) GetSessions(userID uuid.UUID) ([]user.SessionInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessions", userID)
+	ret0, _ := ret[0].([]user.SessionInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessions indicates an expected call of GetSessions.
+func (mr *MockUserMockRecorder) GetSessions(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessions", reflect.TypeOf((*MockUser)(nil).GetSessions), userID)
 }
 
 // GetUserInfo mocks base method.
@@ -183,6 +321,35 @@ func (mr *MockUserMockRecorder) GetUserInfo(userID interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserInfo", reflect.TypeOf((*MockUser)(nil).GetUserInfo), userID)
 }
 
+// GrantCoins mocks base method.
+func (m *MockUser) GrantCoins(adminID uuid.UUID, inp user.CoinAdjustmentInput) (user.CoinAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantCoins", adminID, inp)
+	ret0, _ := ret[0].(user.CoinAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GrantCoins indicates an expected call of GrantCoins.
+func (mr *MockUserMockRecorder) GrantCoins(adminID, inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantCoins", reflect.TypeOf((*MockUser)(nil).GrantCoins), adminID, inp)
+}
+
+// GrantRole mocks base method.
+func (m *MockUser) GrantRole(inp user.RoleInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantRole", inp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GrantRole indicates an expected call of GrantRole.
+func (mr *MockUserMockRecorder) GrantRole(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantRole", reflect.TypeOf((*MockUser)(nil).GrantRole), inp)
+}
+
 // Logout mocks base method.
 func (m *MockUser) Logout(refreshTokenID string) error {
 	m.ctrl.T.Helper()
@@ -197,19 +364,63 @@ func (mr *MockUserMockRecorder) Logout(refreshTokenID interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockUser)(nil).Logout), refreshTokenID)
 }
 
+// PurgeDeletedProfiles mocks base method.
+func (m *MockUser) PurgeDeletedProfiles(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedProfiles", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedProfiles indicates an expected call of PurgeDeletedProfiles.
+func (mr *MockUserMockRecorder) PurgeDeletedProfiles(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedProfiles", reflect.TypeOf((*MockUser)(nil).PurgeDeletedProfiles), ctx)
+}
+
 // RefreshTokens mocks base method.
-func (m *MockUser) RefreshTokens(refreshTokenID string) (user.Tokens, error) {
+func (m *MockUser) RefreshTokens(refreshTokenID string, device user.DeviceInfo) (user.Tokens, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RefreshTokens", refreshTokenID)
+	ret := m.ctrl.Call(m, "RefreshTokens", refreshTokenID, device)
 	ret0, _ := ret[0].(user.Tokens)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // RefreshTokens indicates an expected call of RefreshTokens.
-func (mr *MockUserMockRecorder) RefreshTokens(refreshTokenID interface{}) *gomock.Call {
+func (mr *MockUserMockRecorder) RefreshTokens(refreshTokenID, device interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshTokens", reflect.TypeOf((*MockUser)(nil).RefreshTokens), refreshTokenID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshTokens", reflect.TypeOf((*MockUser)(nil).RefreshTokens), refreshTokenID, device)
+}
+
+// RegenerateRecoveryCodes mocks base method.
+func (m *MockUser) RegenerateRecoveryCodes(userID uuid.UUID, code string) (user.RecoveryCodes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegenerateRecoveryCodes", userID, code)
+	ret0, _ := ret[0].(user.RecoveryCodes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegenerateRecoveryCodes indicates an expected call of RegenerateRecoveryCodes.
+func (mr *MockUserMockRecorder) RegenerateRecoveryCodes(userID, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegenerateRecoveryCodes", reflect.TypeOf((*MockUser)(nil).RegenerateRecoveryCodes), userID, code)
+}
+
+// RequestEmailChange mocks base method.
+func (m *MockUser) RequestEmailChange(userID uuid.UUID, inp user.ChangeEmailInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestEmailChange", userID, inp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestEmailChange indicates an expected call of RequestEmailChange.
+func (mr *MockUserMockRecorder) RequestEmailChange(userID, inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestEmailChange", reflect.TypeOf((*MockUser)(nil).RequestEmailChange), userID, inp)
 }
 
 // ResetPassword mocks base method.
@@ -226,6 +437,62 @@ func (mr *MockUserMockRecorder) ResetPassword(inp interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockUser)(nil).ResetPassword), inp)
 }
 
+// RevokeAllSessions mocks base method.
+func (m *MockUser) RevokeAllSessions(userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAllSessions", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAllSessions indicates an expected call of RevokeAllSessions.
+func (mr *MockUserMockRecorder) RevokeAllSessions(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAllSessions", reflect.TypeOf((*MockUser)(nil).RevokeAllSessions), userID)
+}
+
+// RevokeOtherSessions mocks base method.
+func (m *MockUser) RevokeOtherSessions(userID uuid.UUID, refreshTokenID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeOtherSessions", userID, refreshTokenID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeOtherSessions indicates an expected call of RevokeOtherSessions.
+func (mr *MockUserMockRecorder) RevokeOtherSessions(userID, refreshTokenID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeOtherSessions", reflect.TypeOf((*MockUser)(nil).RevokeOtherSessions), userID, refreshTokenID)
+}
+
+// RevokeRole mocks base method.
+func (m *MockUser) RevokeRole(inp user.RoleInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRole", inp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRole indicates an expected call of RevokeRole.
+func (mr *MockUserMockRecorder) RevokeRole(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRole", reflect.TypeOf((*MockUser)(nil).RevokeRole), inp)
+}
+
+// RevokeSession mocks base method.
+func (m *MockUser) RevokeSession(userID, sessionID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", userID, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockUserMockRecorder) RevokeSession(userID, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockUser)(nil).RevokeSession), userID, sessionID)
+}
+
 // SendVerificationCode mocks base method.
 func (m *MockUser) SendVerificationCode(email string) error {
 	m.ctrl.T.Helper()
@@ -241,18 +508,18 @@ func (mr *MockUserMockRecorder) SendVerificationCode(email interface{}) *gomock.
 }
 
 // SignIn mocks base method.
-func (m *MockUser) SignIn(input user.SignInInput) (user.Tokens, error) {
+func (m *MockUser) SignIn(input user.SignInInput, device user.DeviceInfo) (user.Tokens, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SignIn", input)
+	ret := m.ctrl.Call(m, "SignIn", input, device)
 	ret0, _ := ret[0].(user.Tokens)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SignIn indicates an expected call of SignIn.
-func (mr *MockUserMockRecorder) SignIn(input interface{}) *gomock.Call {
+func (mr *MockUserMockRecorder) SignIn(input, device interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignIn", reflect.TypeOf((*MockUser)(nil).SignIn), input)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignIn", reflect.TypeOf((*MockUser)(nil).SignIn), input, device)
 }
 
 // SignUp mocks base method.
@@ -269,231 +536,1362 @@ func (mr *MockUserMockRecorder) SignUp(input interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignUp", reflect.TypeOf((*MockUser)(nil).SignUp), input)
 }
 
-// MockTokenManager is a mock of TokenManager interface.
-type MockTokenManager struct {
-	ctrl     *gomock.Controller
-	recorder *MockTokenManagerMockRecorder
+// UndoEmailChange mocks base method.
+func (m *MockUser) UndoEmailChange(token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UndoEmailChange", token)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// MockTokenManagerMockRecorder is the mock recorder for MockTokenManager.
-type MockTokenManagerMockRecorder struct {
-	mock *MockTokenManager
+// UndoEmailChange indicates an expected call of UndoEmailChange.
+func (mr *MockUserMockRecorder) UndoEmailChange(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UndoEmailChange", reflect.TypeOf((*MockUser)(nil).UndoEmailChange), token)
 }
 
-// NewMockTokenManager creates a new mock instance.
-func NewMockTokenManager(ctrl *gomock.Controller) *MockTokenManager {
-	mock := &MockTokenManager{ctrl: ctrl}
-	mock.recorder = &MockTokenManagerMockRecorder{mock}
-	return mock
+// UpdateProfile mocks base method.
+func (m *MockUser) UpdateProfile(userID uuid.UUID, inp user.UpdateProfileInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfile", userID, inp)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockTokenManager) EXPECT() *MockTokenManagerMockRecorder {
-	return m.recorder
+// UpdateProfile indicates an expected call of UpdateProfile.
+func (mr *MockUserMockRecorder) UpdateProfile(userID, inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*MockUser)(nil).UpdateProfile), userID, inp)
 }
 
-// CreateJWT mocks base method.
-func (m *MockTokenManager) CreateJWT(userID string) (int64, string, error) {
+// UploadAvatar mocks base method.
+func (m *MockUser) UploadAvatar(userID uuid.UUID, file io.Reader) (user.Avatar, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateJWT", userID)
-	ret0, _ := ret[0].(int64)
-	ret1, _ := ret[1].(string)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret := m.ctrl.Call(m, "UploadAvatar", userID, file)
+	ret0, _ := ret[0].(user.Avatar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// CreateJWT indicates an expected call of CreateJWT.
-func (mr *MockTokenManagerMockRecorder) CreateJWT(userID interface{}) *gomock.Call {
+// UploadAvatar indicates an expected call of UploadAvatar.
+func (mr *MockUserMockRecorder) UploadAvatar(userID, file interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJWT", reflect.TypeOf((*MockTokenManager)(nil).CreateJWT), userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadAvatar", reflect.TypeOf((*MockUser)(nil).UploadAvatar), userID, file)
 }
 
-// CreateRefreshToken mocks base method.
-func (m *MockTokenManager) CreateRefreshToken() (string, error) {
+// VerifyTwoFactor mocks base method.
+func (m *MockUser) VerifyTwoFactor(inp user.TwoFactorVerifyInput, device user.DeviceInfo) (user.Tokens, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateRefreshToken")
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "VerifyTwoFactor", inp, device)
+	ret0, _ := ret[0].(user.Tokens)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateRefreshToken indicates an expected call of CreateRefreshToken.
-func (mr *MockTokenManagerMockRecorder) CreateRefreshToken() *gomock.Call {
+// VerifyTwoFactor indicates an expected call of VerifyTwoFactor.
+func (mr *MockUserMockRecorder) VerifyTwoFactor(inp, device interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRefreshToken", reflect.TypeOf((*MockTokenManager)(nil).CreateRefreshToken))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyTwoFactor", reflect.TypeOf((*MockUser)(nil).VerifyTwoFactor), inp, device)
 }
 
-// ParseJWT mocks base method.
-func (m *MockTokenManager) ParseJWT(accessToken string) (string, error) {
+// WriteCoinTransactionsCSV mocks base method.
+func (m *MockUser) WriteCoinTransactionsCSV(profileID uuid.UUID, filter user.CoinTransactionsFilter, w io.Writer) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ParseJWT", accessToken)
-	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "WriteCoinTransactionsCSV", profileID, filter, w)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// ParseJWT indicates an expected call of ParseJWT.
-func (mr *MockTokenManagerMockRecorder) ParseJWT(accessToken interface{}) *gomock.Call {
+// WriteCoinTransactionsCSV indicates an expected call of WriteCoinTransactionsCSV.
+func (mr *MockUserMockRecorder) WriteCoinTransactionsCSV(profileID, filter, w interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseJWT", reflect.TypeOf((*MockTokenManager)(nil).ParseJWT), accessToken)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteCoinTransactionsCSV", reflect.TypeOf((*MockUser)(nil).WriteCoinTransactionsCSV), profileID, filter, w)
 }
 
-// MockTeams is a mock of Teams interface.
-type MockTeams struct {
+// MockNotifications is a mock of Notifications interface.
+type MockNotifications struct {
 	ctrl     *gomock.Controller
-	recorder *MockTeamsMockRecorder
+	recorder *MockNotificationsMockRecorder
 }
 
-// MockTeamsMockRecorder is the mock recorder for MockTeams.
-type MockTeamsMockRecorder struct {
-	mock *MockTeams
+// MockNotificationsMockRecorder is the mock recorder for MockNotifications.
+type MockNotificationsMockRecorder struct {
+	mock *MockNotifications
 }
 
-// NewMockTeams creates a new mock instance.
-func NewMockTeams(ctrl *gomock.Controller) *MockTeams {
-	mock := &MockTeams{ctrl: ctrl}
-	mock.recorder = &MockTeamsMockRecorder{mock}
+// NewMockNotifications creates a new mock instance.
+func NewMockNotifications(ctrl *gomock.Controller) *MockNotifications {
+	mock := &MockNotifications{ctrl: ctrl}
+	mock.recorder = &MockNotificationsMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockTeams) EXPECT() *MockTeamsMockRecorder {
+func (m *MockNotifications) EXPECT() *MockNotificationsMockRecorder {
 	return m.recorder
 }
 
-// AddEventsKHL mocks base method.
-func (m *MockTeams) AddEventsKHL(ctx context.Context, events []tournaments.EventDataKHL) error {
+// GetPreferences mocks base method.
+func (m *MockNotifications) GetPreferences(userID uuid.UUID) (user.NotificationPreferences, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddEventsKHL", ctx, events)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "GetPreferences", userID)
+	ret0, _ := ret[0].(user.NotificationPreferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// AddEventsKHL indicates an expected call of AddEventsKHL.
-func (mr *MockTeamsMockRecorder) AddEventsKHL(ctx, events interface{}) *gomock.Call {
+// GetPreferences indicates an expected call of GetPreferences.
+func (mr *MockNotificationsMockRecorder) GetPreferences(userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEventsKHL", reflect.TypeOf((*MockTeams)(nil).AddEventsKHL), ctx, events)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreferences", reflect.TypeOf((*MockNotifications)(nil).GetPreferences), userID)
 }
 
-// AddEventsNHL mocks base method.
-func (m *MockTeams) AddEventsNHL(ctx context.Context, events []tournaments.Game) error {
+// Unsubscribe mocks base method.
+func (m *MockNotifications) Unsubscribe(token string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddEventsNHL", ctx, events)
+	ret := m.ctrl.Call(m, "Unsubscribe", token)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// AddEventsNHL indicates an expected call of AddEventsNHL.
-func (mr *MockTeamsMockRecorder) AddEventsNHL(ctx, events interface{}) *gomock.Call {
+// Unsubscribe indicates an expected call of Unsubscribe.
+func (mr *MockNotificationsMockRecorder) Unsubscribe(token interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEventsNHL", reflect.TypeOf((*MockTeams)(nil).AddEventsNHL), ctx, events)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockNotifications)(nil).Unsubscribe), token)
 }
 
-// CreateTeamsKHL mocks base method.
-func (m *MockTeams) CreateTeamsKHL(ctx context.Context, teams []tournaments.TeamKHL) error {
+// UpdatePreferences mocks base method.
+func (m *MockNotifications) UpdatePreferences(userID uuid.UUID, inp user.NotificationPreferencesInput) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTeamsKHL", ctx, teams)
+	ret := m.ctrl.Call(m, "UpdatePreferences", userID, inp)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// CreateTeamsKHL indicates an expected call of CreateTeamsKHL.
-func (mr *MockTeamsMockRecorder) CreateTeamsKHL(ctx, teams interface{}) *gomock.Call {
+// UpdatePreferences indicates an expected call of UpdatePreferences.
+func (mr *MockNotificationsMockRecorder) UpdatePreferences(userID, inp interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTeamsKHL", reflect.TypeOf((*MockTeams)(nil).CreateTeamsKHL), ctx, teams)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePreferences", reflect.TypeOf((*MockNotifications)(nil).UpdatePreferences), userID, inp)
 }
 
-// CreateTeamsNHL mocks base method.
-func (m *MockTeams) CreateTeamsNHL(arg0 context.Context, arg1 []tournaments.Standing) error {
+// MockExports is a mock of Exports interface.
+type MockExports struct {
+	ctrl     *gomock.Controller
+	recorder *MockExportsMockRecorder
+}
+
+// MockExportsMockRecorder is the mock recorder for MockExports.
+type MockExportsMockRecorder struct {
+	mock *MockExports
+}
+
+// NewMockExports creates a new mock instance.
+func NewMockExports(ctrl *gomock.Controller) *MockExports {
+	mock := &MockExports{ctrl: ctrl}
+	mock.recorder = &MockExportsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExports) EXPECT() *MockExportsMockRecorder {
+	return m.recorder
+}
+
+// GetDataExport mocks base method.
+func (m *MockExports) GetDataExport(userID, exportID uuid.UUID) (user.DataExport, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTeamsNHL", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "GetDataExport", userID, exportID)
+	ret0, _ := ret[0].(user.DataExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// CreateTeamsNHL indicates an expected call of CreateTeamsNHL.
-func (mr *MockTeamsMockRecorder) CreateTeamsNHL(arg0, arg1 interface{}) *gomock.Call {
+// GetDataExport indicates an expected call of GetDataExport.
+func (mr *MockExportsMockRecorder) GetDataExport(userID, exportID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTeamsNHL", reflect.TypeOf((*MockTeams)(nil).CreateTeamsNHL), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDataExport", reflect.TypeOf((*MockExports)(nil).GetDataExport), userID, exportID)
 }
 
-// CreateTournaments mocks base method.
-func (m *MockTeams) CreateTournaments(ctx context.Context) error {
+// OpenDataExport mocks base method.
+func (m *MockExports) OpenDataExport(inp user.DataExportDownloadInput) (io.ReadCloser, blobstore.BlobInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTournaments", ctx)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "OpenDataExport", inp)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(blobstore.BlobInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// CreateTournaments indicates an expected call of CreateTournaments.
-func (mr *MockTeamsMockRecorder) CreateTournaments(ctx interface{}) *gomock.Call {
+// OpenDataExport indicates an expected call of OpenDataExport.
+func (mr *MockExportsMockRecorder) OpenDataExport(inp interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTournaments", reflect.TypeOf((*MockTeams)(nil).CreateTournaments), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenDataExport", reflect.TypeOf((*MockExports)(nil).OpenDataExport), inp)
 }
 
-// GetMatchesDay mocks base method.
-func (m *MockTeams) GetMatchesDay(ctx context.Context, league tournaments.League) ([]tournaments.Matches, error) {
+// RequestDataExport mocks base method.
+func (m *MockExports) RequestDataExport(userID uuid.UUID) (user.DataExport, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMatchesDay", ctx, league)
-	ret0, _ := ret[0].([]tournaments.Matches)
+	ret := m.ctrl.Call(m, "RequestDataExport", userID)
+	ret0, _ := ret[0].(user.DataExport)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetMatchesDay indicates an expected call of GetMatchesDay.
-func (mr *MockTeamsMockRecorder) GetMatchesDay(ctx, league interface{}) *gomock.Call {
+// RequestDataExport indicates an expected call of RequestDataExport.
+func (mr *MockExportsMockRecorder) RequestDataExport(userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMatchesDay", reflect.TypeOf((*MockTeams)(nil).GetMatchesDay), ctx, league)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestDataExport", reflect.TypeOf((*MockExports)(nil).RequestDataExport), userID)
 }
 
-// MockStore is a mock of Store interface.
-type MockStore struct {
+// MockReconciliation is a mock of Reconciliation interface.
+type MockReconciliation struct {
 	ctrl     *gomock.Controller
-	recorder *MockStoreMockRecorder
+	recorder *MockReconciliationMockRecorder
 }
 
-// MockStoreMockRecorder is the mock recorder for MockStore.
-type MockStoreMockRecorder struct {
-	mock *MockStore
+// MockReconciliationMockRecorder is the mock recorder for MockReconciliation.
+type MockReconciliationMockRecorder struct {
+	mock *MockReconciliation
 }
 
-// NewMockStore creates a new mock instance.
-func NewMockStore(ctrl *gomock.Controller) *MockStore {
-	mock := &MockStore{ctrl: ctrl}
+// NewMockReconciliation creates a new mock instance.
+func NewMockReconciliation(ctrl *gomock.Controller) *MockReconciliation {
+	mock := &MockReconciliation{ctrl: ctrl}
+	mock.recorder = &MockReconciliationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReconciliation) EXPECT() *MockReconciliationMockRecorder {
+	return m.recorder
+}
+
+// GetReconciliationReport mocks base method.
+func (m *MockReconciliation) GetReconciliationReport(runID int64) (user.ReconciliationReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReconciliationReport", runID)
+	ret0, _ := ret[0].(user.ReconciliationReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReconciliationReport indicates an expected call of GetReconciliationReport.
+func (mr *MockReconciliationMockRecorder) GetReconciliationReport(runID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReconciliationReport", reflect.TypeOf((*MockReconciliation)(nil).GetReconciliationReport), runID)
+}
+
+// GetReconciliationRuns mocks base method.
+func (m *MockReconciliation) GetReconciliationRuns() ([]user.ReconciliationRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReconciliationRuns")
+	ret0, _ := ret[0].([]user.ReconciliationRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReconciliationRuns indicates an expected call of GetReconciliationRuns.
+func (mr *MockReconciliationMockRecorder) GetReconciliationRuns() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReconciliationRuns", reflect.TypeOf((*MockReconciliation)(nil).GetReconciliationRuns))
+}
+
+// ReconcileBalances mocks base method.
+func (m *MockReconciliation) ReconcileBalances() (user.ReconciliationRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileBalances")
+	ret0, _ := ret[0].(user.ReconciliationRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReconcileBalances indicates an expected call of ReconcileBalances.
+func (mr *MockReconciliationMockRecorder) ReconcileBalances() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileBalances", reflect.TypeOf((*MockReconciliation)(nil).ReconcileBalances))
+}
+
+// MockTokenManager is a mock of TokenManager interface.
+type MockTokenManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenManagerMockRecorder
+}
+
+// MockTokenManagerMockRecorder is the mock recorder for MockTokenManager.
+type MockTokenManagerMockRecorder struct {
+	mock *MockTokenManager
+}
+
+// NewMockTokenManager creates a new mock instance.
+func NewMockTokenManager(ctrl *gomock.Controller) *MockTokenManager {
+	mock := &MockTokenManager{ctrl: ctrl}
+	mock.recorder = &MockTokenManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenManager) EXPECT() *MockTokenManagerMockRecorder {
+	return m.recorder
+}
+
+// CreateJWT mocks base method.
+func (m *MockTokenManager) CreateJWT(userID string, roles []string) (int64, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateJWT", userID, roles)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateJWT indicates an expected call of CreateJWT.
+func (mr *MockTokenManagerMockRecorder) CreateJWT(userID, roles interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJWT", reflect.TypeOf((*MockTokenManager)(nil).CreateJWT), userID, roles)
+}
+
+// CreateRefreshToken mocks base method.
+func (m *MockTokenManager) CreateRefreshToken() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRefreshToken")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRefreshToken indicates an expected call of CreateRefreshToken.
+func (mr *MockTokenManagerMockRecorder) CreateRefreshToken() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRefreshToken", reflect.TypeOf((*MockTokenManager)(nil).CreateRefreshToken))
+}
+
+// JWKS mocks base method.
+func (m *MockTokenManager) JWKS() service.JWKSet {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JWKS")
+	ret0, _ := ret[0].(service.JWKSet)
+	return ret0
+}
+
+// JWKS indicates an expected call of JWKS.
+func (mr *MockTokenManagerMockRecorder) JWKS() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JWKS", reflect.TypeOf((*MockTokenManager)(nil).JWKS))
+}
+
+// ParseJWT mocks base method.
+func (m *MockTokenManager) ParseJWT(accessToken string) (user.AccessTokenClaims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseJWT", accessToken)
+	ret0, _ := ret[0].(user.AccessTokenClaims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseJWT indicates an expected call of ParseJWT.
+func (mr *MockTokenManagerMockRecorder) ParseJWT(accessToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseJWT", reflect.TypeOf((*MockTokenManager)(nil).ParseJWT), accessToken)
+}
+
+// MockRateLimiter is a mock of RateLimiter interface.
+type MockRateLimiter struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateLimiterMockRecorder
+}
+
+// MockRateLimiterMockRecorder is the mock recorder for MockRateLimiter.
+type MockRateLimiterMockRecorder struct {
+	mock *MockRateLimiter
+}
+
+// NewMockRateLimiter creates a new mock instance.
+func NewMockRateLimiter(ctrl *gomock.Controller) *MockRateLimiter {
+	mock := &MockRateLimiter{ctrl: ctrl}
+	mock.recorder = &MockRateLimiterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateLimiter) EXPECT() *MockRateLimiterMockRecorder {
+	return m.recorder
+}
+
+// Allow mocks base method.
+func (m *MockRateLimiter) Allow(key string, limit config.RateLimit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Allow", key, limit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Allow indicates an expected call of Allow.
+func (mr *MockRateLimiterMockRecorder) Allow(key, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Allow", reflect.TypeOf((*MockRateLimiter)(nil).Allow), key, limit)
+}
+
+// MockIdempotency is a mock of Idempotency interface.
+type MockIdempotency struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyMockRecorder
+}
+
+// MockIdempotencyMockRecorder is the mock recorder for MockIdempotency.
+type MockIdempotencyMockRecorder struct {
+	mock *MockIdempotency
+}
+
+// NewMockIdempotency creates a new mock instance.
+func NewMockIdempotency(ctrl *gomock.Controller) *MockIdempotency {
+	mock := &MockIdempotency{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotency) EXPECT() *MockIdempotencyMockRecorder {
+	return m.recorder
+}
+
+// Begin mocks base method.
+func (m *MockIdempotency) Begin(userID uuid.UUID, key, fingerprint string) (*user.IdempotentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Begin", userID, key, fingerprint)
+	ret0, _ := ret[0].(*user.IdempotentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Begin indicates an expected call of Begin.
+func (mr *MockIdempotencyMockRecorder) Begin(userID, key, fingerprint interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Begin", reflect.TypeOf((*MockIdempotency)(nil).Begin), userID, key, fingerprint)
+}
+
+// Complete mocks base method.
+func (m *MockIdempotency) Complete(userID uuid.UUID, key string, resp user.IdempotentRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", userID, key, resp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockIdempotencyMockRecorder) Complete(userID, key, resp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockIdempotency)(nil).Complete), userID, key, resp)
+}
+
+// Hold mocks base method.
+func (m *MockIdempotency) Hold(userID uuid.UUID, key, fingerprint string) func() {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hold", userID, key, fingerprint)
+	ret0, _ := ret[0].(func())
+	return ret0
+}
+
+// Hold indicates an expected call of Hold.
+func (mr *MockIdempotencyMockRecorder) Hold(userID, key, fingerprint interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hold", reflect.TypeOf((*MockIdempotency)(nil).Hold), userID, key, fingerprint)
+}
+
+// Release mocks base method.
+func (m *MockIdempotency) Release(userID uuid.UUID, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", userID, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockIdempotencyMockRecorder) Release(userID, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockIdempotency)(nil).Release), userID, key)
+}
+
+// MockTeams is a mock of Teams interface.
+type MockTeams struct {
+	ctrl     *gomock.Controller
+	recorder *MockTeamsMockRecorder
+}
+
+// MockTeamsMockRecorder is the mock recorder for MockTeams.
+type MockTeamsMockRecorder struct {
+	mock *MockTeams
+}
+
+// NewMockTeams creates a new mock instance.
+func NewMockTeams(ctrl *gomock.Controller) *MockTeams {
+	mock := &MockTeams{ctrl: ctrl}
+	mock.recorder = &MockTeamsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTeams) EXPECT() *MockTeamsMockRecorder {
+	return m.recorder
+}
+
+// CreateTeamsKHL mocks base method.
+func (m *MockTeams) CreateTeamsKHL(ctx context.Context, teams []tournaments.TeamKHL) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTeamsKHL", ctx, teams)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTeamsKHL indicates an expected call of CreateTeamsKHL.
+func (mr *MockTeamsMockRecorder) CreateTeamsKHL(ctx, teams interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTeamsKHL", reflect.TypeOf((*MockTeams)(nil).CreateTeamsKHL), ctx, teams)
+}
+
+// CreateTeamsNHL mocks base method.
+func (m *MockTeams) CreateTeamsNHL(arg0 context.Context, arg1 []tournaments.Standing) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTeamsNHL", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTeamsNHL indicates an expected call of CreateTeamsNHL.
+func (mr *MockTeamsMockRecorder) CreateTeamsNHL(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTeamsNHL", reflect.TypeOf((*MockTeams)(nil).CreateTeamsNHL), arg0, arg1)
+}
+
+// GetMatchesDay mocks base method.
+func (m *MockTeams) GetMatchesDay(ctx context.Context, league tournaments.League) ([]tournaments.Matches, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMatchesDay", ctx, league)
+	ret0, _ := ret[0].([]tournaments.Matches)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMatchesDay indicates an expected call of GetMatchesDay.
+func (mr *MockTeamsMockRecorder) GetMatchesDay(ctx, league interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMatchesDay", reflect.TypeOf((*MockTeams)(nil).GetMatchesDay), ctx, league)
+}
+
+// MockTournaments is a mock of Tournaments interface.
+type MockTournaments struct {
+	ctrl     *gomock.Controller
+	recorder *MockTournamentsMockRecorder
+}
+
+// MockTournamentsMockRecorder is the mock recorder for MockTournaments.
+type MockTournamentsMockRecorder struct {
+	mock *MockTournaments
+}
+
+// NewMockTournaments creates a new mock instance.
+func NewMockTournaments(ctrl *gomock.Controller) *MockTournaments {
+	mock := &MockTournaments{ctrl: ctrl}
+	mock.recorder = &MockTournamentsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTournaments) EXPECT() *MockTournamentsMockRecorder {
+	return m.recorder
+}
+
+// CheckUserTeam mocks base method.
+func (m *MockTournaments) CheckUserTeam(tournamentInfo tournaments.Tournament, userTeam []int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckUserTeam", tournamentInfo, userTeam)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckUserTeam indicates an expected call of CheckUserTeam.
+func (mr *MockTournamentsMockRecorder) CheckUserTeam(tournamentInfo, userTeam interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckUserTeam", reflect.TypeOf((*MockTournaments)(nil).CheckUserTeam), tournamentInfo, userTeam)
+}
+
+// CreateTournamentTeam mocks base method.
+func (m *MockTournaments) CreateTournamentTeam(inp tournaments.TournamentTeamModel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTournamentTeam", inp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTournamentTeam indicates an expected call of CreateTournamentTeam.
+func (mr *MockTournamentsMockRecorder) CreateTournamentTeam(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTournamentTeam", reflect.TypeOf((*MockTournaments)(nil).CreateTournamentTeam), inp)
+}
+
+// EditTournamentTeam mocks base method.
+func (m *MockTournaments) EditTournamentTeam(inp tournaments.TournamentTeamModel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditTournamentTeam", inp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EditTournamentTeam indicates an expected call of EditTournamentTeam.
+func (mr *MockTournamentsMockRecorder) EditTournamentTeam(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditTournamentTeam", reflect.TypeOf((*MockTournaments)(nil).EditTournamentTeam), inp)
+}
+
+// GetCachedTournamentResults mocks base method.
+func (m *MockTournaments) GetCachedTournamentResults(tournamentID int) ([]players.TournamentResults, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCachedTournamentResults", tournamentID)
+	ret0, _ := ret[0].([]players.TournamentResults)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCachedTournamentResults indicates an expected call of GetCachedTournamentResults.
+func (mr *MockTournamentsMockRecorder) GetCachedTournamentResults(tournamentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCachedTournamentResults", reflect.TypeOf((*MockTournaments)(nil).GetCachedTournamentResults), tournamentID)
+}
+
+// GetMatchesByTournamentsId mocks base method.
+func (m *MockTournaments) GetMatchesByTournamentsId(arg0 context.Context, arg1 tournaments.ID) ([]tournaments.GetMatchesByTourId, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMatchesByTournamentsId", arg0, arg1)
+	ret0, _ := ret[0].([]tournaments.GetMatchesByTourId)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMatchesByTournamentsId indicates an expected call of GetMatchesByTournamentsId.
+func (mr *MockTournamentsMockRecorder) GetMatchesByTournamentsId(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMatchesByTournamentsId", reflect.TypeOf((*MockTournaments)(nil).GetMatchesByTournamentsId), arg0, arg1)
+}
+
+// GetRosterByTournamentID mocks base method.
+func (m *MockTournaments) GetRosterByTournamentID(userID uuid.UUID, tournamentID int) (players.TournamentRosterResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRosterByTournamentID", userID, tournamentID)
+	ret0, _ := ret[0].(players.TournamentRosterResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRosterByTournamentID indicates an expected call of GetRosterByTournamentID.
+func (mr *MockTournamentsMockRecorder) GetRosterByTournamentID(userID, tournamentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRosterByTournamentID", reflect.TypeOf((*MockTournaments)(nil).GetRosterByTournamentID), userID, tournamentID)
+}
+
+// GetTeamCost mocks base method.
+func (m *MockTournaments) GetTeamCost(team []int) (float32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamCost", team)
+	ret0, _ := ret[0].(float32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamCost indicates an expected call of GetTeamCost.
+func (mr *MockTournamentsMockRecorder) GetTeamCost(team interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamCost", reflect.TypeOf((*MockTournaments)(nil).GetTeamCost), team)
+}
+
+// GetTournamentResults mocks base method.
+func (m *MockTournaments) GetTournamentResults(tournamentID int) ([]players.TournamentResults, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTournamentResults", tournamentID)
+	ret0, _ := ret[0].([]players.TournamentResults)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTournamentResults indicates an expected call of GetTournamentResults.
+func (mr *MockTournamentsMockRecorder) GetTournamentResults(tournamentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTournamentResults", reflect.TypeOf((*MockTournaments)(nil).GetTournamentResults), tournamentID)
+}
+
+// GetTournamentTeam mocks base method.
+func (m *MockTournaments) GetTournamentTeam(userID uuid.UUID, tournamentID int) (players.UserTeamResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTournamentTeam", userID, tournamentID)
+	ret0, _ := ret[0].(players.UserTeamResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTournamentTeam indicates an expected call of GetTournamentTeam.
+func (mr *MockTournamentsMockRecorder) GetTournamentTeam(userID, tournamentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTournamentTeam", reflect.TypeOf((*MockTournaments)(nil).GetTournamentTeam), userID, tournamentID)
+}
+
+// GetTournaments mocks base method.
+func (m *MockTournaments) GetTournaments(arg0 context.Context, arg1 tournaments.League) ([]tournaments.Tournament, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTournaments", arg0, arg1)
+	ret0, _ := ret[0].([]tournaments.Tournament)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTournaments indicates an expected call of GetTournaments.
+func (mr *MockTournamentsMockRecorder) GetTournaments(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTournaments", reflect.TypeOf((*MockTournaments)(nil).GetTournaments), arg0, arg1)
+}
+
+// GetTournamentsInfo mocks base method.
+func (m *MockTournaments) GetTournamentsInfo(filter tournaments.TournamentFilter) ([]tournaments.Tournament, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTournamentsInfo", filter)
+	ret0, _ := ret[0].([]tournaments.Tournament)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTournamentsInfo indicates an expected call of GetTournamentsInfo.
+func (mr *MockTournamentsMockRecorder) GetTournamentsInfo(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTournamentsInfo", reflect.TypeOf((*MockTournaments)(nil).GetTournamentsInfo), filter)
+}
+
+// MockStore is a mock of Store interface.
+type MockStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreMockRecorder
+}
+
+// MockStoreMockRecorder is the mock recorder for MockStore.
+type MockStoreMockRecorder struct {
+	mock *MockStore
+}
+
+// NewMockStore creates a new mock instance.
+func NewMockStore(ctrl *gomock.Controller) *MockStore {
+	mock := &MockStore{ctrl: ctrl}
 	mock.recorder = &MockStoreMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockStore) EXPECT() *MockStoreMockRecorder {
+func (m *MockStore) EXPECT() *MockStoreMockRecorder {
+	return m.recorder
+}
+
+// ArchiveProduct mocks base method.
+func (m *MockStore) ArchiveProduct(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveProduct", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ArchiveProduct indicates an expected call of ArchiveProduct.
+func (mr *MockStoreMockRecorder) ArchiveProduct(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveProduct", reflect.TypeOf((*MockStore)(nil).ArchiveProduct), id)
+}
+
+// BuyProduct mocks base method.
+func (m *MockStore) BuyProduct(buy store.BuyProductModel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BuyProduct", buy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BuyProduct indicates an expected call of BuyProduct.
+func (mr *MockStoreMockRecorder) BuyProduct(buy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuyProduct", reflect.TypeOf((*MockStore)(nil).BuyProduct), buy)
+}
+
+// CreateProduct mocks base method.
+func (m *MockStore) CreateProduct(inp store.ProductInput) (store.AdminProduct, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProduct", inp)
+	ret0, _ := ret[0].(store.AdminProduct)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProduct indicates an expected call of CreateProduct.
+func (mr *MockStoreMockRecorder) CreateProduct(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProduct", reflect.TypeOf((*MockStore)(nil).CreateProduct), inp)
+}
+
+// GetAdminProducts mocks base method.
+func (m *MockStore) GetAdminProducts(filter store.AdminProductsFilter) ([]store.AdminProduct, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAdminProducts", filter)
+	ret0, _ := ret[0].([]store.AdminProduct)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAdminProducts indicates an expected call of GetAdminProducts.
+func (mr *MockStoreMockRecorder) GetAdminProducts(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdminProducts", reflect.TypeOf((*MockStore)(nil).GetAdminProducts), filter)
+}
+
+// GetAllProducts mocks base method.
+func (m *MockStore) GetAllProducts() ([]store.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllProducts")
+	ret0, _ := ret[0].([]store.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllProducts indicates an expected call of GetAllProducts.
+func (mr *MockStoreMockRecorder) GetAllProducts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllProducts", reflect.TypeOf((*MockStore)(nil).GetAllProducts))
+}
+
+// GetPackSeed mocks base method.
+func (m *MockStore) GetPackSeed(profileID uuid.UUID) (store.PackSeed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPackSeed", profileID)
+	ret0, _ := ret[0].(store.PackSeed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPackSeed indicates an expected call of GetPackSeed.
+func (mr *MockStoreMockRecorder) GetPackSeed(profileID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPackSeed", reflect.TypeOf((*MockStore)(nil).GetPackSeed), profileID)
+}
+
+// GetProductOdds mocks base method.
+func (m *MockStore) GetProductOdds(id int) (store.ProductOdds, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductOdds", id)
+	ret0, _ := ret[0].(store.ProductOdds)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductOdds indicates an expected call of GetProductOdds.
+func (mr *MockStoreMockRecorder) GetProductOdds(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductOdds", reflect.TypeOf((*MockStore)(nil).GetProductOdds), id)
+}
+
+// GetProductPhoto mocks base method.
+func (m *MockStore) GetProductPhoto(name string) (io.ReadCloser, blobstore.BlobInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductPhoto", name)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(blobstore.BlobInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetProductPhoto indicates an expected call of GetProductPhoto.
+func (mr *MockStoreMockRecorder) GetProductPhoto(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductPhoto", reflect.TypeOf((*MockStore)(nil).GetProductPhoto), name)
+}
+
+// ReorderProducts mocks base method.
+func (m *MockStore) ReorderProducts(inp store.ReorderProductsInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderProducts", inp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReorderProducts indicates an expected call of ReorderProducts.
+func (mr *MockStoreMockRecorder) ReorderProducts(inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderProducts", reflect.TypeOf((*MockStore)(nil).ReorderProducts), inp)
+}
+
+// RotatePackSeed mocks base method.
+func (m *MockStore) RotatePackSeed(profileID uuid.UUID, inp store.RotatePackSeedInput) (store.PackSeedRotation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotatePackSeed", profileID, inp)
+	ret0, _ := ret[0].(store.PackSeedRotation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotatePackSeed indicates an expected call of RotatePackSeed.
+func (mr *MockStoreMockRecorder) RotatePackSeed(profileID, inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotatePackSeed", reflect.TypeOf((*MockStore)(nil).RotatePackSeed), profileID, inp)
+}
+
+// UpdateProduct mocks base method.
+func (m *MockStore) UpdateProduct(id int, inp store.ProductInput) (store.AdminProduct, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProduct", id, inp)
+	ret0, _ := ret[0].(store.AdminProduct)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProduct indicates an expected call of UpdateProduct.
+func (mr *MockStoreMockRecorder) UpdateProduct(id, inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProduct", reflect.TypeOf((*MockStore)(nil).UpdateProduct), id, inp)
+}
+
+// UpdateProductDropTable mocks base method.
+func (m *MockStore) UpdateProductDropTable(id int, table store.DropTable) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProductDropTable", id, table)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProductDropTable indicates an expected call of UpdateProductDropTable.
+func (mr *MockStoreMockRecorder) UpdateProductDropTable(id, table interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProductDropTable", reflect.TypeOf((*MockStore)(nil).UpdateProductDropTable), id, table)
+}
+
+// UploadProductPhoto mocks base method.
+func (m *MockStore) UploadProductPhoto(id int, file io.Reader) (store.AdminProduct, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadProductPhoto", id, file)
+	ret0, _ := ret[0].(store.AdminProduct)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadProductPhoto indicates an expected call of UploadProductPhoto.
+func (mr *MockStoreMockRecorder) UploadProductPhoto(id, file interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadProductPhoto", reflect.TypeOf((*MockStore)(nil).UploadProductPhoto), id, file)
+}
+
+// VerifyPackOpening mocks base method.
+func (m *MockStore) VerifyPackOpening(id int64) (store.PackVerification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyPackOpening", id)
+	ret0, _ := ret[0].(store.PackVerification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyPackOpening indicates an expected call of VerifyPackOpening.
+func (mr *MockStoreMockRecorder) VerifyPackOpening(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyPackOpening", reflect.TypeOf((*MockStore)(nil).VerifyPackOpening), id)
+}
+
+// MockPlayers is a mock of Players interface.
+type MockPlayers struct {
+	ctrl     *gomock.Controller
+	recorder *MockPlayersMockRecorder
+}
+
+// MockPlayersMockRecorder is the mock recorder for MockPlayers.
+type MockPlayersMockRecorder struct {
+	mock *MockPlayers
+}
+
+// NewMockPlayers creates a new mock instance.
+func NewMockPlayers(ctrl *gomock.Controller) *MockPlayers {
+	mock := &MockPlayers{ctrl: ctrl}
+	mock.recorder = &MockPlayersMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPlayers) EXPECT() *MockPlayersMockRecorder {
 	return m.recorder
 }
 
-// BuyProduct mocks base method.
-func (m *MockStore) BuyProduct(buy store.BuyProductModel) error {
+// CardUnpacking mocks base method.
+func (m *MockPlayers) CardUnpacking(id int, userID uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BuyProduct", buy)
+	ret := m.ctrl.Call(m, "CardUnpacking", id, userID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// BuyProduct indicates an expected call of BuyProduct.
-func (mr *MockStoreMockRecorder) BuyProduct(buy interface{}) *gomock.Call {
+// CardUnpacking indicates an expected call of CardUnpacking.
+func (mr *MockPlayersMockRecorder) CardUnpacking(id, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuyProduct", reflect.TypeOf((*MockStore)(nil).BuyProduct), buy)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CardUnpacking", reflect.TypeOf((*MockPlayers)(nil).CardUnpacking), id, userID)
 }
 
-// GetAllProducts mocks base method.
-func (m *MockStore) GetAllProducts() ([]store.Product, error) {
+// CreatePlayers mocks base method.
+func (m *MockPlayers) CreatePlayers(playersData []players.Player) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllProducts")
-	ret0, _ := ret[0].([]store.Product)
+	ret := m.ctrl.Call(m, "CreatePlayers", playersData)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePlayers indicates an expected call of CreatePlayers.
+func (mr *MockPlayersMockRecorder) CreatePlayers(playersData interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePlayers", reflect.TypeOf((*MockPlayers)(nil).CreatePlayers), playersData)
+}
+
+// GetPlayerCards mocks base method.
+func (m *MockPlayers) GetPlayerCards(filter players.PlayerCardsFilter) ([]players.PlayerCardResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlayerCards", filter)
+	ret0, _ := ret[0].([]players.PlayerCardResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllProducts indicates an expected call of GetAllProducts.
-func (mr *MockStoreMockRecorder) GetAllProducts() *gomock.Call {
+// GetPlayerCards indicates an expected call of GetPlayerCards.
+func (mr *MockPlayersMockRecorder) GetPlayerCards(filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllProducts", reflect.TypeOf((*MockStore)(nil).GetAllProducts))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlayerCards", reflect.TypeOf((*MockPlayers)(nil).GetPlayerCards), filter)
+}
+
+// GetPlayers mocks base method.
+func (m *MockPlayers) GetPlayers(playersFilter players.PlayersFilter) ([]players.PlayerResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlayers", playersFilter)
+	ret0, _ := ret[0].([]players.PlayerResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlayers indicates an expected call of GetPlayers.
+func (mr *MockPlayersMockRecorder) GetPlayers(playersFilter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlayers", reflect.TypeOf((*MockPlayers)(nil).GetPlayers), playersFilter)
+}
+
+// GetStatisticByPlayerId mocks base method.
+func (m *MockPlayers) GetStatisticByPlayerId(ctx context.Context, playersId int) ([]players.PlayersStatisticDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatisticByPlayerId", ctx, playersId)
+	ret0, _ := ret[0].([]players.PlayersStatisticDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatisticByPlayerId indicates an expected call of GetStatisticByPlayerId.
+func (mr *MockPlayersMockRecorder) GetStatisticByPlayerId(ctx, playersId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatisticByPlayerId", reflect.TypeOf((*MockPlayers)(nil).GetStatisticByPlayerId), ctx, playersId)
+}
+
+// MockMarketplace is a mock of Marketplace interface.
+type MockMarketplace struct {
+	ctrl     *gomock.Controller
+	recorder *MockMarketplaceMockRecorder
+}
+
+// MockMarketplaceMockRecorder is the mock recorder for MockMarketplace.
+type MockMarketplaceMockRecorder struct {
+	mock *MockMarketplace
+}
+
+// NewMockMarketplace creates a new mock instance.
+func NewMockMarketplace(ctrl *gomock.Controller) *MockMarketplace {
+	mock := &MockMarketplace{ctrl: ctrl}
+	mock.recorder = &MockMarketplaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMarketplace) EXPECT() *MockMarketplaceMockRecorder {
+	return m.recorder
+}
+
+// BuyListing mocks base method.
+func (m *MockMarketplace) BuyListing(buyerID uuid.UUID, id int64) (players.CardListing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BuyListing", buyerID, id)
+	ret0, _ := ret[0].(players.CardListing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BuyListing indicates an expected call of BuyListing.
+func (mr *MockMarketplaceMockRecorder) BuyListing(buyerID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuyListing", reflect.TypeOf((*MockMarketplace)(nil).BuyListing), buyerID, id)
+}
+
+// CancelListing mocks base method.
+func (m *MockMarketplace) CancelListing(sellerID uuid.UUID, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelListing", sellerID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelListing indicates an expected call of CancelListing.
+func (mr *MockMarketplaceMockRecorder) CancelListing(sellerID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelListing", reflect.TypeOf((*MockMarketplace)(nil).CancelListing), sellerID, id)
+}
+
+// CreateListing mocks base method.
+func (m *MockMarketplace) CreateListing(sellerID uuid.UUID, inp players.CreateListingInput) (players.CardListing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateListing", sellerID, inp)
+	ret0, _ := ret[0].(players.CardListing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateListing indicates an expected call of CreateListing.
+func (mr *MockMarketplaceMockRecorder) CreateListing(sellerID, inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateListing", reflect.TypeOf((*MockMarketplace)(nil).CreateListing), sellerID, inp)
+}
+
+// GetListings mocks base method.
+func (m *MockMarketplace) GetListings(filter players.CardListingsFilter) ([]players.CardListingResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListings", filter)
+	ret0, _ := ret[0].([]players.CardListingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListings indicates an expected call of GetListings.
+func (mr *MockMarketplaceMockRecorder) GetListings(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListings", reflect.TypeOf((*MockMarketplace)(nil).GetListings), filter)
+}
+
+// MockTrades is a mock of Trades interface.
+type MockTrades struct {
+	ctrl     *gomock.Controller
+	recorder *MockTradesMockRecorder
+}
+
+// MockTradesMockRecorder is the mock recorder for MockTrades.
+type MockTradesMockRecorder struct {
+	mock *MockTrades
+}
+
+// NewMockTrades creates a new mock instance.
+func NewMockTrades(ctrl *gomock.Controller) *MockTrades {
+	mock := &MockTrades{ctrl: ctrl}
+	mock.recorder = &MockTradesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTrades) EXPECT() *MockTradesMockRecorder {
+	return m.recorder
+}
+
+// AcceptTradeOffer mocks base method.
+func (m *MockTrades) AcceptTradeOffer(recipientID uuid.UUID, id int64) (players.TradeOffer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptTradeOffer", recipientID, id)
+	ret0, _ := ret[0].(players.TradeOffer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptTradeOffer indicates an expected call of AcceptTradeOffer.
+func (mr *MockTradesMockRecorder) AcceptTradeOffer(recipientID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptTradeOffer", reflect.TypeOf((*MockTrades)(nil).AcceptTradeOffer), recipientID, id)
+}
+
+// CounterTradeOffer mocks base method.
+func (m *MockTrades) CounterTradeOffer(recipientID uuid.UUID, id int64, inp players.CounterTradeOfferInput) (players.TradeOffer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CounterTradeOffer", recipientID, id, inp)
+	ret0, _ := ret[0].(players.TradeOffer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CounterTradeOffer indicates an expected call of CounterTradeOffer.
+func (mr *MockTradesMockRecorder) CounterTradeOffer(recipientID, id, inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CounterTradeOffer", reflect.TypeOf((*MockTrades)(nil).CounterTradeOffer), recipientID, id, inp)
+}
+
+// CreateTradeOffer mocks base method.
+func (m *MockTrades) CreateTradeOffer(proposerID uuid.UUID, inp players.TradeOfferInput) (players.TradeOffer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTradeOffer", proposerID, inp)
+	ret0, _ := ret[0].(players.TradeOffer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTradeOffer indicates an expected call of CreateTradeOffer.
+func (mr *MockTradesMockRecorder) CreateTradeOffer(proposerID, inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTradeOffer", reflect.TypeOf((*MockTrades)(nil).CreateTradeOffer), proposerID, inp)
+}
+
+// DeclineTradeOffer mocks base method.
+func (m *MockTrades) DeclineTradeOffer(recipientID uuid.UUID, id int64) (players.TradeOffer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeclineTradeOffer", recipientID, id)
+	ret0, _ := ret[0].(players.TradeOffer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeclineTradeOffer indicates an expected call of DeclineTradeOffer.
+func (mr *MockTradesMockRecorder) DeclineTradeOffer(recipientID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclineTradeOffer", reflect.TypeOf((*MockTrades)(nil).DeclineTradeOffer), recipientID, id)
+}
+
+// ExpireTradeOffers mocks base method.
+func (m *MockTrades) ExpireTradeOffers() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireTradeOffers")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireTradeOffers indicates an expected call of ExpireTradeOffers.
+func (mr *MockTradesMockRecorder) ExpireTradeOffers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireTradeOffers", reflect.TypeOf((*MockTrades)(nil).ExpireTradeOffers))
+}
+
+// GetTradeOffer mocks base method.
+func (m *MockTrades) GetTradeOffer(profileID uuid.UUID, id int64) (players.TradeOffer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTradeOffer", profileID, id)
+	ret0, _ := ret[0].(players.TradeOffer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTradeOffer indicates an expected call of GetTradeOffer.
+func (mr *MockTradesMockRecorder) GetTradeOffer(profileID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTradeOffer", reflect.TypeOf((*MockTrades)(nil).GetTradeOffer), profileID, id)
+}
+
+// GetTradeOffers mocks base method.
+func (m *MockTrades) GetTradeOffers(profileID uuid.UUID, filter players.TradeOffersFilter) ([]players.TradeOffer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTradeOffers", profileID, filter)
+	ret0, _ := ret[0].([]players.TradeOffer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTradeOffers indicates an expected call of GetTradeOffers.
+func (mr *MockTradesMockRecorder) GetTradeOffers(profileID, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTradeOffers", reflect.TypeOf((*MockTrades)(nil).GetTradeOffers), profileID, filter)
+}
+
+// MockCrafting is a mock of Crafting interface.
+type MockCrafting struct {
+	ctrl     *gomock.Controller
+	recorder *MockCraftingMockRecorder
+}
+
+// MockCraftingMockRecorder is the mock recorder for MockCrafting.
+type MockCraftingMockRecorder struct {
+	mock *MockCrafting
+}
+
+// NewMockCrafting creates a new mock instance.
+func NewMockCrafting(ctrl *gomock.Controller) *MockCrafting {
+	mock := &MockCrafting{ctrl: ctrl}
+	mock.recorder = &MockCraftingMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCrafting) EXPECT() *MockCraftingMockRecorder {
+	return m.recorder
+}
+
+// CraftCard mocks base method.
+func (m *MockCrafting) CraftCard(profileID uuid.UUID, inp players.CraftCardInput) (players.CardConversion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CraftCard", profileID, inp)
+	ret0, _ := ret[0].(players.CardConversion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CraftCard indicates an expected call of CraftCard.
+func (mr *MockCraftingMockRecorder) CraftCard(profileID, inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CraftCard", reflect.TypeOf((*MockCrafting)(nil).CraftCard), profileID, inp)
+}
+
+// DismantleCards mocks base method.
+func (m *MockCrafting) DismantleCards(profileID uuid.UUID, inp players.DismantleCardsInput) (players.CardConversion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DismantleCards", profileID, inp)
+	ret0, _ := ret[0].(players.CardConversion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DismantleCards indicates an expected call of DismantleCards.
+func (mr *MockCraftingMockRecorder) DismantleCards(profileID, inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DismantleCards", reflect.TypeOf((*MockCrafting)(nil).DismantleCards), profileID, inp)
+}
+
+// GetCardConversions mocks base method.
+func (m *MockCrafting) GetCardConversions(profileID uuid.UUID, filter players.CardConversionsFilter) ([]players.CardConversion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardConversions", profileID, filter)
+	ret0, _ := ret[0].([]players.CardConversion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardConversions indicates an expected call of GetCardConversions.
+func (mr *MockCraftingMockRecorder) GetCardConversions(profileID, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardConversions", reflect.TypeOf((*MockCrafting)(nil).GetCardConversions), profileID, filter)
+}
+
+// GetCraftingInfo mocks base method.
+func (m *MockCrafting) GetCraftingInfo(profileID uuid.UUID) (players.CraftingInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCraftingInfo", profileID)
+	ret0, _ := ret[0].(players.CraftingInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCraftingInfo indicates an expected call of GetCraftingInfo.
+func (mr *MockCraftingMockRecorder) GetCraftingInfo(profileID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCraftingInfo", reflect.TypeOf((*MockCrafting)(nil).GetCraftingInfo), profileID)
+}
+
+// UpgradeCards mocks base method.
+func (m *MockCrafting) UpgradeCards(profileID uuid.UUID, inp players.UpgradeCardsInput) (players.CardConversion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpgradeCards", profileID, inp)
+	ret0, _ := ret[0].(players.CardConversion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpgradeCards indicates an expected call of UpgradeCards.
+func (mr *MockCraftingMockRecorder) UpgradeCards(profileID, inp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeCards", reflect.TypeOf((*MockCrafting)(nil).UpgradeCards), profileID, inp)
 }
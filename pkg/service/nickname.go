@@ -2,12 +2,13 @@ package service
 
 import (
 	"errors"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/storage"
 	"log"
 	"strings"
 )
 
 var (
-	NicknameTakenError   = errors.New("никнейм уже занят")
+	NicknameTakenError   = storage.NicknameTakenError
 	InvalidNicknameError = errors.New("невалидный никнейм")
 )
 
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/config"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/google/uuid"
+	"log"
+	"net/url"
+	"strings"
+)
+
+var (
+	InvalidUnsubscribeTokenError = errors.New("невалидная ссылка отписки")
+	UnknownCategoryError         = errors.New("неизвестная категория рассылки")
+)
+
+type PreferencesStorage interface {
+	GetNotificationPreferences(profileID uuid.UUID) (user.NotificationPreferences, error)
+	UpdateNotificationPreferences(profileID uuid.UUID, prefs user.NotificationPreferences) error
+	GetEmailByProfileID(profileID uuid.UUID) (string, error)
+}
+
+func NewNotificationService(storage PreferencesStorage, mail *MailService, cfg config.ServiceConfiguration) *NotificationService {
+	return &NotificationService{
+		storage: storage,
+		mail:    mail,
+		cfg:     cfg,
+	}
+}
+
+// NotificationService отправляет необязательные письма с учетом подписок пользователя.
+// Каждое такое письмо содержит подписанную ссылку отписки, работающую без входа в аккаунт
+type NotificationService struct {
+	storage PreferencesStorage
+	mail    *MailService
+	cfg     config.ServiceConfiguration
+}
+
+func (s *NotificationService) GetPreferences(userID uuid.UUID) (user.NotificationPreferences, error) {
+	prefs, err := s.storage.GetNotificationPreferences(userID)
+	if err != nil {
+		log.Println("Service. GetNotificationPreferences:", err)
+		return prefs, err
+	}
+
+	return prefs, nil
+}
+
+func (s *NotificationService) UpdatePreferences(userID uuid.UUID, inp user.NotificationPreferencesInput) error {
+	prefs := user.NotificationPreferences{
+		TournamentReminders: *inp.TournamentReminders,
+		TournamentResults:   *inp.TournamentResults,
+		StoreOffers:         *inp.StoreOffers,
+		SecurityAlerts:      *inp.SecurityAlerts,
+	}
+
+	if inp.TradeOffers != nil {
+		prefs.TradeOffers = *inp.TradeOffers
+	} else {
+		current, err := s.storage.GetNotificationPreferences(userID)
+		if err != nil {
+			log.Println("Service. GetNotificationPreferences:", err)
+			return err
+		}
+		prefs.TradeOffers = current.TradeOffers
+	}
+
+	err := s.storage.UpdateNotificationPreferences(userID, prefs)
+	if err != nil {
+		log.Println("Service. UpdateNotificationPreferences:", err)
+		return err
+	}
+
+	return nil
+}
+
+// Unsubscribe отключает одну категорию по токену из письма
+func (s *NotificationService) Unsubscribe(token string) error {
+	data, err := s.parseUnsubscribeToken(token)
+	if err != nil {
+		return err
+	}
+
+	prefs, err := s.storage.GetNotificationPreferences(data.ProfileID)
+	if err != nil {
+		log.Println("Service. GetNotificationPreferences:", err)
+		return err
+	}
+
+	switch data.Category {
+	case user.TournamentRemindersCategory:
+		prefs.TournamentReminders = false
+	case user.TournamentResultsCategory:
+		prefs.TournamentResults = false
+	case user.StoreOffersCategory:
+		prefs.StoreOffers = false
+	case user.SecurityAlertsCategory:
+		prefs.SecurityAlerts = false
+	case user.TradeOffersCategory:
+		prefs.TradeOffers = false
+	}
+
+	err = s.storage.UpdateNotificationPreferences(data.ProfileID, prefs)
+	if err != nil {
+		log.Println("Service. UpdateNotificationPreferences:", err)
+		return err
+	}
+
+	return nil
+}
+
+// Notify ставит письмо в очередь, если пользователь подписан на категорию. В шаблон передается
+// UnsubscribeLink, а в заголовки - List-Unsubscribe для отписки в один клик (RFC 8058)
+func (s *NotificationService) Notify(userID uuid.UUID, category string, templateName string, data map[string]interface{}) error {
+	prefs, err := s.storage.GetNotificationPreferences(userID)
+	if err != nil {
+		log.Println("Service. GetNotificationPreferences:", err)
+		return err
+	}
+	if !prefs.Enabled(category) {
+		return nil
+	}
+
+	email, err := s.storage.GetEmailByProfileID(userID)
+	if err != nil {
+		log.Println("Service. GetEmailByProfileID:", err)
+		return err
+	}
+
+	link := s.unsubscribeLink(userID, category)
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	data["UnsubscribeLink"] = link
+
+	return s.mail.EnqueueWithHeaders(email, templateName, data, map[string]string{
+		"List-Unsubscribe":      "<" + link + ">",
+		"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+	})
+}
+
+func (s *NotificationService) unsubscribeLink(userID uuid.UUID, category string) string {
+	return s.cfg.Email.UnsubscribeURL + "?token=" + url.QueryEscape(s.unsubscribeToken(userID, category))
+}
+
+// Токен - base64url("profileID:category") и HMAC-SHA256 от него через точку. Срока действия у токена нет,
+// ссылка в старом письме должна продолжать работать
+func (s *NotificationService) unsubscribeToken(userID uuid.UUID, category string) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(userID.String() + ":" + category))
+	return payload + "." + base64.RawURLEncoding.EncodeToString(s.sign(payload))
+}
+
+func (s *NotificationService) parseUnsubscribeToken(token string) (user.UnsubscribeToken, error) {
+	var data user.UnsubscribeToken
+
+	payload, signature, found := strings.Cut(token, ".")
+	if !found {
+		return data, InvalidUnsubscribeTokenError
+	}
+
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, s.sign(payload)) {
+		return data, InvalidUnsubscribeTokenError
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return data, InvalidUnsubscribeTokenError
+	}
+
+	id, category, found := strings.Cut(string(raw), ":")
+	if !found {
+		return data, InvalidUnsubscribeTokenError
+	}
+	data.ProfileID, err = uuid.Parse(id)
+	if err != nil {
+		return data, InvalidUnsubscribeTokenError
+	}
+
+	data.Category = category
+	if !isNotificationCategory(category) {
+		return data, UnknownCategoryError
+	}
+
+	return data, nil
+}
+
+func (s *NotificationService) sign(payload string) []byte {
+	mac := hmac.New(sha256.New, []byte(s.cfg.Email.UnsubscribeSecret))
+	mac.Write([]byte("unsubscribe:" + payload))
+	return mac.Sum(nil)
+}
+
+func isNotificationCategory(category string) bool {
+	for _, c := range user.NotificationCategories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
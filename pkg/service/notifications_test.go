@@ -0,0 +1,56 @@
+package service
+
+import (
+	"github.com/Frozen-Fantasy/fantasy-backend.git/config"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNotificationService_unsubscribeToken(t *testing.T) {
+	var cfg config.ServiceConfiguration
+	cfg.Email.UnsubscribeSecret = "secret"
+	s := NewNotificationService(nil, nil, cfg)
+
+	userID := uuid.MustParse("6bc57ea9-c881-47d3-a293-b925ff1ddf72")
+	token := s.unsubscribeToken(userID, user.StoreOffersCategory)
+
+	data, err := s.parseUnsubscribeToken(token)
+	assert.NoError(t, err)
+	assert.Equal(t, user.UnsubscribeToken{ProfileID: userID, Category: user.StoreOffersCategory}, data)
+
+	testTable := []struct {
+		name          string
+		token         string
+		expectedError error
+	}{
+		{
+			name:          "Tampered payload",
+			token:         s.unsubscribeToken(userID, user.StoreOffersCategory)[1:],
+			expectedError: InvalidUnsubscribeTokenError,
+		},
+		{
+			name:          "Foreign secret",
+			token:         NewNotificationService(nil, nil, config.ServiceConfiguration{}).unsubscribeToken(userID, user.StoreOffersCategory),
+			expectedError: InvalidUnsubscribeTokenError,
+		},
+		{
+			name:          "Unknown category",
+			token:         s.unsubscribeToken(userID, "unknown"),
+			expectedError: UnknownCategoryError,
+		},
+		{
+			name:          "Malformed",
+			token:         "abc",
+			expectedError: InvalidUnsubscribeTokenError,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := s.parseUnsubscribeToken(testCase.token)
+			assert.Equal(t, testCase.expectedError, err)
+		})
+	}
+}
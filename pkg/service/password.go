@@ -1,14 +1,11 @@
 package service
 
 import (
-	"crypto/rand"
-	"crypto/sha1"
-	"encoding/base64"
 	"errors"
-	"fmt"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/mailer"
 	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
-	"gopkg.in/gomail.v2"
 	"log"
+	"net/url"
 	"strings"
 	"unicode"
 )
@@ -19,46 +16,6 @@ var (
 	PasswordValidationError = errors.New("невалидный пароль")
 )
 
-type SHA1Hasher struct {
-	salt string
-}
-
-func NewSHA1Hasher(salt string) *SHA1Hasher {
-	return &SHA1Hasher{salt: salt}
-}
-
-func (h *SHA1Hasher) Hash(password string) (string, error) {
-	hash := sha1.New()
-
-	if _, err := hash.Write([]byte(password)); err != nil {
-		return "", err
-	}
-
-	return fmt.Sprintf("%x", hash.Sum([]byte(h.salt))), nil
-}
-
-func ComparePasswords(currentPassword string, passwordInput string, passwordSalt string) error {
-	hasher := NewSHA1Hasher(passwordSalt)
-	inputPasswordHash, err := hasher.Hash(passwordInput)
-	if err != nil {
-		return err
-	}
-	if inputPasswordHash != currentPassword {
-		return IncorrectPasswordError
-	}
-
-	return nil
-}
-
-func GenerateSalt() (string, error) {
-	salt := make([]byte, 16)
-	_, err := rand.Read(salt)
-	if err != nil {
-		return "", err
-	}
-	return base64.URLEncoding.EncodeToString(salt), nil
-}
-
 func ValidatePassword(password string) error {
 	var hasLower, hasUpper, hasDigit bool
 	charset := "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789!@#$%*?"
@@ -103,19 +60,12 @@ func (s *UserService) ChangePassword(inp user.ChangePasswordModel) error {
 		return err
 	}
 
-	salt, err := GenerateSalt()
-	if err != nil {
-		log.Println("Service. GenerateSalt:", err)
-		return err
-	}
-
-	hasher := NewSHA1Hasher(salt)
-	inp.NewPassword, err = hasher.Hash(inp.NewPassword)
+	inp.NewPassword, err = s.hasher.Hash(inp.NewPassword)
 	if err != nil {
 		log.Println("Service. Hash:", err)
 		return err
 	}
-	inp.PasswordSalt = salt
+	inp.PasswordSalt = ""
 
 	err = s.storage.ChangePassword(inp)
 	if err != nil {
@@ -138,23 +88,23 @@ func (s *UserService) ForgotPassword(email string) error {
 		return UserDoesNotExistError
 	}
 
-	domain := "localhost:8000"
+	err = s.limiter.Allow("forgot_password_"+email, s.cfg.RateLimits.ForgotPassword)
+	if err != nil {
+		return err
+	}
+
 	resetHash, err := s.rStorage.CreateResetPasswordHash(email)
 	if err != nil {
 		log.Println("Service. CreateResetPasswordHash:", err)
 		return err
 	}
 
-	m := gomail.NewMessage()
-	m.SetHeader("From", "frozen-fantasy@mail.ru")
-	m.SetHeader("To", email)
-	m.SetHeader("Subject", "Password reset")
-	m.SetBody("text/html", fmt.Sprintf("<p>Hi,</p>\n<p>You have sent a password reset request to Frozen Fantasy. Follow the instructions.</p>\n<p>Follow the link below and fill out the password recovery form:</p>\n<p><a href=\"http://%s/reset-password?id=%s\">http://%s/reset-password?id=%s</a></p>\n<p>You have <strong>1 hour</strong> to complete your password reset</p>\n<p>Thanks! &ndash; Frozen-Fantasy team</p>", domain, resetHash, domain, resetHash))
-
-	d := gomail.NewDialer("smtp.mail.ru", 465, s.cfg.Email.Login, s.cfg.Email.Password)
-
-	if err = d.DialAndSend(m); err != nil {
-		log.Println("Service. DialAndSend:", err)
+	err = s.mail.Enqueue(email, mailer.ResetPasswordTemplate, map[string]interface{}{
+		"Link":     strings.TrimRight(s.cfg.Email.BaseURL, "/") + "/reset-password?id=" + url.QueryEscape(resetHash),
+		"TTLHours": 1,
+	})
+	if err != nil {
+		log.Println("Service. Enqueue:", err)
 		return err
 	}
 
@@ -182,19 +132,11 @@ func (s *UserService) ResetPassword(inp user.ResetPasswordInput) error {
 
 	var changePasswordData = user.ChangePasswordModel{ProfileID: userID}
 
-	salt, err := GenerateSalt()
-	if err != nil {
-		log.Println("Service. GenerateSalt:", err)
-		return err
-	}
-
-	hasher := NewSHA1Hasher(salt)
-	changePasswordData.NewPassword, err = hasher.Hash(inp.NewPassword)
+	changePasswordData.NewPassword, err = s.hasher.Hash(inp.NewPassword)
 	if err != nil {
 		log.Println("Service. Hash:", err)
 		return err
 	}
-	changePasswordData.PasswordSalt = salt
 
 	err = s.storage.ChangePassword(changePasswordData)
 	if err != nil {
@@ -204,3 +146,19 @@ func (s *UserService) ResetPassword(inp user.ResetPasswordInput) error {
 
 	return nil
 }
+
+func (s *UserService) rehashPasswordIfNeeded(userData user.UserDataModel, password string) error {
+	if !s.hasher.NeedsRehash(userData.PasswordEncoded) {
+		return nil
+	}
+
+	passwordHash, err := s.hasher.Hash(password)
+	if err != nil {
+		return err
+	}
+
+	return s.storage.UpdatePasswordHash(user.ChangePasswordModel{
+		ProfileID:   userData.ProfileID,
+		NewPassword: passwordHash,
+	})
+}
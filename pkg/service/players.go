@@ -28,7 +28,6 @@ type PlayersStorage interface {
 	GetPlayerCards(filter players.PlayerCardsFilter) ([]players.PlayerCardResponse, error)
 	AddPlayerCards(tx *sqlx.Tx, buy store.BuyProductModel) error
 	CardUnpacking(id int, userID uuid.UUID) error
-	InsertPlayerCards(tx *sqlx.Tx, buy store.BuyProductModel, selectedPlayerIDs []int) error
 	GetPlayerStatistics(ctx context.Context, playerID int) ([]players.PlayersStatisticDB, error)
 }
 
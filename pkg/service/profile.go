@@ -1,11 +1,43 @@
 package service
 
 import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/blobstore"
 	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
 	"github.com/google/uuid"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"io"
 	"log"
+	"net/http"
+	"strings"
+	"time"
 )
 
+var (
+	NicknameChangeCooldownError = errors.New("никнейм недавно менялся, повторите позже")
+	AvatarTooLargeError         = errors.New("файл аватара слишком большой")
+	InvalidAvatarTypeError      = errors.New("аватар должен быть изображением JPEG, PNG или GIF")
+	InvalidAvatarError          = errors.New("не удалось прочитать изображение аватара")
+)
+
+const (
+	avatarMaxDimension = 4096
+	avatarKeyPrefix    = "avatars/"
+)
+
+var avatarContentTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
 func (s *UserService) GetUserInfo(userID uuid.UUID) (user.UserInfoModel, error) {
 	userInfo, err := s.storage.GetUserInfo(userID)
 	if err != nil {
@@ -41,13 +73,165 @@ func (s *UserService) CheckUserDataExists(inp user.UserExistsDataInput) error {
 	return UserDoesNotExistError
 }
 
-func (s *UserService) DeleteProfile(userID uuid.UUID) error {
+func (s *UserService) UpdateProfile(userID uuid.UUID, inp user.UpdateProfileInput) error {
+	err := ValidateNickname(inp.Nickname)
+	if err != nil {
+		log.Println("Service. ValidateNickname:", err)
+		return err
+	}
 
-	err := s.storage.DeleteProfile(userID)
+	userInfo, err := s.storage.GetUserInfo(userID)
 	if err != nil {
-		log.Println("Service. DeleteProfile:", err)
+		log.Println("Service. GetUserInfo:", err)
+		return err
+	}
+	if userInfo.Nickname == inp.Nickname {
+		return nil
+	}
+
+	changedAt, err := s.storage.GetNicknameChangedAt(userID)
+	if err != nil {
+		log.Println("Service. GetNicknameChangedAt:", err)
+		return err
+	}
+	cooldown := time.Duration(s.cfg.Profile.NicknameChangeCooldown) * time.Hour
+	if changedAt != nil && time.Since(*changedAt) < cooldown {
+		return NicknameChangeCooldownError
+	}
+
+	// Смена регистра своего же никнейма не считается занятым никнеймом
+	if !strings.EqualFold(userInfo.Nickname, inp.Nickname) {
+		exists, err := s.storage.CheckNicknameExists(inp.Nickname)
+		if err != nil {
+			log.Println("Service. CheckNicknameExists:", err)
+			return err
+		}
+		if exists {
+			return NicknameTakenError
+		}
+	}
+
+	err = s.storage.UpdateNickname(userID, inp.Nickname)
+	if err != nil {
+		log.Println("Service. UpdateNickname:", err)
 		return err
 	}
 
 	return nil
 }
+
+// UploadAvatar проверяет размер и тип файла, масштабирует изображение до размеров из конфига
+// и сохраняет их в BlobStore. Основной photo_link указывает на первый размер
+func (s *UserService) UploadAvatar(userID uuid.UUID, file io.Reader) (user.Avatar, error) {
+	var avatar user.Avatar
+
+	data, err := io.ReadAll(io.LimitReader(file, s.cfg.Profile.AvatarMaxSize+1))
+	if err != nil {
+		log.Println("Service. ReadAvatar:", err)
+		return avatar, err
+	}
+	if int64(len(data)) > s.cfg.Profile.AvatarMaxSize {
+		return avatar, AvatarTooLargeError
+	}
+	if !avatarContentTypes[http.DetectContentType(data)] {
+		return avatar, InvalidAvatarTypeError
+	}
+
+	imgConfig, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return avatar, InvalidAvatarError
+	}
+	if imgConfig.Width > avatarMaxDimension || imgConfig.Height > avatarMaxDimension {
+		return avatar, InvalidAvatarError
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return avatar, InvalidAvatarError
+	}
+
+	userInfo, err := s.storage.GetUserInfo(userID)
+	if err != nil {
+		log.Println("Service. GetUserInfo:", err)
+		return avatar, err
+	}
+
+	avatarID, err := newAvatarID()
+	if err != nil {
+		log.Println("Service. NewAvatarID:", err)
+		return avatar, err
+	}
+
+	ctx := context.Background()
+	for _, size := range s.cfg.Profile.AvatarSizes {
+		var buf bytes.Buffer
+		if err = png.Encode(&buf, resizeSquare(img, size)); err != nil {
+			log.Println("Service. EncodeAvatar:", err)
+			return avatar, err
+		}
+
+		key := avatarKey(avatarID, size)
+		if err = s.blobs.Put(ctx, key, "image/png", &buf); err != nil {
+			log.Println("Service. PutAvatar:", err)
+			return avatar, err
+		}
+		avatar.Sizes = append(avatar.Sizes, user.AvatarSize{Size: size, URL: s.blobs.URL(key)})
+	}
+	if len(avatar.Sizes) == 0 {
+		return avatar, InvalidAvatarError
+	}
+	avatar.PhotoLink = avatar.Sizes[0].URL
+
+	err = s.storage.UpdatePhotoLink(userID, avatar.PhotoLink)
+	if err != nil {
+		log.Println("Service. UpdatePhotoLink:", err)
+		return avatar, err
+	}
+
+	s.deleteAvatar(userInfo.PhotoLink)
+
+	return avatar, nil
+}
+
+func (s *UserService) GetAvatar(name string) (io.ReadCloser, blobstore.BlobInfo, error) {
+	file, info, err := s.blobs.Get(context.Background(), avatarKeyPrefix+name)
+	if err != nil {
+		log.Println("Service. GetAvatar:", err)
+		return nil, info, err
+	}
+
+	return file, info, nil
+}
+
+// deleteAvatar удаляет все размеры предыдущего загруженного аватара. Ссылки на внешние картинки не трогаются
+func (s *UserService) deleteAvatar(photoLink string) {
+	prefix := s.blobs.URL(avatarKeyPrefix)
+	if !strings.HasPrefix(photoLink, prefix) {
+		return
+	}
+
+	name := strings.TrimPrefix(photoLink, prefix)
+	i := strings.LastIndex(name, "_")
+	if i <= 0 {
+		return
+	}
+
+	for _, size := range s.cfg.Profile.AvatarSizes {
+		if err := s.blobs.Delete(context.Background(), avatarKey(name[:i], size)); err != nil {
+			log.Println("Service. DeleteAvatar:", err)
+		}
+	}
+}
+
+func newAvatarID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%x", b), nil
+}
+
+func avatarKey(avatarID string, size int) string {
+	return fmt.Sprintf("%s%s_%d.png", avatarKeyPrefix, avatarID, size)
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"fmt"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/config"
+	"log"
+	"time"
+)
+
+type RateLimitStorage interface {
+	IncrementCounter(key string, window time.Duration) (int64, time.Duration, error)
+	GetCounter(key string) (int64, time.Duration, error)
+	DeleteCounter(key string) error
+	SetCooldown(key string, ttl time.Duration) (bool, time.Duration, error)
+}
+
+type RateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("слишком много запросов, повторите через %d сек.", e.RetrySeconds())
+}
+
+// RetrySeconds округляет время ожидания вверх до целых секунд для заголовка Retry-After
+func (e *RateLimitError) RetrySeconds() int {
+	seconds := int((e.RetryAfter + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		return 1
+	}
+	return seconds
+}
+
+func NewRateLimitService(storage RateLimitStorage) *RateLimitService {
+	return &RateLimitService{storage: storage}
+}
+
+type RateLimitService struct {
+	storage RateLimitStorage
+}
+
+// Allow учитывает запрос и возвращает RateLimitError, если лимит в текущем окне исчерпан.
+// Нулевой лимит означает отсутствие ограничений
+func (s *RateLimitService) Allow(key string, limit config.RateLimit) error {
+	if limit.Limit <= 0 {
+		return nil
+	}
+
+	count, ttl, err := s.storage.IncrementCounter(key, time.Duration(limit.Window)*time.Second)
+	if err != nil {
+		log.Println("Service. IncrementCounter:", err)
+		return err
+	}
+
+	if count > int64(limit.Limit) {
+		return &RateLimitError{RetryAfter: ttl}
+	}
+
+	return nil
+}
+
+// CheckLocked возвращает RateLimitError, если по ключу уже накоплено limit неудачных попыток
+func (s *RateLimitService) CheckLocked(key string, limit config.RateLimit) error {
+	if limit.Limit <= 0 {
+		return nil
+	}
+
+	count, ttl, err := s.storage.GetCounter(key)
+	if err != nil {
+		log.Println("Service. GetCounter:", err)
+		return err
+	}
+
+	if count >= int64(limit.Limit) {
+		return &RateLimitError{RetryAfter: ttl}
+	}
+
+	return nil
+}
+
+func (s *RateLimitService) RegisterFailure(key string, limit config.RateLimit) error {
+	if limit.Limit <= 0 {
+		return nil
+	}
+
+	_, _, err := s.storage.IncrementCounter(key, time.Duration(limit.Window)*time.Second)
+	if err != nil {
+		log.Println("Service. IncrementCounter:", err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *RateLimitService) Reset(key string) error {
+	err := s.storage.DeleteCounter(key)
+	if err != nil {
+		log.Println("Service. DeleteCounter:", err)
+		return err
+	}
+
+	return nil
+}
+
+// Cooldown разрешает действие не чаще одного раза в seconds секунд
+func (s *RateLimitService) Cooldown(key string, seconds int) error {
+	if seconds <= 0 {
+		return nil
+	}
+
+	ok, ttl, err := s.storage.SetCooldown(key, time.Duration(seconds)*time.Second)
+	if err != nil {
+		log.Println("Service. SetCooldown:", err)
+		return err
+	}
+
+	if !ok {
+		return &RateLimitError{RetryAfter: ttl}
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"log"
+)
+
+const reconciliationRunsLimit = 30
+
+type ReconciliationStorage interface {
+	ReconcileBalances() (user.ReconciliationRun, error)
+	GetReconciliationRuns(limit int) ([]user.ReconciliationRun, error)
+	GetReconciliationReport(runID int64) (user.ReconciliationReport, error)
+}
+
+func NewReconciliationService(storage ReconciliationStorage) *ReconciliationService {
+	return &ReconciliationService{storage: storage}
+}
+
+// ReconciliationService сверяет кэшированные балансы пользователей с главной книгой
+type ReconciliationService struct {
+	storage ReconciliationStorage
+}
+
+func (s *ReconciliationService) ReconcileBalances() (user.ReconciliationRun, error) {
+	run, err := s.storage.ReconcileBalances()
+	if err != nil {
+		log.Println("Service. ReconcileBalances:", err)
+		return run, err
+	}
+
+	if run.Discrepancies > 0 {
+		log.Printf("Service. ReconcileBalances: run %d found %d discrepancies", run.ID, run.Discrepancies)
+	}
+
+	return run, nil
+}
+
+// GetReconciliationRuns возвращает последние запуски сверки, от новых к старым
+func (s *ReconciliationService) GetReconciliationRuns() ([]user.ReconciliationRun, error) {
+	runs, err := s.storage.GetReconciliationRuns(reconciliationRunsLimit)
+	if err != nil {
+		log.Println("Service. GetReconciliationRuns:", err)
+		return runs, err
+	}
+
+	return runs, nil
+}
+
+func (s *ReconciliationService) GetReconciliationReport(runID int64) (user.ReconciliationReport, error) {
+	report, err := s.storage.GetReconciliationReport(runID)
+	if err != nil {
+		log.Println("Service. GetReconciliationReport:", err)
+		return report, err
+	}
+
+	return report, nil
+}
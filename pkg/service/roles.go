@@ -0,0 +1,28 @@
+package service
+
+import (
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"log"
+)
+
+// Роли попадают в access токен при его создании, поэтому изменения
+// вступают в силу после обновления токенов пользователем
+func (s *UserService) GrantRole(inp user.RoleInput) error {
+	err := s.storage.AddProfileRole(inp.ProfileID, inp.Role)
+	if err != nil {
+		log.Println("Service. AddProfileRole:", err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *UserService) RevokeRole(inp user.RoleInput) error {
+	err := s.storage.RemoveProfileRole(inp.ProfileID, inp.Role)
+	if err != nil {
+		log.Println("Service. RemoveProfileRole:", err)
+		return err
+	}
+
+	return nil
+}
@@ -3,22 +3,28 @@ package service
 import (
 	"context"
 	"github.com/Frozen-Fantasy/fantasy-backend.git/config"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/blobstore"
 	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/players"
 	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/store"
 	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/tournaments"
 	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
 	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/storage"
 	"github.com/google/uuid"
+	"io"
 )
 
 //go:generate mockgen -source=service.go -destination=mocks/mock.go
 
 type User interface {
 	SignUp(input user.SignUpInput) error
-	SignIn(input user.SignInInput) (user.Tokens, error)
-	RefreshTokens(refreshTokenID string) (user.Tokens, error)
-	CreateSession(userID uuid.UUID) (user.Tokens, error)
+	SignIn(input user.SignInInput, device user.DeviceInfo) (user.Tokens, error)
+	RefreshTokens(refreshTokenID string, device user.DeviceInfo) (user.Tokens, error)
+	CreateSession(userID uuid.UUID, device user.DeviceInfo) (user.Tokens, error)
 	Logout(refreshTokenID string) error
+	GetSessions(userID uuid.UUID) ([]user.SessionInfo, error)
+	RevokeSession(userID uuid.UUID, sessionID uuid.UUID) error
+	RevokeOtherSessions(userID uuid.UUID, refreshTokenID string) error
+	RevokeAllSessions(userID uuid.UUID) error
 	SendVerificationCode(email string) error
 	CheckEmailVerification(email string, inputCode int) error
 	CheckEmailExists(email string) (bool, error)
@@ -29,13 +35,62 @@ type User interface {
 	GetUserInfo(userID uuid.UUID) (user.UserInfoModel, error)
 	CheckUserDataExists(inp user.UserExistsDataInput) error
 	DeleteProfile(userID uuid.UUID) error
-	GetCoinTransactions(profileID uuid.UUID) ([]user.CoinTransactionsModel, error)
+	CheckProfileActive(userID uuid.UUID) error
+	PurgeDeletedProfiles(ctx context.Context) (int, error)
+	GetCoinTransactions(profileID uuid.UUID, filter user.CoinTransactionsFilter) (user.CoinTransactionsPage, error)
+	WriteCoinTransactionsCSV(profileID uuid.UUID, filter user.CoinTransactionsFilter, w io.Writer) error
+	GrantRole(inp user.RoleInput) error
+	RevokeRole(inp user.RoleInput) error
+	GrantCoins(adminID uuid.UUID, inp user.CoinAdjustmentInput) (user.CoinAdjustment, error)
+	DeductCoins(adminID uuid.UUID, inp user.CoinAdjustmentInput) (user.CoinAdjustment, error)
+	GetCoinAdjustments(profileID uuid.UUID) ([]user.CoinAdjustment, error)
+	EnrollTwoFactor(userID uuid.UUID) (user.TwoFactorEnrollment, error)
+	ConfirmTwoFactor(userID uuid.UUID, code string) (user.RecoveryCodes, error)
+	DisableTwoFactor(userID uuid.UUID, code string) error
+	RegenerateRecoveryCodes(userID uuid.UUID, code string) (user.RecoveryCodes, error)
+	VerifyTwoFactor(inp user.TwoFactorVerifyInput, device user.DeviceInfo) (user.Tokens, error)
+	UpdateProfile(userID uuid.UUID, inp user.UpdateProfileInput) error
+	UploadAvatar(userID uuid.UUID, file io.Reader) (user.Avatar, error)
+	GetAvatar(name string) (io.ReadCloser, blobstore.BlobInfo, error)
+	RequestEmailChange(userID uuid.UUID, inp user.ChangeEmailInput) error
+	ConfirmEmailChange(userID uuid.UUID, code int) error
+	UndoEmailChange(token string) error
+}
+
+type Notifications interface {
+	GetPreferences(userID uuid.UUID) (user.NotificationPreferences, error)
+	UpdatePreferences(userID uuid.UUID, inp user.NotificationPreferencesInput) error
+	Unsubscribe(token string) error
+}
+
+type Exports interface {
+	RequestDataExport(userID uuid.UUID) (user.DataExport, error)
+	GetDataExport(userID uuid.UUID, exportID uuid.UUID) (user.DataExport, error)
+	OpenDataExport(inp user.DataExportDownloadInput) (io.ReadCloser, blobstore.BlobInfo, error)
+}
+
+type Reconciliation interface {
+	ReconcileBalances() (user.ReconciliationRun, error)
+	GetReconciliationRuns() ([]user.ReconciliationRun, error)
+	GetReconciliationReport(runID int64) (user.ReconciliationReport, error)
 }
 
 type TokenManager interface {
-	CreateJWT(userID string) (int64, string, error)
-	ParseJWT(accessToken string) (string, error)
+	CreateJWT(userID string, roles []string) (int64, string, error)
+	ParseJWT(accessToken string) (user.AccessTokenClaims, error)
 	CreateRefreshToken() (string, error)
+	JWKS() JWKSet
+}
+
+type RateLimiter interface {
+	Allow(key string, limit config.RateLimit) error
+}
+
+type Idempotency interface {
+	Begin(userID uuid.UUID, key string, fingerprint string) (*user.IdempotentRequest, error)
+	Hold(userID uuid.UUID, key string, fingerprint string) (stop func())
+	Complete(userID uuid.UUID, key string, resp user.IdempotentRequest) error
+	Release(userID uuid.UUID, key string) error
 }
 
 type Teams interface {
@@ -61,6 +116,18 @@ type Tournaments interface {
 type Store interface {
 	GetAllProducts() ([]store.Product, error)
 	BuyProduct(buy store.BuyProductModel) error
+	GetAdminProducts(filter store.AdminProductsFilter) ([]store.AdminProduct, error)
+	CreateProduct(inp store.ProductInput) (store.AdminProduct, error)
+	UpdateProduct(id int, inp store.ProductInput) (store.AdminProduct, error)
+	ArchiveProduct(id int) error
+	ReorderProducts(inp store.ReorderProductsInput) error
+	UploadProductPhoto(id int, file io.Reader) (store.AdminProduct, error)
+	GetProductPhoto(name string) (io.ReadCloser, blobstore.BlobInfo, error)
+	GetProductOdds(id int) (store.ProductOdds, error)
+	UpdateProductDropTable(id int, table store.DropTable) error
+	GetPackSeed(profileID uuid.UUID) (store.PackSeed, error)
+	RotatePackSeed(profileID uuid.UUID, inp store.RotatePackSeedInput) (store.PackSeedRotation, error)
+	VerifyPackOpening(id int64) (store.PackVerification, error)
 }
 
 type Players interface {
@@ -71,34 +138,94 @@ type Players interface {
 	GetStatisticByPlayerId(ctx context.Context, playersId int) ([]players.PlayersStatisticDB, error)
 }
 
+type Marketplace interface {
+	CreateListing(sellerID uuid.UUID, inp players.CreateListingInput) (players.CardListing, error)
+	CancelListing(sellerID uuid.UUID, id int64) error
+	GetListings(filter players.CardListingsFilter) ([]players.CardListingResponse, error)
+	BuyListing(buyerID uuid.UUID, id int64) (players.CardListing, error)
+}
+
+type Trades interface {
+	CreateTradeOffer(proposerID uuid.UUID, inp players.TradeOfferInput) (players.TradeOffer, error)
+	CounterTradeOffer(recipientID uuid.UUID, id int64, inp players.CounterTradeOfferInput) (players.TradeOffer, error)
+	AcceptTradeOffer(recipientID uuid.UUID, id int64) (players.TradeOffer, error)
+	DeclineTradeOffer(recipientID uuid.UUID, id int64) (players.TradeOffer, error)
+	GetTradeOffers(profileID uuid.UUID, filter players.TradeOffersFilter) ([]players.TradeOffer, error)
+	GetTradeOffer(profileID uuid.UUID, id int64) (players.TradeOffer, error)
+	ExpireTradeOffers() (int, error)
+}
+
+type Crafting interface {
+	GetCraftingInfo(profileID uuid.UUID) (players.CraftingInfo, error)
+	DismantleCards(profileID uuid.UUID, inp players.DismantleCardsInput) (players.CardConversion, error)
+	UpgradeCards(profileID uuid.UUID, inp players.UpgradeCardsInput) (players.CardConversion, error)
+	CraftCard(profileID uuid.UUID, inp players.CraftCardInput) (players.CardConversion, error)
+	GetCardConversions(profileID uuid.UUID, filter players.CardConversionsFilter) ([]players.CardConversion, error)
+}
+
 type Services struct {
 	User
+	Notifications
+	Exports
+	Reconciliation
 	TokenManager
+	RateLimiter
+	Idempotency
 	Teams
 	Tournaments
 	Store
 	Players
+	Marketplace
+	Trades
+	Crafting
 }
 
+// Deps - зависимости сервисов. Сервисы, с которыми работают и фоновые задачи, создаются один раз
+// снаружи и передаются готовыми, чтобы у API и задач было общее состояние
 type Deps struct {
 	Cfg      config.ServiceConfiguration
 	Storage  *storage.PostgresStorage
 	RStorage *storage.RedisStorage
 	Jwt      *Manager
+	Mail     *MailService
+	Blobs    blobstore.BlobStore
+	Exports  *ExportService
+	Limiter  *RateLimitService
+	Users    *UserService
+	Balances *ReconciliationService
+	Notifier *NotificationService
+	Trades   *TradeService
 }
 
 func NewServices(deps Deps) *Services {
-	userService := NewUserService(deps.Storage, deps.RStorage, deps.Jwt, deps.Cfg)
+	rateLimitService := deps.Limiter
+	idempotencyService := NewIdempotencyService(deps.RStorage, deps.Cfg)
+	blobStore := deps.Blobs
+	notificationService := deps.Notifier
+	exportService := deps.Exports
+	reconciliationService := deps.Balances
+	userService := deps.Users
 	playersService := NewPlayersService(deps.Storage)
 	tournamentsService := NewTournamentsService(deps.Storage, deps.RStorage, playersService)
-	storeService := NewStoreService(deps.Storage)
+	storeService := NewStoreService(deps.Storage, blobStore, deps.Cfg)
 	teamsService := NewTeamsService(deps.Storage)
+	marketplaceService := NewMarketplaceService(deps.Storage, deps.Cfg)
+	tradeService := deps.Trades
+	craftingService := NewCraftingService(deps.Storage, deps.Cfg)
 	return &Services{
-		User:         userService,
-		TokenManager: deps.Jwt,
-		Teams:        teamsService,
-		Tournaments:  tournamentsService,
-		Store:        storeService,
-		Players:      playersService,
+		User:           userService,
+		Notifications:  notificationService,
+		Exports:        exportService,
+		Reconciliation: reconciliationService,
+		TokenManager:   deps.Jwt,
+		RateLimiter:    rateLimitService,
+		Idempotency:    idempotencyService,
+		Teams:          teamsService,
+		Tournaments:    tournamentsService,
+		Store:          storeService,
+		Players:        playersService,
+		Marketplace:    marketplaceService,
+		Trades:         tradeService,
+		Crafting:       craftingService,
 	}
 }
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/google/uuid"
+	"log"
+	"strings"
+)
+
+func (s *UserService) GetSessions(userID uuid.UUID) ([]user.SessionInfo, error) {
+	sessions, err := s.storage.GetActiveSessionsByProfileID(userID)
+	if err != nil {
+		log.Println("Service. GetActiveSessionsByProfileID:", err)
+		return sessions, err
+	}
+
+	return sessions, nil
+}
+
+func (s *UserService) RevokeSession(userID uuid.UUID, sessionID uuid.UUID) error {
+	err := s.storage.DeleteSessionFamily(userID, sessionID)
+	if err != nil {
+		log.Println("Service. DeleteSessionFamily:", err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *UserService) RevokeOtherSessions(userID uuid.UUID, refreshTokenID string) error {
+	session, err := s.storage.GetSessionByRefreshToken(refreshTokenID)
+	if err != nil {
+		log.Println("Service. GetSessionByRefreshToken:", err)
+		return err
+	}
+	if session.ProfileID != userID || session.RotatedAt != nil {
+		return InvalidRefreshTokenError
+	}
+
+	err = s.storage.DeleteOtherSessions(userID, session.FamilyID)
+	if err != nil {
+		log.Println("Service. DeleteOtherSessions:", err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *UserService) RevokeAllSessions(userID uuid.UUID) error {
+	err := s.storage.RevokeAllSessions(userID)
+	if err != nil {
+		log.Println("Service. RevokeAllSessions:", err)
+		return err
+	}
+
+	return nil
+}
+
+const purgeSessionFamiliesBatch = 500
+
+// PurgeExpiredSessions пачками удаляет семьи сессий с истекшими refresh токенами
+// и возвращает количество удаленных записей
+func (s *UserService) PurgeExpiredSessions(ctx context.Context) (int, error) {
+	purged := 0
+	for {
+		if ctx.Err() != nil {
+			return purged, ctx.Err()
+		}
+
+		deleted, err := s.storage.DeleteExpiredSessionFamilies(purgeSessionFamiliesBatch)
+		if err != nil {
+			log.Println("Service. DeleteExpiredSessionFamilies:", err)
+			return purged, err
+		}
+		purged += deleted
+
+		if deleted == 0 {
+			return purged, nil
+		}
+	}
+}
+
+// deviceLabel формирует читаемое название устройства по User-Agent, если клиент не передал свое
+func deviceLabel(userAgent string) string {
+	ua := strings.ToLower(userAgent)
+
+	var browser, platform string
+	switch {
+	case strings.Contains(ua, "edg/"):
+		browser = "Edge"
+	case strings.Contains(ua, "yabrowser"):
+		browser = "Yandex Browser"
+	case strings.Contains(ua, "opr/"), strings.Contains(ua, "opera"):
+		browser = "Opera"
+	case strings.Contains(ua, "firefox"):
+		browser = "Firefox"
+	case strings.Contains(ua, "chrome"):
+		browser = "Chrome"
+	case strings.Contains(ua, "safari"):
+		browser = "Safari"
+	case strings.Contains(ua, "okhttp"), strings.Contains(ua, "dalvik"):
+		browser = "Android App"
+	case strings.Contains(ua, "cfnetwork"):
+		browser = "iOS App"
+	}
+
+	switch {
+	case strings.Contains(ua, "android"):
+		platform = "Android"
+	case strings.Contains(ua, "iphone"), strings.Contains(ua, "ipad"):
+		platform = "iOS"
+	case strings.Contains(ua, "windows"):
+		platform = "Windows"
+	case strings.Contains(ua, "mac os"):
+		platform = "macOS"
+	case strings.Contains(ua, "linux"):
+		platform = "Linux"
+	}
+
+	switch {
+	case browser != "" && platform != "":
+		return browser + " on " + platform
+	case browser != "":
+		return browser
+	case platform != "":
+		return platform
+	default:
+		return "Unknown device"
+	}
+}
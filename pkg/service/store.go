@@ -1,13 +1,45 @@
 package service
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/config"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/blobstore"
 	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/store"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/tournaments"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/storage"
+	"github.com/google/uuid"
+	"io"
 	"log"
+	"net/http"
+	"strings"
 )
 
-func NewStoreService(storage StoreStorage) *StoreService {
+var (
+	InvalidProductLeagueError      = errors.New("неизвестная лига товара")
+	InvalidProductRarityError      = errors.New("неизвестная редкость товара")
+	InvalidAvailabilityWindowError = errors.New("дата окончания продажи должна быть позже даты начала")
+	ProductPhotoTooLargeError      = errors.New("файл изображения товара слишком большой")
+	InvalidProductPhotoTypeError   = errors.New("изображение товара должно быть в формате JPEG, PNG или GIF")
+	InvalidDropTableError          = errors.New("редкости в таблице выпадения повторяются или не задана стоимость звездного игрока")
+	DropTableTooManySlotsError     = errors.New("гарантии или квоты позиций превышают число карточек в наборе")
+)
+
+const productPhotoKeyPrefix = "products/"
+
+var productPhotoExtensions = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+}
+
+func NewStoreService(storage StoreStorage, blobs blobstore.BlobStore, cfg config.ServiceConfiguration) *StoreService {
 	return &StoreService{
 		storage: storage,
+		blobs:   blobs,
+		cfg:     cfg,
 	}
 }
 
@@ -15,10 +47,24 @@ type StoreStorage interface {
 	GetAllProducts() ([]store.Product, error)
 	GetProductByID(id int) (store.Product, error)
 	BuyProduct(buy store.BuyProductModel) error
+	GetAdminProducts(filter store.AdminProductsFilter) ([]store.AdminProduct, error)
+	GetAdminProductByID(id int) (store.AdminProduct, error)
+	CreateProduct(inp store.ProductInput) (store.AdminProduct, error)
+	UpdateProduct(id int, inp store.ProductInput) (store.AdminProduct, error)
+	ArchiveProduct(id int) error
+	ReorderProducts(ids []int) error
+	UpdateProductPhotoLink(id int, photoLink string) error
+	GetProductDropTable(id int) (store.DropTable, error)
+	UpdateProductDropTable(id int, table store.DropTable) error
+	GetActivePackSeed(profileID uuid.UUID) (store.PackSeed, error)
+	RotatePackSeed(profileID uuid.UUID, clientSeed string) (store.PackSeedRotation, error)
+	VerifyPackOpening(id int64) (store.PackVerification, error)
 }
 
 type StoreService struct {
 	storage StoreStorage
+	blobs   blobstore.BlobStore
+	cfg     config.ServiceConfiguration
 }
 
 func (s *StoreService) GetAllProducts() ([]store.Product, error) {
@@ -45,6 +91,12 @@ func (s *StoreService) BuyProduct(buy store.BuyProductModel) error {
 	buy.Rarity = product.Rarity
 	buy.PlayerCardsCount = product.PlayerCardsCount
 
+	buy.DropTable, err = s.storage.GetProductDropTable(buy.ID)
+	if err != nil {
+		log.Println("Service. GetProductDropTable:", err)
+		return err
+	}
+
 	err = s.storage.BuyProduct(buy)
 	if err != nil {
 		log.Println("Service. BuyProduct:", err)
@@ -53,3 +105,282 @@ func (s *StoreService) BuyProduct(buy store.BuyProductModel) error {
 
 	return nil
 }
+
+// GetProductOdds раскрывает шансы выпадения карточек для товара в продаже
+func (s *StoreService) GetProductOdds(id int) (store.ProductOdds, error) {
+	product, err := s.storage.GetProductByID(id)
+	if err != nil {
+		log.Println("Service. GetProductByID:", err)
+		return store.ProductOdds{}, err
+	}
+
+	table, err := s.storage.GetProductDropTable(id)
+	if err != nil {
+		log.Println("Service. GetProductDropTable:", err)
+		return store.ProductOdds{}, err
+	}
+
+	return table.Odds(product), nil
+}
+
+func (s *StoreService) UpdateProductDropTable(id int, table store.DropTable) error {
+	product, err := s.storage.GetAdminProductByID(id)
+	if err != nil {
+		log.Println("Service. GetAdminProductByID:", err)
+		return err
+	}
+
+	if err = validateDropTable(table, product.PlayerCardsCount); err != nil {
+		return err
+	}
+
+	err = s.storage.UpdateProductDropTable(id, table)
+	if err != nil {
+		log.Println("Service. UpdateProductDropTable:", err)
+		return err
+	}
+
+	return nil
+}
+
+// GetPackSeed отдает хэш сида сервера, которым будут открыты следующие наборы пользователя
+func (s *StoreService) GetPackSeed(profileID uuid.UUID) (store.PackSeed, error) {
+	seed, err := s.storage.GetActivePackSeed(profileID)
+	if err != nil {
+		log.Println("Service. GetActivePackSeed:", err)
+		return seed, err
+	}
+
+	return seed, nil
+}
+
+func (s *StoreService) RotatePackSeed(profileID uuid.UUID, inp store.RotatePackSeedInput) (store.PackSeedRotation, error) {
+	rotation, err := s.storage.RotatePackSeed(profileID, inp.ClientSeed)
+	if err != nil {
+		log.Println("Service. RotatePackSeed:", err)
+		return rotation, err
+	}
+
+	return rotation, nil
+}
+
+func (s *StoreService) VerifyPackOpening(id int64) (store.PackVerification, error) {
+	verification, err := s.storage.VerifyPackOpening(id)
+	if err != nil {
+		log.Println("Service. VerifyPackOpening:", err)
+		return verification, err
+	}
+
+	return verification, nil
+}
+
+func (s *StoreService) GetAdminProducts(filter store.AdminProductsFilter) ([]store.AdminProduct, error) {
+	products, err := s.storage.GetAdminProducts(filter)
+	if err != nil {
+		log.Println("Service. GetAdminProducts:", err)
+		return products, err
+	}
+
+	for i := range products {
+		fillAdminProductNames(&products[i])
+	}
+
+	return products, nil
+}
+
+func (s *StoreService) CreateProduct(inp store.ProductInput) (store.AdminProduct, error) {
+	if err := validateProductInput(inp); err != nil {
+		return store.AdminProduct{}, err
+	}
+
+	product, err := s.storage.CreateProduct(inp)
+	if err != nil {
+		log.Println("Service. CreateProduct:", err)
+		return product, err
+	}
+	fillAdminProductNames(&product)
+
+	return product, nil
+}
+
+func (s *StoreService) UpdateProduct(id int, inp store.ProductInput) (store.AdminProduct, error) {
+	if err := validateProductInput(inp); err != nil {
+		return store.AdminProduct{}, err
+	}
+
+	product, err := s.storage.GetAdminProductByID(id)
+	if err != nil {
+		log.Println("Service. GetAdminProductByID:", err)
+		return product, err
+	}
+
+	// Таблица выпадения должна остаться выполнимой при новом числе карточек в наборе
+	table := store.DefaultDropTable(inp.Rarity)
+	if product.DropTable != nil {
+		table = *product.DropTable
+	}
+	if err = validateDropTable(table, inp.PlayerCardsCount); err != nil {
+		return store.AdminProduct{}, err
+	}
+
+	product, err = s.storage.UpdateProduct(id, inp)
+	if err != nil {
+		log.Println("Service. UpdateProduct:", err)
+		return product, err
+	}
+	fillAdminProductNames(&product)
+
+	return product, nil
+}
+
+func (s *StoreService) ArchiveProduct(id int) error {
+	err := s.storage.ArchiveProduct(id)
+	if err != nil {
+		log.Println("Service. ArchiveProduct:", err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *StoreService) ReorderProducts(inp store.ReorderProductsInput) error {
+	err := s.storage.ReorderProducts(inp.IDs)
+	if err != nil {
+		log.Println("Service. ReorderProducts:", err)
+		return err
+	}
+
+	return nil
+}
+
+// UploadProductPhoto сохраняет картинку товара в BlobStore как есть и меняет photo_link.
+// Предыдущая загруженная картинка удаляется, внешние ссылки не трогаются
+func (s *StoreService) UploadProductPhoto(id int, file io.Reader) (store.AdminProduct, error) {
+	data, err := io.ReadAll(io.LimitReader(file, s.cfg.Store.PhotoMaxSize+1))
+	if err != nil {
+		log.Println("Service. ReadProductPhoto:", err)
+		return store.AdminProduct{}, err
+	}
+	if int64(len(data)) > s.cfg.Store.PhotoMaxSize {
+		return store.AdminProduct{}, ProductPhotoTooLargeError
+	}
+	contentType := http.DetectContentType(data)
+	ext, ok := productPhotoExtensions[contentType]
+	if !ok {
+		return store.AdminProduct{}, InvalidProductPhotoTypeError
+	}
+
+	product, err := s.storage.GetAdminProductByID(id)
+	if err != nil {
+		log.Println("Service. GetAdminProductByID:", err)
+		return product, err
+	}
+	if product.ArchivedAt != nil {
+		return product, storage.IncorrectProductID
+	}
+
+	photoID, err := newAvatarID()
+	if err != nil {
+		log.Println("Service. NewPhotoID:", err)
+		return product, err
+	}
+
+	key := fmt.Sprintf("%s%s.%s", productPhotoKeyPrefix, photoID, ext)
+	if err = s.blobs.Put(context.Background(), key, contentType, bytes.NewReader(data)); err != nil {
+		log.Println("Service. PutProductPhoto:", err)
+		return product, err
+	}
+
+	photoLink := s.blobs.URL(key)
+	err = s.storage.UpdateProductPhotoLink(id, photoLink)
+	if err != nil {
+		log.Println("Service. UpdateProductPhotoLink:", err)
+		return product, err
+	}
+
+	s.deleteProductPhoto(product.PhotoLink)
+	product.PhotoLink = photoLink
+	fillAdminProductNames(&product)
+
+	return product, nil
+}
+
+func (s *StoreService) GetProductPhoto(name string) (io.ReadCloser, blobstore.BlobInfo, error) {
+	file, info, err := s.blobs.Get(context.Background(), productPhotoKeyPrefix+name)
+	if err != nil {
+		log.Println("Service. GetProductPhoto:", err)
+		return nil, info, err
+	}
+
+	return file, info, nil
+}
+
+func (s *StoreService) deleteProductPhoto(photoLink string) {
+	prefix := s.blobs.URL(productPhotoKeyPrefix)
+	if !strings.HasPrefix(photoLink, prefix) {
+		return
+	}
+
+	if err := s.blobs.Delete(context.Background(), productPhotoKeyPrefix+strings.TrimPrefix(photoLink, prefix)); err != nil {
+		log.Println("Service. DeleteProductPhoto:", err)
+	}
+}
+
+func validateProductInput(inp store.ProductInput) error {
+	if _, ok := tournaments.LeagueTitles[inp.League]; !ok {
+		return InvalidProductLeagueError
+	}
+	if !validProductRarity(inp.Rarity) {
+		return InvalidProductRarityError
+	}
+	if inp.AvailableFrom != nil && inp.AvailableUntil != nil && !inp.AvailableUntil.After(*inp.AvailableFrom) {
+		return InvalidAvailabilityWindowError
+	}
+
+	return nil
+}
+
+func validateDropTable(table store.DropTable, cardsCount int) error {
+	weighted := make(map[store.CardRarity]bool)
+	for _, w := range table.RarityWeights {
+		if !validProductRarity(w.Rarity) {
+			return InvalidProductRarityError
+		}
+		if weighted[w.Rarity] {
+			return InvalidDropTableError
+		}
+		weighted[w.Rarity] = true
+	}
+
+	var guaranteed int
+	for _, g := range table.Guarantees {
+		if !validProductRarity(g.Rarity) {
+			return InvalidProductRarityError
+		}
+		guaranteed += g.Count
+	}
+
+	var quoted int
+	for _, q := range table.PositionQuotas {
+		quoted += q.Count
+	}
+
+	if guaranteed > cardsCount || quoted > cardsCount {
+		return DropTableTooManySlotsError
+	}
+	if table.StarChance > 0 && table.StarCost <= 0 {
+		return InvalidDropTableError
+	}
+
+	return nil
+}
+
+func validProductRarity(rarity store.CardRarity) bool {
+	_, ok := store.PlayerCardsRarityTitles[rarity]
+	return ok && rarity != store.ErrCardRarity
+}
+
+func fillAdminProductNames(product *store.AdminProduct) {
+	product.LeagueName = product.League.GetLeagueString()
+	product.RarityName = product.Rarity.GetCardRarityString()
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/store"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/tournaments"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type productStorage struct {
+	StoreStorage
+	product store.AdminProduct
+	updated bool
+}
+
+func (s *productStorage) GetAdminProductByID(id int) (store.AdminProduct, error) {
+	return s.product, nil
+}
+
+func (s *productStorage) UpdateProduct(id int, inp store.ProductInput) (store.AdminProduct, error) {
+	s.updated = true
+	return s.product, nil
+}
+
+func TestStoreService_UpdateProduct_validatesDropTable(t *testing.T) {
+	active := true
+	inp := store.ProductInput{League: tournaments.NHL, Rarity: store.Gold, PlayerCardsCount: 2, Active: &active}
+
+	storage := &productStorage{product: store.AdminProduct{DropTable: &store.DropTable{
+		RarityWeights:  []store.RarityWeight{{Rarity: store.Silver, Weight: 1}},
+		PositionQuotas: []store.PositionQuota{{Position: 3, Count: 3}},
+	}}}
+	s := &StoreService{storage: storage}
+
+	_, err := s.UpdateProduct(1, inp)
+	assert.Equal(t, DropTableTooManySlotsError, err)
+	assert.False(t, storage.updated)
+
+	inp.PlayerCardsCount = 3
+	_, err = s.UpdateProduct(1, inp)
+	assert.NoError(t, err)
+	assert.True(t, storage.updated)
+
+	// Без сохраненной таблицы проверяется таблица по умолчанию для новой редкости
+	storage = &productStorage{}
+	s = &StoreService{storage: storage}
+	_, err = s.UpdateProduct(1, inp)
+	assert.NoError(t, err)
+	assert.True(t, storage.updated)
+}
+
+func TestValidateProductInput(t *testing.T) {
+	from := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	until := from.Add(24 * time.Hour)
+	valid := store.ProductInput{League: tournaments.NHL, Rarity: store.Silver, AvailableFrom: &from, AvailableUntil: &until}
+
+	assert.NoError(t, validateProductInput(valid))
+
+	inp := valid
+	inp.League = tournaments.League(9)
+	assert.Equal(t, InvalidProductLeagueError, validateProductInput(inp))
+
+	inp = valid
+	inp.Rarity = store.ErrCardRarity
+	assert.Equal(t, InvalidProductRarityError, validateProductInput(inp))
+
+	inp = valid
+	inp.AvailableFrom, inp.AvailableUntil = &until, &from
+	assert.Equal(t, InvalidAvailabilityWindowError, validateProductInput(inp))
+
+	inp = valid
+	inp.AvailableUntil = nil
+	assert.NoError(t, validateProductInput(inp))
+}
+
+func TestValidateDropTable(t *testing.T) {
+	valid := store.DropTable{
+		RarityWeights:  []store.RarityWeight{{Rarity: store.Silver, Weight: 90}, {Rarity: store.Gold, Weight: 10}},
+		Guarantees:     []store.RarityGuarantee{{Rarity: store.Gold, Count: 1}},
+		PositionQuotas: []store.PositionQuota{{Position: 1, Count: 1}, {Position: 2, Count: 2}},
+		StarCost:       8,
+		StarChance:     5,
+	}
+	assert.NoError(t, validateDropTable(valid, 3))
+
+	assert.Equal(t, DropTableTooManySlotsError, validateDropTable(valid, 2))
+
+	table := valid
+	table.RarityWeights = []store.RarityWeight{{Rarity: store.Gold, Weight: 1}, {Rarity: store.Gold, Weight: 2}}
+	assert.Equal(t, InvalidDropTableError, validateDropTable(table, 3))
+
+	table = valid
+	table.Guarantees = []store.RarityGuarantee{{Rarity: store.ErrCardRarity, Count: 1}}
+	assert.Equal(t, InvalidProductRarityError, validateDropTable(table, 3))
+
+	table = valid
+	table.StarCost = 0
+	assert.Equal(t, InvalidDropTableError, validateDropTable(table, 3))
+}
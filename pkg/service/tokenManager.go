@@ -1,74 +1,268 @@
 package service
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"github.com/Frozen-Fantasy/fantasy-backend.git/config"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
 	"github.com/golang-jwt/jwt/v5"
-	"math/rand"
+	"sort"
+	"strings"
 	"time"
 )
 
 var (
 	ParseTokenError         = errors.New("невозможно получить параметры токена")
 	InvalidAccessTokenError = errors.New("невалидный access токен")
+	UnknownSigningKeyError  = errors.New("неизвестный ключ подписи токена")
 )
 
+const (
+	HS256SigningAlgorithm = "HS256"
+	EdDSASigningAlgorithm = "EdDSA"
+)
+
+type signingKey struct {
+	id        string
+	method    jwt.SigningMethod
+	signKey   interface{}
+	verifyKey interface{}
+}
+
+// Manager хранит связку ключей: активным ключом подписываются новые токены,
+// остальные ключи используются только для проверки уже выданных токенов.
+// Ключ для проверки выбирается по заголовку kid, токены без kid проверяются ключом
+// с идентификатором по умолчанию, которым подписывались токены до ротации.
 type Manager struct {
-	signingKey           string
+	keys                 map[string]signingKey
+	activeKeyID          string
 	AccessTokenLifetime  time.Duration
 	RefreshTokenLifetime time.Duration
 }
 
+const defaultSigningKeyID = "default"
+
 func NewTokenManager(cfg config.ServiceConfiguration) *Manager {
-	return &Manager{
-		signingKey:           cfg.User.SigningKey,
+	algorithm := cfg.User.SigningAlgorithm
+	if algorithm == "" {
+		algorithm = HS256SigningAlgorithm
+	}
+	activeKeyID := cfg.User.SigningKeyID
+	if activeKeyID == "" {
+		activeKeyID = defaultSigningKeyID
+	}
+
+	activeKey, err := newSigningKey(activeKeyID, algorithm, cfg.User.SigningKey)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid signing key %s: %s", activeKeyID, err))
+	}
+
+	m := &Manager{
+		keys:                 map[string]signingKey{activeKeyID: activeKey},
+		activeKeyID:          activeKeyID,
 		AccessTokenLifetime:  time.Duration(cfg.User.AccessTokenLifetime) * time.Minute,
 		RefreshTokenLifetime: time.Duration(cfg.User.RefreshTokenLifetime) * time.Minute,
 	}
+
+	previousKeys, err := parsePreviousSigningKeys(cfg.User.PreviousSigningKeys, algorithm)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid previous signing keys: %s", err))
+	}
+	for _, key := range previousKeys {
+		if _, ok := m.keys[key.id]; ok {
+			panic(fmt.Sprintf("Duplicate signing key id %s", key.id))
+		}
+		m.keys[key.id] = key
+	}
+
+	return m
+}
+
+// parsePreviousSigningKeys разбирает список ключей вида "kid:secret,kid:EdDSA:seed".
+// Если алгоритм не указан, используется алгоритм активного ключа
+func parsePreviousSigningKeys(value string, defaultAlgorithm string) ([]signingKey, error) {
+	var keys []signingKey
+
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.SplitN(entry, ":", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("entry must have format kid:secret")
+		}
+
+		algorithm, secret := defaultAlgorithm, parts[1]
+		if algParts := strings.SplitN(parts[1], ":", 2); len(algParts) == 2 &&
+			(algParts[0] == HS256SigningAlgorithm || algParts[0] == EdDSASigningAlgorithm) {
+			algorithm, secret = algParts[0], algParts[1]
+		}
+
+		key, err := newSigningKey(parts[0], algorithm, secret)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", parts[0], err)
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// newSigningKey создает ключ подписи. Для HS256 secret используется как есть,
+// для EdDSA secret - это 32-байтный seed ed25519 в base64
+func newSigningKey(id string, algorithm string, secret string) (signingKey, error) {
+	if secret == "" {
+		return signingKey{}, errors.New("empty secret")
+	}
+
+	switch algorithm {
+	case HS256SigningAlgorithm:
+		return signingKey{
+			id:        id,
+			method:    jwt.SigningMethodHS256,
+			signKey:   []byte(secret),
+			verifyKey: []byte(secret),
+		}, nil
+	case EdDSASigningAlgorithm:
+		seed, err := base64.StdEncoding.DecodeString(secret)
+		if err != nil {
+			return signingKey{}, err
+		}
+		if len(seed) != ed25519.SeedSize {
+			return signingKey{}, fmt.Errorf("ed25519 seed must be %d bytes", ed25519.SeedSize)
+		}
+		privateKey := ed25519.NewKeyFromSeed(seed)
+		return signingKey{
+			id:        id,
+			method:    jwt.SigningMethodEdDSA,
+			signKey:   privateKey,
+			verifyKey: privateKey.Public(),
+		}, nil
+	default:
+		return signingKey{}, fmt.Errorf("unsupported signing algorithm %s", algorithm)
+	}
 }
 
-func (m *Manager) CreateJWT(userID string) (int64, string, error) {
+func (m *Manager) CreateJWT(userID string, roles []string) (int64, string, error) {
+	key := m.keys[m.activeKeyID]
+
 	expiresIn := time.Now().Add(m.AccessTokenLifetime).Unix()
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256,
+	token := jwt.NewWithClaims(key.method,
 		jwt.MapClaims{
-			"exp": expiresIn,
-			"sub": userID,
+			"exp":   expiresIn,
+			"sub":   userID,
+			"roles": roles,
 		})
-	signedToken, err := token.SignedString([]byte(m.signingKey))
+	token.Header["kid"] = key.id
+
+	signedToken, err := token.SignedString(key.signKey)
 	if err != nil {
 		return 0, "", err
 	}
 	return expiresIn, signedToken, nil
 }
 
-func (m *Manager) ParseJWT(accessToken string) (string, error) {
-	token, err := jwt.Parse(accessToken, func(token *jwt.Token) (interface{}, error) {
-		return []byte(m.signingKey), nil
-	})
+func (m *Manager) ParseJWT(accessToken string) (user.AccessTokenClaims, error) {
+	var result user.AccessTokenClaims
+
+	token, err := jwt.Parse(accessToken, m.verificationKey)
 	if err != nil {
-		return "", InvalidAccessTokenError
+		return result, InvalidAccessTokenError
 	}
 
 	if !token.Valid {
-		return "", InvalidAccessTokenError
+		return result, InvalidAccessTokenError
 	}
 
 	claims, ok := token.Claims.(jwt.MapClaims)
 	if !ok {
-		return "", ParseTokenError
+		return result, ParseTokenError
+	}
+
+	result.UserID, ok = claims["sub"].(string)
+	if !ok {
+		return result, ParseTokenError
+	}
+
+	// В токенах, выданных до появления ролей, claim roles отсутствует
+	if roles, ok := claims["roles"].([]interface{}); ok {
+		for _, role := range roles {
+			if r, ok := role.(string); ok {
+				result.Roles = append(result.Roles, r)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func (m *Manager) verificationKey(token *jwt.Token) (interface{}, error) {
+	kid := defaultSigningKeyID
+	if value, ok := token.Header["kid"]; ok {
+		if kid, ok = value.(string); !ok {
+			return nil, UnknownSigningKeyError
+		}
+	}
+
+	key, ok := m.keys[kid]
+	if !ok {
+		return nil, UnknownSigningKeyError
+	}
+
+	// Алгоритм берется из ключа, а не из заголовка токена
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, UnknownSigningKeyError
+	}
+
+	return key.verifyKey, nil
+}
+
+type JWK struct {
+	KeyType   string `json:"kty"`
+	Curve     string `json:"crv"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	X         string `json:"x"`
+}
+
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS возвращает публичные ключи для проверки токенов другими сервисами.
+// Симметричные HS256 ключи не публикуются
+func (m *Manager) JWKS() JWKSet {
+	set := JWKSet{Keys: []JWK{}}
+
+	for _, key := range m.keys {
+		publicKey, ok := key.verifyKey.(ed25519.PublicKey)
+		if !ok {
+			continue
+		}
+		set.Keys = append(set.Keys, JWK{
+			KeyType:   "OKP",
+			Curve:     "Ed25519",
+			KeyID:     key.id,
+			Use:       "sig",
+			Algorithm: EdDSASigningAlgorithm,
+			X:         base64.RawURLEncoding.EncodeToString(publicKey),
+		})
 	}
+	sort.Slice(set.Keys, func(i, j int) bool { return set.Keys[i].KeyID < set.Keys[j].KeyID })
 
-	return claims["sub"].(string), nil
+	return set
 }
 
 func (m *Manager) CreateRefreshToken() (string, error) {
 	b := make([]byte, 32)
 
-	s := rand.NewSource(time.Now().Unix())
-	r := rand.New(s)
-
-	if _, err := r.Read(b); err != nil {
+	if _, err := rand.Read(b); err != nil {
 		return "", err
 	}
 
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"github.com/Frozen-Fantasy/fantasy-backend.git/config"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+const testEd25519Seed = "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8="
+
+func newTestTokenManager(keyID, algorithm, key, previous string) *Manager {
+	return NewTokenManager(config.ServiceConfiguration{User: config.User{
+		SigningKey:          key,
+		SigningKeyID:        keyID,
+		SigningAlgorithm:    algorithm,
+		PreviousSigningKeys: previous,
+		AccessTokenLifetime: 60,
+	}})
+}
+
+func TestManager_KeyRotation(t *testing.T) {
+	userID := "6bc57ea9-c881-47d3-a293-b925ff1ddf72"
+
+	oldManager := newTestTokenManager("", "", "old-secret", "")
+	_, oldToken, err := oldManager.CreateJWT(userID, nil)
+	assert.NoError(t, err)
+
+	// Токен, выданный до появления kid
+	legacyToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"exp": time.Now().Add(time.Hour).Unix(),
+		"sub": userID,
+	}).SignedString([]byte("old-secret"))
+	assert.NoError(t, err)
+
+	rotated := newTestTokenManager("2024-05", "", "new-secret", "default:old-secret")
+	_, newToken, err := rotated.CreateJWT(userID, nil)
+	assert.NoError(t, err)
+
+	for name, token := range map[string]string{"old": oldToken, "legacy": legacyToken, "new": newToken} {
+		t.Run(name, func(t *testing.T) {
+			claims, err := rotated.ParseJWT(token)
+			assert.NoError(t, err)
+			assert.Equal(t, userID, claims.UserID)
+		})
+	}
+
+	// После удаления старого ключа из связки его токены перестают приниматься
+	retired := newTestTokenManager("2024-05", "", "new-secret", "")
+	_, err = retired.ParseJWT(oldToken)
+	assert.Equal(t, InvalidAccessTokenError, err)
+	_, err = retired.ParseJWT(newToken)
+	assert.NoError(t, err)
+}
+
+func TestManager_EdDSA(t *testing.T) {
+	userID := "6bc57ea9-c881-47d3-a293-b925ff1ddf72"
+
+	manager := newTestTokenManager("ed-1", EdDSASigningAlgorithm, testEd25519Seed, "default:HS256:old-secret")
+	_, token, err := manager.CreateJWT(userID, []string{user.AdminRole})
+	assert.NoError(t, err)
+
+	claims, err := manager.ParseJWT(token)
+	assert.NoError(t, err)
+	assert.Equal(t, userID, claims.UserID)
+	assert.True(t, claims.HasRole(user.AdminRole))
+
+	jwks := manager.JWKS()
+	assert.Len(t, jwks.Keys, 1)
+	assert.Equal(t, "ed-1", jwks.Keys[0].KeyID)
+	assert.Equal(t, "OKP", jwks.Keys[0].KeyType)
+
+	// HS256 токен с kid EdDSA ключа не должен проходить проверку
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"exp": time.Now().Add(time.Hour).Unix(),
+		"sub": userID,
+	})
+	forged.Header["kid"] = "ed-1"
+	forgedToken, err := forged.SignedString([]byte(jwks.Keys[0].X))
+	assert.NoError(t, err)
+	_, err = manager.ParseJWT(forgedToken)
+	assert.Equal(t, InvalidAccessTokenError, err)
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTOTPCode(t *testing.T) {
+	// Тестовые векторы RFC 6238 для SHA1, секрет "12345678901234567890"
+	secret := base32NoPadding.EncodeToString([]byte("12345678901234567890"))
+
+	testTable := []struct {
+		unix int64
+		code string
+	}{
+		{unix: 59, code: "287082"},
+		{unix: 1111111109, code: "081804"},
+		{unix: 1234567890, code: "005924"},
+		{unix: 2000000000, code: "279037"},
+	}
+
+	for _, testCase := range testTable {
+		code, err := TOTPCode(secret, TOTPStep(time.Unix(testCase.unix, 0)))
+		assert.NoError(t, err)
+		assert.Equal(t, testCase.code, code)
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	assert.NoError(t, err)
+
+	now := time.Unix(1716890000, 0)
+	previous, err := TOTPCode(secret, TOTPStep(now)-1)
+	assert.NoError(t, err)
+
+	step, ok := ValidateTOTP(secret, previous, now)
+	assert.True(t, ok)
+	assert.Equal(t, TOTPStep(now)-1, step)
+
+	stale, err := TOTPCode(secret, TOTPStep(now)-3)
+	assert.NoError(t, err)
+	_, ok = ValidateTOTP(secret, stale, now)
+	assert.False(t, ok)
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, hashes, err := generateRecoveryCodes()
+	assert.NoError(t, err)
+	assert.Len(t, codes, recoveryCodesCount)
+
+	assert.Equal(t, hashes[0], hashRecoveryCode(strings.ToUpper(codes[0])))
+	assert.Equal(t, hashes[0], hashRecoveryCode(strings.ReplaceAll(codes[0], "-", "")))
+	assert.NotEqual(t, hashes[0], hashes[1])
+}
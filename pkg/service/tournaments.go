@@ -396,17 +396,22 @@ func (s *TournamentsService) GetTournamentResults(tournamentID int) ([]players.T
 			return res, err
 		}
 
-		userInfo, err := s.storage.GetUserInfo(res[i].ProfileID)
-		if err != nil {
-			log.Println("Service. GetUserInfo:", err)
-			return res, err
-		}
-
 		res[i].Coins = userRoster.Coins
 		res[i].Place = userRoster.Place
 		res[i].FantasyPoints = userRoster.FantasyPoints
-		res[i].Nickname = userInfo.Nickname
-		res[i].UserPhoto = userInfo.PhotoLink
+
+		// Никнейм в составе сохраняется только при окончательном удалении профиля
+		if userRoster.Nickname != "" {
+			res[i].Nickname = userRoster.Nickname
+		} else {
+			userInfo, err := s.storage.GetUserInfo(res[i].ProfileID)
+			if err != nil {
+				log.Println("Service. GetUserInfo:", err)
+				return res, err
+			}
+			res[i].Nickname = userInfo.Nickname
+			res[i].UserPhoto = userInfo.PhotoLink
+		}
 
 		matches, err := s.storage.GetMatchesByTournamentID(tournamentID)
 		if err != nil {
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"github.com/Frozen-Fantasy/fantasy-backend.git/config"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/mailer"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/players"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/google/uuid"
+	"log"
+	"time"
+)
+
+// События предложения обмена, о которых уведомляются участники
+const (
+	TradeOfferCreatedEvent   = "created"
+	TradeOfferCounteredEvent = "countered"
+	TradeOfferAcceptedEvent  = "accepted"
+	TradeOfferDeclinedEvent  = "declined"
+	TradeOfferExpiredEvent   = "expired"
+)
+
+const expireTradeOffersBatch = 100
+
+type TradeStorage interface {
+	CreateTradeOffer(offer players.TradeOffer) (players.TradeOffer, error)
+	CounterTradeOffer(counter players.TradeOffer) (players.TradeOffer, players.TradeOffer, error)
+	AcceptTradeOffer(recipientID uuid.UUID, id int64) (players.TradeOffer, error)
+	DeclineTradeOffer(recipientID uuid.UUID, id int64) (players.TradeOffer, error)
+	GetTradeOffers(profileID uuid.UUID, filter players.TradeOffersFilter) ([]players.TradeOffer, error)
+	GetTradeOffer(profileID uuid.UUID, id int64) (players.TradeOffer, error)
+	ExpireTradeOffers(limit int) ([]players.TradeOffer, error)
+}
+
+type TradeNotifier interface {
+	Notify(userID uuid.UUID, category string, templateName string, data map[string]interface{}) error
+}
+
+func NewTradeService(storage TradeStorage, notifier TradeNotifier, cfg config.ServiceConfiguration) *TradeService {
+	return &TradeService{
+		storage:  storage,
+		notifier: notifier,
+		cfg:      cfg,
+	}
+}
+
+// TradeService - обмен карточками между пользователями. О каждом изменении предложения
+// письмом уведомляются обе стороны
+type TradeService struct {
+	storage  TradeStorage
+	notifier TradeNotifier
+	cfg      config.ServiceConfiguration
+}
+
+func (s *TradeService) offerExpiresAt() time.Time {
+	return time.Now().Add(time.Duration(s.cfg.Trades.OfferTTL) * time.Hour)
+}
+
+func (s *TradeService) CreateTradeOffer(proposerID uuid.UUID, inp players.TradeOfferInput) (players.TradeOffer, error) {
+	offer, err := s.storage.CreateTradeOffer(players.TradeOffer{
+		ProposerID:     proposerID,
+		RecipientID:    inp.RecipientID,
+		OfferedCards:   inp.OfferedCards,
+		RequestedCards: inp.RequestedCards,
+		Coins:          inp.Coins,
+		ExpiresAt:      s.offerExpiresAt(),
+	})
+	if err != nil {
+		log.Println("Service. CreateTradeOffer:", err)
+		return offer, err
+	}
+
+	s.notifyParticipants(offer, TradeOfferCreatedEvent)
+	return offer, nil
+}
+
+// CounterTradeOffer отвечает на предложение новым, в котором стороны меняются местами
+func (s *TradeService) CounterTradeOffer(recipientID uuid.UUID, id int64, inp players.CounterTradeOfferInput) (players.TradeOffer, error) {
+	_, counter, err := s.storage.CounterTradeOffer(players.TradeOffer{
+		ProposerID:     recipientID,
+		OfferedCards:   inp.OfferedCards,
+		RequestedCards: inp.RequestedCards,
+		Coins:          inp.Coins,
+		ParentID:       &id,
+		ExpiresAt:      s.offerExpiresAt(),
+	})
+	if err != nil {
+		log.Println("Service. CounterTradeOffer:", err)
+		return counter, err
+	}
+
+	s.notifyParticipants(counter, TradeOfferCounteredEvent)
+	return counter, nil
+}
+
+func (s *TradeService) AcceptTradeOffer(recipientID uuid.UUID, id int64) (players.TradeOffer, error) {
+	offer, err := s.storage.AcceptTradeOffer(recipientID, id)
+	if err != nil {
+		log.Println("Service. AcceptTradeOffer:", err)
+		return offer, err
+	}
+
+	s.notifyParticipants(offer, TradeOfferAcceptedEvent)
+	return offer, nil
+}
+
+func (s *TradeService) DeclineTradeOffer(recipientID uuid.UUID, id int64) (players.TradeOffer, error) {
+	offer, err := s.storage.DeclineTradeOffer(recipientID, id)
+	if err != nil {
+		log.Println("Service. DeclineTradeOffer:", err)
+		return offer, err
+	}
+
+	s.notifyParticipants(offer, TradeOfferDeclinedEvent)
+	return offer, nil
+}
+
+func (s *TradeService) GetTradeOffers(profileID uuid.UUID, filter players.TradeOffersFilter) ([]players.TradeOffer, error) {
+	if filter.Limit == 0 {
+		filter.Limit = players.DefaultTradeOffersLimit
+	}
+
+	offers, err := s.storage.GetTradeOffers(profileID, filter)
+	if err != nil {
+		log.Println("Service. GetTradeOffers:", err)
+		return offers, err
+	}
+
+	return offers, nil
+}
+
+func (s *TradeService) GetTradeOffer(profileID uuid.UUID, id int64) (players.TradeOffer, error) {
+	offer, err := s.storage.GetTradeOffer(profileID, id)
+	if err != nil {
+		log.Println("Service. GetTradeOffer:", err)
+		return offer, err
+	}
+
+	return offer, nil
+}
+
+// ExpireTradeOffers закрывает просроченные предложения пачками и уведомляет участников
+func (s *TradeService) ExpireTradeOffers() (int, error) {
+	expired := 0
+	for {
+		offers, err := s.storage.ExpireTradeOffers(expireTradeOffersBatch)
+		if err != nil {
+			log.Println("Service. ExpireTradeOffers:", err)
+			return expired, err
+		}
+
+		for _, offer := range offers {
+			s.notifyParticipants(offer, TradeOfferExpiredEvent)
+		}
+		expired += len(offers)
+
+		if len(offers) < expireTradeOffersBatch {
+			return expired, nil
+		}
+	}
+}
+
+// notifyParticipants отправляет письмо обеим сторонам обмена. Ошибка отправки не отменяет
+// уже сохраненное изменение и только логируется
+func (s *TradeService) notifyParticipants(offer players.TradeOffer, event string) {
+	participants := []struct {
+		profileID uuid.UUID
+		nickname  string
+	}{
+		{offer.ProposerID, offer.RecipientNickname},
+		{offer.RecipientID, offer.ProposerNickname},
+	}
+
+	for _, participant := range participants {
+		err := s.notifier.Notify(participant.profileID, user.TradeOffersCategory, mailer.TradeOfferTemplate, map[string]interface{}{
+			"Event":     event,
+			"OfferID":   offer.ID,
+			"Nickname":  participant.nickname,
+			"ExpiresAt": offer.ExpiresAt.Format("02.01.2006 15:04"),
+		})
+		if err != nil {
+			log.Println("Service. Notify trade offer:", err)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"errors"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/config"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/players"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type tradeStorage struct {
+	TradeStorage
+	counter players.TradeOffer
+	expired [][]players.TradeOffer
+}
+
+func (s *tradeStorage) CounterTradeOffer(counter players.TradeOffer) (players.TradeOffer, players.TradeOffer, error) {
+	s.counter = counter
+	counter.ID = *counter.ParentID + 1
+	counter.RecipientID = uuid.New()
+	return players.TradeOffer{ID: *counter.ParentID, Status: players.CounteredTrade}, counter, nil
+}
+
+func (s *tradeStorage) AcceptTradeOffer(recipientID uuid.UUID, id int64) (players.TradeOffer, error) {
+	if id == 0 {
+		return players.TradeOffer{}, errors.New("not found")
+	}
+	return players.TradeOffer{ID: id, ProposerID: uuid.New(), ProposerNickname: "alice", RecipientID: recipientID,
+		RecipientNickname: "bob", Status: players.AcceptedTrade}, nil
+}
+
+func (s *tradeStorage) ExpireTradeOffers(limit int) ([]players.TradeOffer, error) {
+	if len(s.expired) == 0 {
+		return nil, nil
+	}
+	offers := s.expired[0]
+	s.expired = s.expired[1:]
+	return offers, nil
+}
+
+type notification struct {
+	userID   uuid.UUID
+	category string
+	data     map[string]interface{}
+}
+
+type tradeNotifier struct {
+	sent []notification
+}
+
+func (n *tradeNotifier) Notify(userID uuid.UUID, category string, templateName string, data map[string]interface{}) error {
+	n.sent = append(n.sent, notification{userID: userID, category: category, data: data})
+	return errors.New("mail is down")
+}
+
+func TestTradeService_AcceptTradeOffer(t *testing.T) {
+	notifier := &tradeNotifier{}
+	s := NewTradeService(&tradeStorage{}, notifier, config.ServiceConfiguration{})
+	recipientID := uuid.New()
+
+	offer, err := s.AcceptTradeOffer(recipientID, 5)
+	assert.NoError(t, err, "ошибка отправки письма не должна отменять обмен")
+	assert.Len(t, notifier.sent, 2)
+	assert.Equal(t, offer.ProposerID, notifier.sent[0].userID)
+	assert.Equal(t, "bob", notifier.sent[0].data["Nickname"])
+	assert.Equal(t, recipientID, notifier.sent[1].userID)
+	assert.Equal(t, "alice", notifier.sent[1].data["Nickname"])
+	for _, n := range notifier.sent {
+		assert.Equal(t, user.TradeOffersCategory, n.category)
+		assert.Equal(t, TradeOfferAcceptedEvent, n.data["Event"])
+	}
+
+	_, err = s.AcceptTradeOffer(recipientID, 0)
+	assert.Error(t, err)
+	assert.Len(t, notifier.sent, 2)
+}
+
+func TestTradeService_CounterTradeOffer(t *testing.T) {
+	storage := &tradeStorage{}
+	s := NewTradeService(storage, &tradeNotifier{}, config.ServiceConfiguration{Trades: config.Trades{OfferTTL: 24}})
+	recipientID := uuid.New()
+
+	counter, err := s.CounterTradeOffer(recipientID, 7, players.CounterTradeOfferInput{OfferedCards: []int{1}, Coins: 50})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(8), counter.ID)
+	assert.Equal(t, recipientID, storage.counter.ProposerID)
+	assert.Equal(t, int64(7), *storage.counter.ParentID)
+	assert.Equal(t, 50, storage.counter.Coins)
+	assert.WithinDuration(t, time.Now().Add(24*time.Hour), storage.counter.ExpiresAt, time.Minute)
+}
+
+func TestTradeService_ExpireTradeOffers(t *testing.T) {
+	full := make([]players.TradeOffer, expireTradeOffersBatch)
+	storage := &tradeStorage{expired: [][]players.TradeOffer{full, {{ID: 1}}}}
+	notifier := &tradeNotifier{}
+	s := NewTradeService(storage, notifier, config.ServiceConfiguration{})
+
+	expired, err := s.ExpireTradeOffers()
+	assert.NoError(t, err)
+	assert.Equal(t, expireTradeOffersBatch+1, expired)
+	assert.Len(t, notifier.sent, 2*(expireTradeOffersBatch+1))
+	assert.Equal(t, TradeOfferExpiredEvent, notifier.sent[0].data["Event"])
+}
@@ -0,0 +1,319 @@
+package service
+
+import (
+	"errors"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/storage"
+	"github.com/google/uuid"
+	"log"
+	"time"
+)
+
+var (
+	TwoFactorAlreadyEnabledError = errors.New("двухфакторная аутентификация уже включена")
+	TwoFactorNotEnabledError     = errors.New("двухфакторная аутентификация не включена")
+	InvalidTwoFactorCodeError    = errors.New("неверный код двухфакторной аутентификации")
+)
+
+// TwoFactorRequiredError возвращается из SignIn вместо токенов, если у пользователя включена 2FA.
+// Токены выдаются после подтверждения Challenge кодом в VerifyTwoFactor
+type TwoFactorRequiredError struct {
+	Challenge user.TwoFactorChallenge
+}
+
+func (e *TwoFactorRequiredError) Error() string {
+	return "требуется код двухфакторной аутентификации"
+}
+
+func (s *UserService) EnrollTwoFactor(userID uuid.UUID) (user.TwoFactorEnrollment, error) {
+	var enrollment user.TwoFactorEnrollment
+
+	twoFactor, err := s.storage.GetTwoFactor(userID)
+	if err != nil && err != storage.TwoFactorNotFoundError {
+		log.Println("Service. GetTwoFactor:", err)
+		return enrollment, err
+	}
+	if twoFactor.Enabled {
+		return enrollment, TwoFactorAlreadyEnabledError
+	}
+
+	userInfo, err := s.storage.GetUserInfo(userID)
+	if err != nil {
+		log.Println("Service. GetUserInfo:", err)
+		return enrollment, err
+	}
+
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		log.Println("Service. GenerateTOTPSecret:", err)
+		return enrollment, err
+	}
+
+	err = s.storage.SaveTwoFactorSecret(userID, secret)
+	if err != nil {
+		log.Println("Service. SaveTwoFactorSecret:", err)
+		return enrollment, err
+	}
+
+	enrollment.Secret = secret
+	enrollment.OTPAuthURI = OTPAuthURI(userInfo.Email, secret)
+
+	return enrollment, nil
+}
+
+func (s *UserService) ConfirmTwoFactor(userID uuid.UUID, code string) (user.RecoveryCodes, error) {
+	var recoveryCodes user.RecoveryCodes
+
+	twoFactor, err := s.storage.GetTwoFactor(userID)
+	if err != nil {
+		log.Println("Service. GetTwoFactor:", err)
+		return recoveryCodes, err
+	}
+	if twoFactor.Enabled {
+		return recoveryCodes, TwoFactorAlreadyEnabledError
+	}
+
+	err = s.checkTOTP(twoFactor, code)
+	if err != nil {
+		return recoveryCodes, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		log.Println("Service. GenerateRecoveryCodes:", err)
+		return recoveryCodes, err
+	}
+
+	err = s.storage.EnableTwoFactor(userID, hashes)
+	if err != nil {
+		log.Println("Service. EnableTwoFactor:", err)
+		return recoveryCodes, err
+	}
+	recoveryCodes.Codes = codes
+
+	return recoveryCodes, nil
+}
+
+func (s *UserService) DisableTwoFactor(userID uuid.UUID, code string) error {
+	twoFactor, err := s.getEnabledTwoFactor(userID)
+	if err != nil {
+		return err
+	}
+
+	err = s.limitSecondFactor(userID, func() error {
+		return s.checkSecondFactor(twoFactor, code)
+	})
+	if err != nil {
+		return err
+	}
+
+	err = s.storage.DisableTwoFactor(userID)
+	if err != nil {
+		log.Println("Service. DisableTwoFactor:", err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *UserService) RegenerateRecoveryCodes(userID uuid.UUID, code string) (user.RecoveryCodes, error) {
+	var recoveryCodes user.RecoveryCodes
+
+	twoFactor, err := s.getEnabledTwoFactor(userID)
+	if err != nil {
+		return recoveryCodes, err
+	}
+
+	err = s.limitSecondFactor(userID, func() error {
+		return s.checkTOTP(twoFactor, code)
+	})
+	if err != nil {
+		return recoveryCodes, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		log.Println("Service. GenerateRecoveryCodes:", err)
+		return recoveryCodes, err
+	}
+
+	err = s.storage.ReplaceRecoveryCodes(userID, hashes)
+	if err != nil {
+		log.Println("Service. ReplaceRecoveryCodes:", err)
+		return recoveryCodes, err
+	}
+	recoveryCodes.Codes = codes
+
+	return recoveryCodes, nil
+}
+
+func (s *UserService) VerifyTwoFactor(inp user.TwoFactorVerifyInput, device user.DeviceInfo) (user.Tokens, error) {
+	var tokens user.Tokens
+
+	attemptsKey := "two_factor_" + inp.Challenge
+	err := s.limiter.CheckLocked(attemptsKey, s.cfg.RateLimits.TwoFactor)
+	if err != nil {
+		return tokens, err
+	}
+
+	data, err := s.rStorage.GetTwoFactorChallenge(inp.Challenge)
+	if err != nil {
+		log.Println("Service. GetTwoFactorChallenge:", err)
+		return tokens, err
+	}
+
+	// Новый challenge можно получить повторным входом, поэтому неудачные попытки считаются и по аккаунту
+	profileAttemptsKey := failedTwoFactorKey(data.ProfileID)
+	err = s.limiter.CheckLocked(profileAttemptsKey, s.cfg.RateLimits.FailedTwoFactor)
+	if err != nil {
+		return tokens, err
+	}
+
+	twoFactor, err := s.getEnabledTwoFactor(data.ProfileID)
+	if err != nil {
+		return tokens, err
+	}
+
+	err = s.checkSecondFactor(twoFactor, inp.Code)
+	if err != nil {
+		if err == InvalidTwoFactorCodeError {
+			if lockErr := s.limiter.RegisterFailure(attemptsKey, s.cfg.RateLimits.TwoFactor); lockErr != nil {
+				log.Println("Service. RegisterFailure:", lockErr)
+			}
+			if lockErr := s.limiter.RegisterFailure(profileAttemptsKey, s.cfg.RateLimits.FailedTwoFactor); lockErr != nil {
+				log.Println("Service. RegisterFailure:", lockErr)
+			}
+		}
+		return tokens, err
+	}
+
+	if err = s.limiter.Reset(profileAttemptsKey); err != nil {
+		log.Println("Service. Reset:", err)
+	}
+
+	err = s.rStorage.DeleteTwoFactorChallenge(inp.Challenge)
+	if err != nil {
+		log.Println("Service. DeleteTwoFactorChallenge:", err)
+		return tokens, err
+	}
+
+	if device.Device == "" {
+		device.Device = data.Device
+	}
+	tokens, err = s.CreateSession(data.ProfileID, device)
+	if err != nil {
+		log.Println("Service. CreateSession:", err)
+		return tokens, err
+	}
+
+	return tokens, nil
+}
+
+func failedTwoFactorKey(userID uuid.UUID) string {
+	return "failed_two_factor_" + userID.String()
+}
+
+// limitSecondFactor выполняет проверку кода check под общим для аккаунта счетчиком неудачных попыток,
+// чтобы код нельзя было перебрать и через действия с уже выданным access токеном
+func (s *UserService) limitSecondFactor(userID uuid.UUID, check func() error) error {
+	key := failedTwoFactorKey(userID)
+	err := s.limiter.CheckLocked(key, s.cfg.RateLimits.FailedTwoFactor)
+	if err != nil {
+		return err
+	}
+
+	err = check()
+	if err != nil {
+		if err == InvalidTwoFactorCodeError {
+			if lockErr := s.limiter.RegisterFailure(key, s.cfg.RateLimits.FailedTwoFactor); lockErr != nil {
+				log.Println("Service. RegisterFailure:", lockErr)
+			}
+		}
+		return err
+	}
+
+	if err = s.limiter.Reset(key); err != nil {
+		log.Println("Service. Reset:", err)
+	}
+
+	return nil
+}
+
+// requireTwoFactor создает challenge для входа, если у пользователя включена 2FA
+func (s *UserService) requireTwoFactor(profileID uuid.UUID, device string) error {
+	twoFactor, err := s.storage.GetTwoFactor(profileID)
+	if err != nil {
+		if err == storage.TwoFactorNotFoundError {
+			return nil
+		}
+		log.Println("Service. GetTwoFactor:", err)
+		return err
+	}
+	if !twoFactor.Enabled {
+		return nil
+	}
+
+	challenge, err := s.rStorage.CreateTwoFactorChallenge(user.TwoFactorChallengeData{
+		ProfileID: profileID,
+		Device:    device,
+	})
+	if err != nil {
+		log.Println("Service. CreateTwoFactorChallenge:", err)
+		return err
+	}
+
+	return &TwoFactorRequiredError{Challenge: challenge}
+}
+
+func (s *UserService) getEnabledTwoFactor(userID uuid.UUID) (user.TwoFactor, error) {
+	twoFactor, err := s.storage.GetTwoFactor(userID)
+	if err != nil {
+		if err == storage.TwoFactorNotFoundError {
+			return twoFactor, TwoFactorNotEnabledError
+		}
+		log.Println("Service. GetTwoFactor:", err)
+		return twoFactor, err
+	}
+	if !twoFactor.Enabled {
+		return twoFactor, TwoFactorNotEnabledError
+	}
+
+	return twoFactor, nil
+}
+
+// checkSecondFactor принимает TOTP код или одноразовый код восстановления
+func (s *UserService) checkSecondFactor(twoFactor user.TwoFactor, code string) error {
+	if len(code) == totpDigits {
+		return s.checkTOTP(twoFactor, code)
+	}
+
+	used, err := s.storage.UseRecoveryCode(twoFactor.ProfileID, hashRecoveryCode(code))
+	if err != nil {
+		log.Println("Service. UseRecoveryCode:", err)
+		return err
+	}
+	if !used {
+		return InvalidTwoFactorCodeError
+	}
+
+	return nil
+}
+
+func (s *UserService) checkTOTP(twoFactor user.TwoFactor, code string) error {
+	step, ok := ValidateTOTP(twoFactor.Secret, code, time.Now())
+	if !ok {
+		return InvalidTwoFactorCodeError
+	}
+
+	// Один и тот же код нельзя использовать повторно
+	used, err := s.storage.UseTwoFactorStep(twoFactor.ProfileID, step)
+	if err != nil {
+		log.Println("Service. UseTwoFactorStep:", err)
+		return err
+	}
+	if !used {
+		return InvalidTwoFactorCodeError
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"fmt"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/config"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type twoFactorStorage struct {
+	UserStorage
+	twoFactor user.TwoFactor
+}
+
+func (s *twoFactorStorage) GetTwoFactor(profileID uuid.UUID) (user.TwoFactor, error) {
+	return s.twoFactor, nil
+}
+
+func (s *twoFactorStorage) UseRecoveryCode(profileID uuid.UUID, codeHash string) (bool, error) {
+	return false, nil
+}
+
+type twoFactorRStorage struct {
+	UserRStorage
+	profileID uuid.UUID
+}
+
+func (s *twoFactorRStorage) GetTwoFactorChallenge(challenge string) (user.TwoFactorChallengeData, error) {
+	return user.TwoFactorChallengeData{ProfileID: s.profileID}, nil
+}
+
+type memoryRateLimitStorage struct {
+	RateLimitStorage
+	counters map[string]int64
+}
+
+func (s *memoryRateLimitStorage) IncrementCounter(key string, window time.Duration) (int64, time.Duration, error) {
+	s.counters[key]++
+	return s.counters[key], window, nil
+}
+
+func (s *memoryRateLimitStorage) GetCounter(key string) (int64, time.Duration, error) {
+	return s.counters[key], time.Minute, nil
+}
+
+func TestUserService_VerifyTwoFactor_locksProfile(t *testing.T) {
+	profileID := uuid.New()
+	cfg := config.ServiceConfiguration{}
+	cfg.RateLimits.TwoFactor = config.RateLimit{Limit: 5, Window: 300}
+	cfg.RateLimits.FailedTwoFactor = config.RateLimit{Limit: 10, Window: 900}
+
+	limits := &memoryRateLimitStorage{counters: map[string]int64{}}
+	s := &UserService{
+		storage:  &twoFactorStorage{twoFactor: user.TwoFactor{ProfileID: profileID, Enabled: true}},
+		rStorage: &twoFactorRStorage{profileID: profileID},
+		limiter:  NewRateLimitService(limits),
+		cfg:      cfg,
+	}
+
+	// Каждые 5 попыток - новый challenge, как при повторном входе по паролю
+	for i := 0; i < 10; i++ {
+		challenge := fmt.Sprintf("challenge_%d", i/5)
+		_, err := s.VerifyTwoFactor(user.TwoFactorVerifyInput{Challenge: challenge, Code: "wrong-code"}, user.DeviceInfo{})
+		assert.Equal(t, InvalidTwoFactorCodeError, err)
+	}
+
+	_, err := s.VerifyTwoFactor(user.TwoFactorVerifyInput{Challenge: "challenge_2", Code: "wrong-code"}, user.DeviceInfo{})
+	assert.IsType(t, &RateLimitError{}, err)
+}
+
+func TestUserService_DisableTwoFactor_locksProfile(t *testing.T) {
+	profileID := uuid.New()
+	cfg := config.ServiceConfiguration{}
+	cfg.RateLimits.TwoFactor = config.RateLimit{Limit: 5, Window: 300}
+	cfg.RateLimits.FailedTwoFactor = config.RateLimit{Limit: 10, Window: 900}
+
+	limits := &memoryRateLimitStorage{counters: map[string]int64{}}
+	s := &UserService{
+		storage:  &twoFactorStorage{twoFactor: user.TwoFactor{ProfileID: profileID, Enabled: true}},
+		rStorage: &twoFactorRStorage{profileID: profileID},
+		limiter:  NewRateLimitService(limits),
+		cfg:      cfg,
+	}
+
+	for i := 0; i < 10; i++ {
+		err := s.DisableTwoFactor(profileID, "wrong-recovery-code")
+		assert.Equal(t, InvalidTwoFactorCodeError, err)
+	}
+
+	err := s.DisableTwoFactor(profileID, "wrong-recovery-code")
+	assert.IsType(t, &RateLimitError{}, err)
+
+	// Счетчик общий со входом и перевыпуском кодов восстановления
+	_, err = s.RegenerateRecoveryCodes(profileID, "000000")
+	assert.IsType(t, &RateLimitError{}, err)
+	_, err = s.VerifyTwoFactor(user.TwoFactorVerifyInput{Challenge: "challenge", Code: "000000"}, user.DeviceInfo{})
+	assert.IsType(t, &RateLimitError{}, err)
+}
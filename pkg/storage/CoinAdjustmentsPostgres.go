@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"strconv"
+)
+
+// CreateCoinAdjustment записывает изменение в журнал и проводит его переводом между house и пользователем
+// в одной транзакции. Ссылка транзакции главной книги указывает на запись журнала
+func (p *PostgresStorage) CreateCoinAdjustment(adj user.CoinAdjustment) (user.CoinAdjustment, error) {
+	err := p.withTx(func(tx *sqlx.Tx) error {
+		err := tx.QueryRowx(`INSERT INTO coin_adjustments (profile_id, admin_id, amount, reason) 
+			VALUES ($1, $2, $3, $4) RETURNING id, created_at`,
+			adj.ProfileID, adj.AdminID, adj.Amount, adj.Reason).Scan(&adj.ID, &adj.CreatedAt)
+		if err != nil {
+			return err
+		}
+
+		transfer := user.LedgerTransfer{
+			From:          user.HouseAccount(),
+			To:            user.UserAccount(adj.ProfileID),
+			Amount:        adj.Amount,
+			Reason:        user.AdjustmentReason,
+			ReferenceType: user.AdjustmentReference,
+			ReferenceID:   strconv.FormatInt(adj.ID, 10),
+		}
+		if adj.Amount < 0 {
+			transfer.From, transfer.To = transfer.To, transfer.From
+			transfer.Amount = -adj.Amount
+		}
+
+		return p.PostLedgerTransfer(tx, transfer)
+	})
+	if err != nil {
+		return adj, err
+	}
+
+	return adj, nil
+}
+
+// GetCoinAdjustments возвращает изменения баланса пользователя и изменения, сделанные им как администратором
+func (p *PostgresStorage) GetCoinAdjustments(profileID uuid.UUID) ([]user.CoinAdjustment, error) {
+	var adjustments []user.CoinAdjustment
+
+	err := p.db.Select(&adjustments, `SELECT id, profile_id, admin_id, amount, reason, created_at 
+		FROM coin_adjustments WHERE profile_id = $1 OR admin_id = $1 ORDER BY created_at DESC, id DESC`, profileID)
+	if err != nil {
+		return adjustments, err
+	}
+	if adjustments == nil {
+		adjustments = []user.CoinAdjustment{}
+	}
+
+	return adjustments, nil
+}
@@ -2,34 +2,146 @@ package storage
 
 import (
 	"database/sql"
+	"errors"
+	"fmt"
 	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
-	"time"
 )
 
-func (p *PostgresStorage) CreateCoinTransaction(tx *sqlx.Tx, u user.CoinTransactionsModel) error {
-	_, err := tx.Exec(`INSERT INTO coin_transactions (profile_id, transaction_details, amount, transaction_date, 
-                               status) VALUES ($1, $2, $3, $4, $5);`,
-		u.ProfileID,
-		u.TransactionDetails,
-		u.Amount,
-		time.Now(),
-		u.Status,
+var (
+	UnknownLedgerAccountError = errors.New("неизвестный счет")
+	InvalidLedgerAmountError  = errors.New("сумма перевода должна быть положительной")
+)
+
+// PostLedgerTransfer записывает перевод двумя проводками в рамках tx. Баланс пользователя меняется
+// через кошелек под блокировкой строки профиля, поэтому списание больше баланса возвращает
+// InsufficientFundsError даже при параллельных запросах. При ошибке откатить tx должен вызывающий
+func (p *PostgresStorage) PostLedgerTransfer(tx *sqlx.Tx, t user.LedgerTransfer) error {
+	if t.Amount == 0 {
+		return nil
+	}
+	if t.Amount < 0 {
+		return InvalidLedgerAmountError
+	}
+
+	fromID, err := p.ledgerAccountID(tx, t.From)
+	if err != nil {
+		return err
+	}
+	toID, err := p.ledgerAccountID(tx, t.To)
+	if err != nil {
+		return err
+	}
+
+	// Строки пользователей блокируются в одном порядке, чтобы встречные переводы не взаимоблокировались
+	var fromBalance, toBalance *int
+	debitFirst := t.From.Kind != user.UserAccountKind || t.To.Kind != user.UserAccountKind ||
+		t.From.ProfileID.String() < t.To.ProfileID.String()
+	if debitFirst {
+		if fromBalance, err = p.changeLedgerBalance(tx, t.From, -t.Amount); err == nil {
+			toBalance, err = p.changeLedgerBalance(tx, t.To, t.Amount)
+		}
+	} else {
+		if toBalance, err = p.changeLedgerBalance(tx, t.To, t.Amount); err == nil {
+			fromBalance, err = p.changeLedgerBalance(tx, t.From, -t.Amount)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	var referenceType, referenceID *string
+	if t.ReferenceType != "" {
+		referenceType = &t.ReferenceType
+	}
+	if t.ReferenceID != "" {
+		referenceID = &t.ReferenceID
+	}
+
+	var transactionID int64
+	err = tx.Get(&transactionID, `INSERT INTO ledger_transactions (reason, reference_type, reference_id) 
+		VALUES ($1, $2, $3) RETURNING id`, t.Reason, referenceType, referenceID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`INSERT INTO ledger_entries (transaction_id, account_id, amount, balance_after) 
+		VALUES ($1, $2, $3, $4), ($1, $5, $6, $7);`,
+		transactionID,
+		fromID, -t.Amount, fromBalance,
+		toID, t.Amount, toBalance,
 	)
 	if err != nil {
-		tx.Rollback()
 		return err
 	}
 
 	return nil
 }
 
-func (p *PostgresStorage) GetCoinTransactionsByProfileID(profileID uuid.UUID) ([]user.CoinTransactionsModel, error) {
-	var transactions []user.CoinTransactionsModel
+// changeLedgerBalance меняет баланс кошелька пользователя. Для остальных счетов баланс - сумма проводок
+func (p *PostgresStorage) changeLedgerBalance(tx *sqlx.Tx, account user.LedgerAccount, delta int) (*int, error) {
+	if account.Kind != user.UserAccountKind {
+		return nil, nil
+	}
+
+	balance, err := p.changeWalletBalance(tx, account.ProfileID, delta)
+	if err != nil {
+		return nil, err
+	}
+
+	return &balance, nil
+}
+
+// ledgerAccountID возвращает id счета, создавая счет пользователя или турнира при первом обращении
+func (p *PostgresStorage) ledgerAccountID(tx *sqlx.Tx, account user.LedgerAccount) (int, error) {
+	var selectQuery, insertQuery string
+	var args []interface{}
+
+	switch account.Kind {
+	case user.UserAccountKind:
+		selectQuery = `SELECT id FROM ledger_accounts WHERE profile_id = $1`
+		insertQuery = `INSERT INTO ledger_accounts (kind, profile_id) VALUES ('user', $1) ON CONFLICT DO NOTHING`
+		args = []interface{}{account.ProfileID}
+	case user.PrizePoolAccountKind:
+		selectQuery = `SELECT id FROM ledger_accounts WHERE tournament_id = $1`
+		insertQuery = `INSERT INTO ledger_accounts (kind, tournament_id) VALUES ('prize_pool', $1) ON CONFLICT DO NOTHING`
+		args = []interface{}{account.TournamentID}
+	case user.HouseAccountKind:
+		selectQuery = `SELECT id FROM ledger_accounts WHERE kind = 'house'`
+		insertQuery = `INSERT INTO ledger_accounts (kind) VALUES ('house') ON CONFLICT DO NOTHING`
+	default:
+		return 0, UnknownLedgerAccountError
+	}
+
+	var id int
+	err := tx.Get(&id, selectQuery, args...)
+	if err == sql.ErrNoRows {
+		if _, err = tx.Exec(insertQuery, args...); err != nil {
+			return 0, err
+		}
+		err = tx.Get(&id, selectQuery, args...)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+const coinTransactionsSelect = `SELECT lt.id, lt.reason, e.amount, COALESCE(e.balance_after, 0) AS balance_after,
+       		ca.kind AS counterparty_kind, lt.reference_type, lt.reference_id, lt.created_at
+		FROM ledger_entries e
+		    JOIN ledger_accounts a ON a.id = e.account_id
+			JOIN ledger_transactions lt ON lt.id = e.transaction_id
+			JOIN ledger_entries ce ON ce.transaction_id = e.transaction_id AND ce.id <> e.id
+			JOIN ledger_accounts ca ON ca.id = ce.account_id`
 
-	err := p.db.Select(&transactions, `SELECT id, profile_id, transaction_details, amount, transaction_date, status 
-        FROM coin_transactions WHERE profile_id = $1;`, profileID)
+func (p *PostgresStorage) GetCoinTransactionsByProfileID(profileID uuid.UUID) ([]user.CoinTransaction, error) {
+	var transactions []user.CoinTransaction
+
+	err := p.db.Select(&transactions, coinTransactionsSelect+` WHERE a.profile_id = $1
+		ORDER BY lt.created_at, lt.id;`, profileID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return transactions, UserDoesNotExistError
@@ -38,8 +150,83 @@ func (p *PostgresStorage) GetCoinTransactionsByProfileID(profileID uuid.UUID) ([
 		}
 	}
 	if transactions == nil {
-		transactions = []user.CoinTransactionsModel{}
+		transactions = []user.CoinTransaction{}
+	}
+
+	return transactions, nil
+}
+
+// coinTransactionsWhere собирает условия фильтра истории. Курсор в сводке не учитывается
+func coinTransactionsWhere(profileID uuid.UUID, filter user.CoinTransactionsFilter) (string, []interface{}) {
+	args := []interface{}{profileID}
+	where := " WHERE a.profile_id = $1"
+
+	if !filter.From.IsZero() {
+		args = append(args, filter.From)
+		where += fmt.Sprintf(" AND lt.created_at >= $%d", len(args))
+	}
+	if !filter.To.IsZero() {
+		args = append(args, filter.To)
+		where += fmt.Sprintf(" AND lt.created_at < $%d", len(args))
+	}
+	switch filter.Sign {
+	case user.CreditSign:
+		where += " AND e.amount > 0"
+	case user.DebitSign:
+		where += " AND e.amount < 0"
+	}
+	if filter.Reason != "" {
+		args = append(args, filter.Reason)
+		where += fmt.Sprintf(" AND lt.reason = $%d", len(args))
+	}
+
+	return where, args
+}
+
+// GetCoinTransactionsPage возвращает транзакции от новых к старым, начиная после курсора.
+// limit <= 0 - вся история по фильтру
+func (p *PostgresStorage) GetCoinTransactionsPage(profileID uuid.UUID, filter user.CoinTransactionsFilter,
+	after *user.CoinTransactionsCursor, limit int) ([]user.CoinTransaction, error) {
+	var transactions []user.CoinTransaction
+
+	where, args := coinTransactionsWhere(profileID, filter)
+	if after != nil {
+		args = append(args, after.CreatedAt, after.ID)
+		where += fmt.Sprintf(" AND (lt.created_at, lt.id) < ($%d, $%d)", len(args)-1, len(args))
+	}
+
+	query := coinTransactionsSelect + where + " ORDER BY lt.created_at DESC, lt.id DESC"
+	if limit > 0 {
+		args = append(args, limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	err := p.db.Select(&transactions, query, args...)
+	if err != nil {
+		return transactions, err
+	}
+	if transactions == nil {
+		transactions = []user.CoinTransaction{}
 	}
 
 	return transactions, nil
 }
+
+// GetCoinTransactionsSummary считает по фильтру сумму всех списаний и сумму выигранных в турнирах монет
+func (p *PostgresStorage) GetCoinTransactionsSummary(profileID uuid.UUID, filter user.CoinTransactionsFilter) (user.CoinTransactionsSummary, error) {
+	var summary user.CoinTransactionsSummary
+
+	where, args := coinTransactionsWhere(profileID, filter)
+	query := `SELECT COALESCE(SUM(-e.amount) FILTER (WHERE e.amount < 0), 0) AS total_spent,
+       		COALESCE(SUM(e.amount) FILTER (WHERE e.amount > 0 AND lt.reason = 'prize'), 0) AS total_won
+		FROM ledger_entries e
+		    JOIN ledger_accounts a ON a.id = e.account_id
+			JOIN ledger_transactions lt ON lt.id = e.transaction_id` + where
+
+	err := p.db.Get(&summary, query, args...)
+	if err != nil {
+		return summary, err
+	}
+
+	return summary, nil
+}
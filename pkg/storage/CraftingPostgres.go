@@ -0,0 +1,280 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/players"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/store"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"sort"
+	"time"
+)
+
+var (
+	UpgradeCardsMismatchError = errors.New("для улучшения нужны серебряные карточки одного игрока")
+	PlayerNotFoundError       = errors.New("игрок с указанным id не найден")
+)
+
+// InsufficientDustError - пыли на балансе меньше цены крафта
+type InsufficientDustError struct {
+	Balance  int
+	Required int
+}
+
+func (e *InsufficientDustError) Error() string {
+	return fmt.Sprintf("не достаточно пыли: доступно %d, требуется %d", e.Balance, e.Required)
+}
+
+// consumedCard - карточка, которая расходуется при разборе или улучшении
+type consumedCard struct {
+	ID          int               `db:"id"`
+	PlayerID    int               `db:"player_id"`
+	Rarity      store.CardRarity  `db:"rarity"`
+	BonusMetric store.BonusMetric `db:"bonus_metric"`
+}
+
+const cardConversionColumns = `id, kind, consumed_cards, produced_card_id, dust_amount, dust_balance_after, created_at`
+
+type cardConversionRow struct {
+	ID               int64         `db:"id"`
+	Kind             string        `db:"kind"`
+	ConsumedCards    pq.Int64Array `db:"consumed_cards"`
+	ProducedCardID   *int          `db:"produced_card_id"`
+	DustAmount       int           `db:"dust_amount"`
+	DustBalanceAfter int           `db:"dust_balance_after"`
+	CreatedAt        time.Time     `db:"created_at"`
+}
+
+func (r cardConversionRow) toModel() players.CardConversion {
+	consumed := make([]int, len(r.ConsumedCards))
+	for i, id := range r.ConsumedCards {
+		consumed[i] = int(id)
+	}
+
+	return players.CardConversion{
+		ID:               r.ID,
+		Kind:             r.Kind,
+		ConsumedCards:    consumed,
+		ProducedCardID:   r.ProducedCardID,
+		DustAmount:       r.DustAmount,
+		DustBalanceAfter: r.DustBalanceAfter,
+		CreatedAt:        r.CreatedAt,
+	}
+}
+
+// lockConsumedCards блокирует карточки в порядке id и проверяет, что их можно израсходовать:
+// как и при продаже, карточки должны быть распакованы, вне активного состава и не выставлены на рынок.
+// Возвращает карточки в том же порядке
+func (p *PostgresStorage) lockConsumedCards(tx *sqlx.Tx, profileID uuid.UUID, cardIDs []int) ([]consumedCard, error) {
+	ids := append([]int{}, cardIDs...)
+	sort.Ints(ids)
+
+	for _, id := range ids {
+		if err := p.lockOwnedCard(tx, profileID, id); err != nil {
+			return nil, err
+		}
+	}
+
+	var listed bool
+	err := tx.Get(&listed, `SELECT EXISTS (SELECT 1 FROM card_listings WHERE card_id = ANY ($1) AND status = $2)`,
+		pq.Array(ids), players.ActiveListing)
+	if err != nil {
+		return nil, err
+	}
+	if listed {
+		return nil, CardAlreadyListedError
+	}
+
+	var cards []consumedCard
+	err = tx.Select(&cards, `SELECT id, player_id, rarity, bonus_metric FROM player_cards WHERE id = ANY ($1) ORDER BY id`,
+		pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+
+	return cards, nil
+}
+
+func burnCards(tx *sqlx.Tx, cardIDs []int) error {
+	_, err := tx.Exec(`UPDATE player_cards SET profile_id = NULL, burned_at = now() WHERE id = ANY ($1)`, pq.Array(cardIDs))
+	return err
+}
+
+func insertCraftedCard(tx *sqlx.Tx, profileID uuid.UUID, playerID int, rarity store.CardRarity, bonusMetric store.BonusMetric) (int, error) {
+	var id int
+	err := tx.Get(&id, `INSERT INTO player_cards (profile_id, player_id, rarity, multiply, bonus_metric, unpacked)
+		VALUES ($1, $2, $3, $4, $5, TRUE) RETURNING id`,
+		profileID, playerID, rarity, store.CardMultiply[rarity], bonusMetric)
+	return id, err
+}
+
+// postCardConversion меняет баланс пыли на conversion.DustAmount и записывает превращение в журнал
+func postCardConversion(tx *sqlx.Tx, profileID uuid.UUID, conversion players.CardConversion) (players.CardConversion, error) {
+	var balance int
+	err := tx.Get(&balance, `SELECT dust FROM user_profile WHERE id = $1 FOR UPDATE`, profileID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return conversion, UserDoesNotExistError
+		}
+		return conversion, err
+	}
+
+	if balance+conversion.DustAmount < 0 {
+		return conversion, &InsufficientDustError{Balance: balance, Required: -conversion.DustAmount}
+	}
+
+	_, err = tx.Exec(`UPDATE user_profile SET dust = $2 WHERE id = $1`, profileID, balance+conversion.DustAmount)
+	if err != nil {
+		return conversion, err
+	}
+
+	var row cardConversionRow
+	err = tx.Get(&row, `INSERT INTO card_conversions (profile_id, kind, consumed_cards, produced_card_id, dust_amount,
+			dust_balance_after)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING `+cardConversionColumns,
+		profileID, conversion.Kind, pq.Array(conversion.ConsumedCards), conversion.ProducedCardID,
+		conversion.DustAmount, balance+conversion.DustAmount)
+	if err != nil {
+		return conversion, err
+	}
+
+	return row.toModel(), nil
+}
+
+// DismantleCards разбирает карточки на пыль. dust - сколько пыли дает карточка каждой редкости
+func (p *PostgresStorage) DismantleCards(profileID uuid.UUID, cardIDs []int, dust map[store.CardRarity]int) (players.CardConversion, error) {
+	var conversion players.CardConversion
+
+	err := p.withTx(func(tx *sqlx.Tx) error {
+		cards, err := p.lockConsumedCards(tx, profileID, cardIDs)
+		if err != nil {
+			return err
+		}
+
+		conversion = players.CardConversion{Kind: players.DismantleConversion}
+		for _, card := range cards {
+			conversion.ConsumedCards = append(conversion.ConsumedCards, card.ID)
+			conversion.DustAmount += dust[card.Rarity]
+		}
+
+		if err = burnCards(tx, conversion.ConsumedCards); err != nil {
+			return err
+		}
+
+		conversion, err = postCardConversion(tx, profileID, conversion)
+		return err
+	})
+	if err != nil {
+		return conversion, err
+	}
+
+	return conversion, nil
+}
+
+// UpgradeCards объединяет серебряные карточки одного игрока в золотую. Бонусная метрика переходит
+// от израсходованных карточек
+func (p *PostgresStorage) UpgradeCards(profileID uuid.UUID, cardIDs []int) (players.CardConversion, error) {
+	var conversion players.CardConversion
+
+	err := p.withTx(func(tx *sqlx.Tx) error {
+		cards, err := p.lockConsumedCards(tx, profileID, cardIDs)
+		if err != nil {
+			return err
+		}
+
+		conversion = players.CardConversion{Kind: players.UpgradeConversion}
+		for _, card := range cards {
+			if card.Rarity != store.Silver || card.PlayerID != cards[0].PlayerID {
+				return UpgradeCardsMismatchError
+			}
+			conversion.ConsumedCards = append(conversion.ConsumedCards, card.ID)
+		}
+
+		if err = burnCards(tx, conversion.ConsumedCards); err != nil {
+			return err
+		}
+
+		id, err := insertCraftedCard(tx, profileID, cards[0].PlayerID, store.Gold, cards[0].BonusMetric)
+		if err != nil {
+			return err
+		}
+		conversion.ProducedCardID = &id
+
+		conversion, err = postCardConversion(tx, profileID, conversion)
+		return err
+	})
+	if err != nil {
+		return conversion, err
+	}
+
+	return conversion, nil
+}
+
+// CraftCard создает карточку выбранного игрока за price пыли
+func (p *PostgresStorage) CraftCard(profileID uuid.UUID, inp players.CraftCardInput, price int) (players.CardConversion, error) {
+	var conversion players.CardConversion
+
+	err := p.withTx(func(tx *sqlx.Tx) error {
+		var position players.Position
+		err := tx.Get(&position, `SELECT position FROM players WHERE id = $1`, inp.PlayerID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return PlayerNotFoundError
+			}
+			return err
+		}
+
+		id, err := insertCraftedCard(tx, profileID, inp.PlayerID, inp.Rarity, store.BonusMetric(position))
+		if err != nil {
+			return err
+		}
+
+		conversion, err = postCardConversion(tx, profileID, players.CardConversion{
+			Kind:           players.CraftConversion,
+			ProducedCardID: &id,
+			DustAmount:     -price,
+		})
+		return err
+	})
+	if err != nil {
+		return conversion, err
+	}
+
+	return conversion, nil
+}
+
+func (p *PostgresStorage) GetDustBalance(profileID uuid.UUID) (int, error) {
+	var balance int
+
+	err := p.db.Get(&balance, `SELECT dust FROM user_profile WHERE id = $1`, profileID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, UserDoesNotExistError
+		}
+		return 0, err
+	}
+
+	return balance, nil
+}
+
+// GetCardConversions возвращает журнал крафта пользователя от новых записей к старым
+func (p *PostgresStorage) GetCardConversions(profileID uuid.UUID, filter players.CardConversionsFilter) ([]players.CardConversion, error) {
+	var rows []cardConversionRow
+
+	err := p.db.Select(&rows, `SELECT `+cardConversionColumns+` FROM card_conversions
+		WHERE profile_id = $1 AND ($2 = 0 OR id < $2) ORDER BY id DESC LIMIT $3`,
+		profileID, filter.Before, filter.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	conversions := make([]players.CardConversion, len(rows))
+	for i, row := range rows {
+		conversions[i] = row.toModel()
+	}
+
+	return conversions, nil
+}
@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/google/uuid"
+	"time"
+)
+
+var (
+	DataExportNotFoundError = errors.New("выгрузка данных не найдена")
+)
+
+// exportClaimTimeout - после этого времени зависшая в processing выгрузка снова берется в работу
+const exportClaimTimeout = 30 * time.Minute
+
+const dataExportColumns = `id, profile_id, status, blob_key, size, last_error, claimed_at, created_at, completed_at, expires_at`
+
+func (p *PostgresStorage) CreateDataExport(profileID uuid.UUID) (user.DataExport, error) {
+	var export user.DataExport
+
+	err := p.db.Get(&export, `INSERT INTO data_exports (id, profile_id) VALUES ($1, $2) RETURNING `+dataExportColumns,
+		uuid.New(), profileID)
+	if err != nil {
+		return export, err
+	}
+
+	return export, nil
+}
+
+func (p *PostgresStorage) GetDataExport(id uuid.UUID) (user.DataExport, error) {
+	var export user.DataExport
+
+	err := p.db.Get(&export, `SELECT `+dataExportColumns+` FROM data_exports WHERE id = $1`, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return export, DataExportNotFoundError
+		}
+		return export, err
+	}
+
+	return export, nil
+}
+
+// GetActiveDataExport возвращает выгрузку, которая еще собирается
+func (p *PostgresStorage) GetActiveDataExport(profileID uuid.UUID) (user.DataExport, error) {
+	var export user.DataExport
+
+	err := p.db.Get(&export, `SELECT `+dataExportColumns+` FROM data_exports 
+		WHERE profile_id = $1 AND status IN ('pending', 'processing') ORDER BY created_at DESC LIMIT 1`, profileID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return export, DataExportNotFoundError
+		}
+		return export, err
+	}
+
+	return export, nil
+}
+
+// ClaimDataExport забирает одну выгрузку в работу. Несколько воркеров не получат одну и ту же
+func (p *PostgresStorage) ClaimDataExport() (user.DataExport, error) {
+	var export user.DataExport
+
+	err := p.db.Get(&export, `UPDATE data_exports SET status = 'processing', claimed_at = now()
+		WHERE id = (
+			SELECT id FROM data_exports
+			WHERE status = 'pending' OR (status = 'processing' AND claimed_at < $1)
+			ORDER BY created_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+dataExportColumns, time.Now().Add(-exportClaimTimeout))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return export, DataExportNotFoundError
+		}
+		return export, err
+	}
+
+	return export, nil
+}
+
+func (p *PostgresStorage) MarkDataExportReady(id uuid.UUID, blobKey string, size int64, expiresAt time.Time) error {
+	_, err := p.db.Exec(`UPDATE data_exports SET status = 'ready', blob_key = $2, size = $3, completed_at = now(), expires_at = $4
+		WHERE id = $1;`, id, blobKey, size, expiresAt)
+
+	return err
+}
+
+func (p *PostgresStorage) MarkDataExportFailed(id uuid.UUID, exportErr string) error {
+	_, err := p.db.Exec(`UPDATE data_exports SET status = 'failed', last_error = $2, completed_at = now() WHERE id = $1;`,
+		id, exportErr)
+
+	return err
+}
+
+func (p *PostgresStorage) GetExpiredDataExports(limit int) ([]user.DataExport, error) {
+	exports := []user.DataExport{}
+
+	err := p.db.Select(&exports, `SELECT `+dataExportColumns+` FROM data_exports 
+		WHERE status = 'ready' AND expires_at <= now() ORDER BY expires_at LIMIT $1`, limit)
+	if err != nil {
+		return exports, err
+	}
+
+	return exports, nil
+}
+
+func (p *PostgresStorage) MarkDataExportExpired(id uuid.UUID) error {
+	_, err := p.db.Exec(`UPDATE data_exports SET status = 'expired' WHERE id = $1;`, id)
+
+	return err
+}
@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+var (
+	EmailChangeNotFoundError = errors.New("запрос на смену email не найден или истек")
+	EmailChangeUndoError     = errors.New("ссылка отмены смены email не найдена или истекла")
+)
+
+// CreateEmailChange запоминает новый адрес до подтверждения кодом. Живет столько же, сколько код верификации
+func (r *RedisStorage) CreateEmailChange(profileID uuid.UUID, newEmail string) error {
+	return r.client.Set(context.Background(), "email_change_"+profileID.String(), newEmail, codeTTL).Err()
+}
+
+func (r *RedisStorage) GetEmailChange(profileID uuid.UUID) (string, error) {
+	email, err := r.client.Get(context.Background(), "email_change_"+profileID.String()).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return "", EmailChangeNotFoundError
+		}
+		return "", err
+	}
+
+	return email, nil
+}
+
+func (r *RedisStorage) DeleteEmailChange(profileID uuid.UUID) error {
+	return r.client.Del(context.Background(), "email_change_"+profileID.String()).Err()
+}
+
+func (r *RedisStorage) CreateEmailChangeUndoToken(data user.EmailChangeUndo, ttl time.Duration) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	token := fmt.Sprintf("%x", b)
+
+	value, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+
+	err = r.client.Set(context.Background(), "email_change_undo_"+token, value, ttl).Err()
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+func (r *RedisStorage) GetEmailChangeUndo(token string) (user.EmailChangeUndo, error) {
+	var data user.EmailChangeUndo
+
+	val, err := r.client.Get(context.Background(), "email_change_undo_"+token).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return data, EmailChangeUndoError
+		}
+		return data, err
+	}
+
+	err = json.Unmarshal(val, &data)
+	if err != nil {
+		return data, err
+	}
+
+	return data, nil
+}
+
+func (r *RedisStorage) DeleteEmailChangeUndoToken(token string) error {
+	return r.client.Del(context.Background(), "email_change_undo_"+token).Err()
+}
@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"time"
+)
+
+// outboxClaimTimeout - на это время письмо скрывается от других воркеров, пока идет отправка
+const outboxClaimTimeout = 5 * time.Minute
+
+func (p *PostgresStorage) CreateOutboxEmail(email user.OutboxEmail) error {
+	_, err := p.db.Exec(`INSERT INTO email_outbox (recipient, subject, body, headers) VALUES ($1, $2, $3, $4);`,
+		email.Recipient,
+		email.Subject,
+		email.Body,
+		email.Headers,
+	)
+
+	return err
+}
+
+// ClaimOutboxEmails забирает письма, готовые к отправке. Несколько воркеров не получат одно и то же письмо
+func (p *PostgresStorage) ClaimOutboxEmails(limit int) ([]user.OutboxEmail, error) {
+	emails := []user.OutboxEmail{}
+
+	err := p.db.Select(&emails, `UPDATE email_outbox SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT id FROM email_outbox 
+			WHERE status = 'pending' AND next_attempt_at <= now()
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, recipient, subject, body, headers, status, attempts, last_error, next_attempt_at, created_at, sent_at;`,
+		limit, time.Now().Add(outboxClaimTimeout))
+	if err != nil {
+		return emails, err
+	}
+
+	return emails, nil
+}
+
+func (p *PostgresStorage) MarkOutboxEmailSent(id int) error {
+	_, err := p.db.Exec(`UPDATE email_outbox SET status = 'sent', sent_at = now(), attempts = attempts + 1 
+		WHERE id = $1;`, id)
+
+	return err
+}
+
+func (p *PostgresStorage) MarkOutboxEmailFailed(id int, sendErr string, nextAttemptAt time.Time, final bool) error {
+	status := user.PendingEmail
+	if final {
+		status = user.FailedEmail
+	}
+
+	_, err := p.db.Exec(`UPDATE email_outbox SET status = $2, attempts = attempts + 1, last_error = $3, next_attempt_at = $4 
+		WHERE id = $1;`, id, status, sendErr, nextAttemptAt)
+
+	return err
+}
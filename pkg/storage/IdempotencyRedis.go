@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+const idempotencyPrefix = "idempotency_"
+
+// extendIdempotencyKeyScript продлевает ключ, только если он все еще занят тем же запросом
+var extendIdempotencyKeyScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// ReserveIdempotencyKey занимает ключ на время выполнения запроса. Если ключ уже есть,
+// возвращает false и сохраненную запись
+func (r *RedisStorage) ReserveIdempotencyKey(key string, fingerprint string, ttl time.Duration) (bool, user.IdempotentRequest, error) {
+	ctx := context.Background()
+	var existing user.IdempotentRequest
+
+	value, err := json.Marshal(user.IdempotentRequest{
+		Status:      user.IdempotencyInProgress,
+		Fingerprint: fingerprint,
+	})
+	if err != nil {
+		return false, existing, err
+	}
+
+	ok, err := r.client.SetNX(ctx, idempotencyPrefix+key, value, ttl).Result()
+	if err != nil || ok {
+		return ok, existing, err
+	}
+
+	data, err := r.client.Get(ctx, idempotencyPrefix+key).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			// Ключ истек между SetNX и Get - пробуем занять еще раз
+			return r.ReserveIdempotencyKey(key, fingerprint, ttl)
+		}
+		return false, existing, err
+	}
+
+	err = json.Unmarshal(data, &existing)
+	if err != nil {
+		return false, existing, err
+	}
+
+	return false, existing, nil
+}
+
+func (r *RedisStorage) ExtendIdempotencyKey(key string, fingerprint string, ttl time.Duration) error {
+	value, err := json.Marshal(user.IdempotentRequest{
+		Status:      user.IdempotencyInProgress,
+		Fingerprint: fingerprint,
+	})
+	if err != nil {
+		return err
+	}
+
+	return extendIdempotencyKeyScript.Run(context.Background(), r.client, []string{idempotencyPrefix + key},
+		string(value), ttl.Milliseconds()).Err()
+}
+
+func (r *RedisStorage) SaveIdempotentResponse(key string, resp user.IdempotentRequest, ttl time.Duration) error {
+	value, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+
+	return r.client.Set(context.Background(), idempotencyPrefix+key, value, ttl).Err()
+}
+
+func (r *RedisStorage) ReleaseIdempotencyKey(key string) error {
+	return r.client.Del(context.Background(), idempotencyPrefix+key).Err()
+}
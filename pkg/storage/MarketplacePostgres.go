@@ -0,0 +1,234 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/players"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/store"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/tournaments"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"strconv"
+	"strings"
+)
+
+var (
+	CardNotUnpackedError    = errors.New("карточку нужно распаковать перед продажей")
+	CardInActiveRosterError = errors.New("карточка игрока из состава активного турнира")
+	CardAlreadyListedError  = errors.New("карточка уже выставлена на продажу")
+	ListingNotFoundError    = errors.New("объявление не найдено или уже закрыто")
+	OwnListingPurchaseError = errors.New("нельзя купить собственную карточку")
+)
+
+const cardListingColumns = `l.id, l.card_id, l.seller_id, l.price, l.status, l.buyer_id, l.fee, l.created_at, l.closed_at`
+
+// lockOwnedCard блокирует карточку до конца tx и проверяет, что ею можно распоряжаться: она принадлежит
+// profileID, распакована и ее игрок не стоит в составе незавершенного турнира
+func (p *PostgresStorage) lockOwnedCard(tx *sqlx.Tx, profileID uuid.UUID, cardID int) error {
+	var card struct {
+		ProfileID uuid.UUID `db:"profile_id"`
+		PlayerID  int       `db:"player_id"`
+		Unpacked  bool      `db:"unpacked"`
+	}
+
+	err := tx.Get(&card, `SELECT profile_id, player_id, unpacked FROM player_cards WHERE id = $1 FOR UPDATE`, cardID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return PlayerCardNotFoundError
+		}
+		return err
+	}
+
+	if card.ProfileID != profileID {
+		return IncorrectPlayerCardUserID
+	}
+	if !card.Unpacked {
+		return CardNotUnpackedError
+	}
+
+	var inRoster bool
+	err = tx.Get(&inRoster, `SELECT EXISTS (SELECT 1 FROM user_roster r JOIN tournaments t ON t.id = r.tournament_id 
+		WHERE r.user_id = $1 AND $2 = ANY (r.roster) AND t.status_tournament <> 'finished')`, profileID, card.PlayerID)
+	if err != nil {
+		return err
+	}
+	if inRoster {
+		return CardInActiveRosterError
+	}
+
+	return nil
+}
+
+func (p *PostgresStorage) CreateCardListing(sellerID uuid.UUID, inp players.CreateListingInput) (players.CardListing, error) {
+	var listing players.CardListing
+
+	err := p.withTx(func(tx *sqlx.Tx) error {
+		err := p.lockOwnedCard(tx, sellerID, inp.CardID)
+		if err != nil {
+			return err
+		}
+
+		var listed bool
+		err = tx.Get(&listed, `SELECT EXISTS (SELECT 1 FROM card_listings WHERE card_id = $1 AND status = $2)`,
+			inp.CardID, players.ActiveListing)
+		if err != nil {
+			return err
+		}
+		if listed {
+			return CardAlreadyListedError
+		}
+
+		return tx.Get(&listing, `INSERT INTO card_listings AS l (card_id, seller_id, price) VALUES ($1, $2, $3) 
+			RETURNING `+cardListingColumns, inp.CardID, sellerID, inp.Price)
+	})
+	if err != nil {
+		return listing, err
+	}
+
+	return listing, nil
+}
+
+func (p *PostgresStorage) CancelCardListing(sellerID uuid.UUID, id int64) error {
+	result, err := p.db.Exec(`UPDATE card_listings SET status = $3, closed_at = now() 
+		WHERE id = $1 AND seller_id = $2 AND status = $4`, id, sellerID, players.CancelledListing, players.ActiveListing)
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return ListingNotFoundError
+	}
+
+	return nil
+}
+
+func (p *PostgresStorage) GetCardListings(filter players.CardListingsFilter) ([]players.CardListingResponse, error) {
+	listings := []players.CardListingResponse{}
+
+	conditions := []string{"l.status = $1"}
+	args := []interface{}{players.ActiveListing}
+	addCondition := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if filter.League != 0 {
+		addCondition("p.league = $%d", filter.League)
+	}
+	if filter.Position != 0 {
+		addCondition("p.position = $%d", filter.Position)
+	}
+	if filter.Rarity != 0 {
+		addCondition("pc.rarity = $%d", filter.Rarity)
+	}
+	if filter.TeamID != 0 {
+		addCondition("p.team_id = $%d", filter.TeamID)
+	}
+	if filter.Before != 0 {
+		addCondition("l.id < $%d", filter.Before)
+	}
+	args = append(args, filter.Limit)
+
+	query := `SELECT ` + cardListingColumns + `, pc.player_id, pc.rarity, pc.multiply, p.name, p.photo_link, p.team_id, 
+			t.team_name, p.position, p.league, up.nickname AS seller_nickname 
+		FROM card_listings l 
+		JOIN player_cards pc ON pc.id = l.card_id 
+		JOIN players p ON p.id = pc.player_id 
+		JOIN teams t ON t.team_id = p.team_id 
+		JOIN user_profile up ON up.id = l.seller_id 
+		WHERE ` + strings.Join(conditions, " AND ") + ` ORDER BY l.id DESC LIMIT $` + strconv.Itoa(len(args))
+
+	err := p.db.Select(&listings, query, args...)
+	if err != nil {
+		return listings, err
+	}
+
+	for i := range listings {
+		listings[i].RarityName = store.PlayerCardsRarityTitles[listings[i].Rarity]
+		listings[i].PositionName = players.PlayerPositionTitles[listings[i].Position]
+		listings[i].LeagueName = tournaments.LeagueTitles[listings[i].League]
+	}
+
+	return listings, nil
+}
+
+// BuyCardListing в одной транзакции закрывает объявление, переводит монеты продавцу и комиссию площадке
+// и передает карточку покупателю. Карточка перепроверяется: за время объявления продавец мог поставить
+// игрока в состав турнира
+func (p *PostgresStorage) BuyCardListing(buyerID uuid.UUID, id int64, feePercent int) (players.CardListing, error) {
+	var listing players.CardListing
+
+	err := p.withTx(func(tx *sqlx.Tx) error {
+		err := tx.Get(&listing, `SELECT `+cardListingColumns+` FROM card_listings l 
+			WHERE l.id = $1 AND l.status = $2 FOR UPDATE`, id, players.ActiveListing)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return ListingNotFoundError
+			}
+			return err
+		}
+		if listing.SellerID == buyerID {
+			return OwnListingPurchaseError
+		}
+
+		err = p.lockOwnedCard(tx, listing.SellerID, listing.CardID)
+		if err != nil {
+			return err
+		}
+
+		// Оба кошелька блокируются заранее в том же порядке, что и в PostLedgerTransfer, чтобы нехватка монет
+		// сообщалась на полную цену, а не на одну из двух проводок
+		first, second := buyerID, listing.SellerID
+		if second.String() < first.String() {
+			first, second = second, first
+		}
+		balances := make(map[uuid.UUID]int)
+		for _, profileID := range []uuid.UUID{first, second} {
+			if balances[profileID], err = p.lockWallet(tx, profileID); err != nil {
+				return err
+			}
+		}
+		if balances[buyerID] < listing.Price {
+			return &InsufficientFundsError{ProfileID: buyerID, Balance: balances[buyerID], Required: listing.Price}
+		}
+
+		fee := listing.Price * feePercent / 100
+		reference := strconv.FormatInt(listing.ID, 10)
+		err = p.PostLedgerTransfer(tx, user.LedgerTransfer{
+			From:          user.UserAccount(buyerID),
+			To:            user.UserAccount(listing.SellerID),
+			Amount:        listing.Price - fee,
+			Reason:        user.MarketplaceSaleReason,
+			ReferenceType: user.ListingReference,
+			ReferenceID:   reference,
+		})
+		if err != nil {
+			return err
+		}
+		err = p.PostLedgerTransfer(tx, user.LedgerTransfer{
+			From:          user.UserAccount(buyerID),
+			To:            user.HouseAccount(),
+			Amount:        fee,
+			Reason:        user.MarketplaceFeeReason,
+			ReferenceType: user.ListingReference,
+			ReferenceID:   reference,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(`UPDATE player_cards SET profile_id = $2 WHERE id = $1`, listing.CardID, buyerID)
+		if err != nil {
+			return err
+		}
+
+		return tx.Get(&listing, `UPDATE card_listings AS l SET status = $2, buyer_id = $3, fee = $4, closed_at = now() 
+			WHERE l.id = $1 RETURNING `+cardListingColumns, listing.ID, players.SoldListing, buyerID, fee)
+	})
+	if err != nil {
+		return listing, err
+	}
+
+	return listing, nil
+}
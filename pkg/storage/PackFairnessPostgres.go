@@ -0,0 +1,273 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/players"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/store"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	mathrand "math/rand"
+	"time"
+)
+
+var (
+	PackOpeningNotFoundError = errors.New("открытие набора не найдено")
+	PackSeedNotRevealedError = errors.New("сид сервера для этого открытия еще не раскрыт, смените пару сидов")
+)
+
+const packSeedColumns = `id, profile_id, server_seed, server_seed_hash, client_seed, nonce, created_at, revealed_at`
+
+// packInputs - снимок входных данных розыгрыша. Вместе с сидами его достаточно, чтобы пересчитать набор
+type packInputs struct {
+	Count      int                        `json:"count"`
+	Candidates []packCandidate            `json:"candidates"`
+	Owned      map[store.CardRarity][]int `json:"owned"`
+}
+
+type packCandidate struct {
+	ID       int              `json:"id"`
+	Position players.Position `json:"position"`
+	Cost     float32          `json:"cost"`
+}
+
+func (i packInputs) Value() (driver.Value, error) {
+	return json.Marshal(i)
+}
+
+func (i *packInputs) Scan(src interface{}) error {
+	b, ok := src.([]byte)
+	if !ok {
+		return errors.New("pack inputs must be jsonb")
+	}
+	return json.Unmarshal(b, i)
+}
+
+func newPackInputs(count int, candidates []players.PlayerResponse, owned map[store.CardRarity]map[int]struct{}) packInputs {
+	inputs := packInputs{Count: count, Owned: make(map[store.CardRarity][]int)}
+	for _, player := range candidates {
+		inputs.Candidates = append(inputs.Candidates, packCandidate{ID: player.ID, Position: player.Position, Cost: player.PlayerCost})
+	}
+	for rarity, ids := range owned {
+		for id := range ids {
+			inputs.Owned[rarity] = append(inputs.Owned[rarity], id)
+		}
+	}
+
+	return inputs
+}
+
+func (i packInputs) draw(seed string, table store.DropTable) store.PackCards {
+	candidates := make([]players.PlayerResponse, 0, len(i.Candidates))
+	for _, c := range i.Candidates {
+		candidates = append(candidates, players.PlayerResponse{ID: c.ID, Position: c.Position, PlayerCost: c.Cost})
+	}
+	owned := make(map[store.CardRarity]map[int]struct{})
+	for rarity, ids := range i.Owned {
+		owned[rarity] = make(map[int]struct{})
+		for _, id := range ids {
+			owned[rarity][id] = struct{}{}
+		}
+	}
+
+	return drawPack(packRand(seed), table, i.Count, candidates, owned)
+}
+
+func newPackSeedValue() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func packSeedHash(serverSeed string) string {
+	sum := sha256.Sum256([]byte(serverSeed))
+	return hex.EncodeToString(sum[:])
+}
+
+// packSeed - HMAC-SHA256 с ключом serverSeed от строки "clientSeed:nonce" в hex
+func packSeed(serverSeed, clientSeed string, nonce int) string {
+	mac := hmac.New(sha256.New, []byte(serverSeed))
+	mac.Write([]byte(fmt.Sprintf("%s:%d", clientSeed, nonce)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// packRand - генератор math/rand, засеянный первыми 8 байтами seed (big endian)
+func packRand(seed string) *mathrand.Rand {
+	b, _ := hex.DecodeString(seed)
+	if len(b) < 8 {
+		b = append(b, make([]byte, 8-len(b))...)
+	}
+	return mathrand.New(mathrand.NewSource(int64(binary.BigEndian.Uint64(b[:8]))))
+}
+
+// GetActivePackSeed возвращает текущую пару сидов пользователя, при необходимости создавая новую
+func (p *PostgresStorage) GetActivePackSeed(profileID uuid.UUID) (store.PackSeed, error) {
+	var seed store.PackSeed
+
+	err := p.ensurePackSeed(p.db, profileID)
+	if err != nil {
+		return seed, err
+	}
+
+	err = p.db.Get(&seed, `SELECT `+packSeedColumns+` FROM pack_seeds WHERE profile_id = $1 AND revealed_at IS NULL`, profileID)
+	if err != nil {
+		return seed, err
+	}
+
+	return seed, nil
+}
+
+// RotatePackSeed раскрывает текущий сид сервера и начинает новую пару с clientSeed
+func (p *PostgresStorage) RotatePackSeed(profileID uuid.UUID, clientSeed string) (store.PackSeedRotation, error) {
+	var rotation store.PackSeedRotation
+
+	serverSeed, err := newPackSeedValue()
+	if err != nil {
+		return rotation, err
+	}
+
+	err = p.withTx(func(tx *sqlx.Tx) error {
+		var previous store.RevealedPackSeed
+		err := tx.QueryRow(`UPDATE pack_seeds SET revealed_at = now() WHERE profile_id = $1 AND revealed_at IS NULL 
+			RETURNING server_seed, server_seed_hash, client_seed, nonce, revealed_at`, profileID).
+			Scan(&previous.ServerSeed, &previous.ServerSeedHash, &previous.ClientSeed, &previous.Nonce, &previous.RevealedAt)
+		switch err {
+		case nil:
+			rotation.Previous = &previous
+		case sql.ErrNoRows:
+		default:
+			return err
+		}
+
+		return tx.Get(&rotation.Current, `INSERT INTO pack_seeds (profile_id, server_seed, server_seed_hash, client_seed) 
+			VALUES ($1, $2, $3, $4) RETURNING `+packSeedColumns,
+			profileID, serverSeed, packSeedHash(serverSeed), clientSeed)
+	})
+	if err != nil {
+		return rotation, err
+	}
+
+	return rotation, nil
+}
+
+func (p *PostgresStorage) ensurePackSeed(db sqlx.Execer, profileID uuid.UUID) error {
+	serverSeed, err := newPackSeedValue()
+	if err != nil {
+		return err
+	}
+	clientSeed, err := newPackSeedValue()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec(`INSERT INTO pack_seeds (profile_id, server_seed, server_seed_hash, client_seed) 
+		VALUES ($1, $2, $3, $4) ON CONFLICT (profile_id) WHERE revealed_at IS NULL DO NOTHING`,
+		profileID, serverSeed, packSeedHash(serverSeed), clientSeed[:16])
+
+	return err
+}
+
+// nextPackSeed занимает следующий nonce активной пары. Строка пары блокируется до конца транзакции,
+// поэтому параллельные покупки получают разные nonce
+func (p *PostgresStorage) nextPackSeed(tx *sqlx.Tx, profileID uuid.UUID) (store.PackSeed, error) {
+	var seed store.PackSeed
+
+	err := p.ensurePackSeed(tx, profileID)
+	if err != nil {
+		return seed, err
+	}
+
+	err = tx.Get(&seed, `UPDATE pack_seeds SET nonce = nonce + 1 WHERE profile_id = $1 AND revealed_at IS NULL 
+		RETURNING `+packSeedColumns, profileID)
+	if err != nil {
+		return seed, err
+	}
+
+	return seed, nil
+}
+
+func (p *PostgresStorage) createPackOpening(tx *sqlx.Tx, buy store.BuyProductModel, seed store.PackSeed, seedHex string,
+	inputs packInputs, cards store.PackCards) (int64, error) {
+	var id int64
+
+	err := tx.Get(&id, `INSERT INTO pack_openings (profile_id, product_id, seed_id, nonce, seed, drop_table, inputs, cards) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`,
+		buy.ProfileID, buy.ID, seed.ID, seed.Nonce, seedHex, buy.DropTable, inputs, cards)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// VerifyPackOpening пересчитывает набор по раскрытым сидам и сохраненному снимку входных данных
+func (p *PostgresStorage) VerifyPackOpening(id int64) (store.PackVerification, error) {
+	var row struct {
+		ProductID      int             `db:"product_id"`
+		OpenedAt       time.Time       `db:"created_at"`
+		Nonce          int             `db:"nonce"`
+		Seed           string          `db:"seed"`
+		DropTable      store.DropTable `db:"drop_table"`
+		Inputs         packInputs      `db:"inputs"`
+		Cards          store.PackCards `db:"cards"`
+		ServerSeed     string          `db:"server_seed"`
+		ServerSeedHash string          `db:"server_seed_hash"`
+		ClientSeed     string          `db:"client_seed"`
+		RevealedAt     *time.Time      `db:"revealed_at"`
+	}
+
+	err := p.db.Get(&row, `SELECT o.product_id, o.created_at, o.nonce, o.seed, o.drop_table, o.inputs, o.cards, 
+			s.server_seed, s.server_seed_hash, s.client_seed, s.revealed_at 
+		FROM pack_openings o JOIN pack_seeds s ON s.id = o.seed_id WHERE o.id = $1`, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return store.PackVerification{}, PackOpeningNotFoundError
+		}
+		return store.PackVerification{}, err
+	}
+
+	if row.RevealedAt == nil {
+		return store.PackVerification{}, PackSeedNotRevealedError
+	}
+
+	seed := packSeed(row.ServerSeed, row.ClientSeed, row.Nonce)
+	verification := store.PackVerification{
+		OpeningID:      id,
+		ProductID:      row.ProductID,
+		OpenedAt:       row.OpenedAt,
+		ServerSeed:     row.ServerSeed,
+		ServerSeedHash: row.ServerSeedHash,
+		ClientSeed:     row.ClientSeed,
+		Nonce:          row.Nonce,
+		Seed:           seed,
+		DropTable:      row.DropTable,
+		Cards:          row.Cards,
+		DerivedCards:   row.Inputs.draw(seed, row.DropTable),
+	}
+	verification.Verified = packSeedHash(row.ServerSeed) == row.ServerSeedHash && seed == row.Seed &&
+		packCardsEqual(verification.Cards, verification.DerivedCards)
+
+	return verification, nil
+}
+
+func packCardsEqual(a, b store.PackCards) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
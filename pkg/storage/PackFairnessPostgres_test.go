@@ -0,0 +1,37 @@
+package storage
+
+import (
+	"encoding/json"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/store"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPackSeed(t *testing.T) {
+	assert.Equal(t, "91024ec49c5bec0b689e42892526320fce08337205c91de94c7a588c20d08eeb", packSeedHash("server-seed"))
+	assert.Equal(t, "ca1b10e5d9c089b287a41f5c72a33acd3b35de0eb4a654c0f04ef5fb5252475c", packSeed("server-seed", "client", 3))
+	assert.NotEqual(t, packSeed("server-seed", "client", 3), packSeed("server-seed", "client", 4))
+}
+
+func TestPackInputs_drawAfterRoundTrip(t *testing.T) {
+	table := store.DropTable{
+		RarityWeights: []store.RarityWeight{{Rarity: store.Silver, Weight: 9}, {Rarity: store.Gold, Weight: 1}},
+		Guarantees:    []store.RarityGuarantee{{Rarity: store.Gold, Count: 1}},
+		StarCost:      9,
+		StarChance:    20,
+	}
+	owned := map[store.CardRarity]map[int]struct{}{store.Silver: {4: {}, 5: {}}, store.Gold: {6: {}}}
+	inputs := newPackInputs(5, testPackCandidates(), owned)
+	seed := packSeed("server-seed", "client", 1)
+
+	cards := inputs.draw(seed, table)
+
+	raw, err := json.Marshal(inputs)
+	assert.NoError(t, err)
+	var restored packInputs
+	assert.NoError(t, json.Unmarshal(raw, &restored))
+
+	assert.Len(t, cards, 5)
+	assert.True(t, packCardsEqual(cards, restored.draw(seed, table)))
+	assert.False(t, packCardsEqual(cards, restored.draw(packSeed("server-seed", "client", 2), table)))
+}
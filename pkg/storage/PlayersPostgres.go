@@ -9,9 +9,7 @@ import (
 	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/tournaments"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
-	"math/rand"
 	"strings"
-	"time"
 )
 
 var (
@@ -49,6 +47,11 @@ func (p *PostgresStorage) CreatePlayers(playersData []players.Player) error {
 }
 
 func (p *PostgresStorage) GetPlayers(playersFilter players.PlayersFilter) ([]players.PlayerResponse, error) {
+	return selectPlayers(p.db, playersFilter)
+}
+
+// selectPlayers выполняет GetPlayers через q, чтобы его можно было вызвать и внутри транзакции
+func selectPlayers(q sqlx.Queryer, playersFilter players.PlayersFilter) ([]players.PlayerResponse, error) {
 	var res []players.PlayerResponse
 
 	query := "SELECT p.id, p.position, p.name, p.team_id, p.sweater_number, p.photo_link, p.league, p.player_cost, " +
@@ -88,7 +91,7 @@ func (p *PostgresStorage) GetPlayers(playersFilter players.PlayersFilter) ([]pla
 	query = strings.TrimSuffix(query, "AND")
 	query += " GROUP BY p.id, p.position, p.name, p.team_id, p.sweater_number, p.photo_link, p.league, p.player_cost, t.team_name, t.team_logo"
 
-	err := p.db.Select(&res, query)
+	err := sqlx.Select(q, &res, query)
 	if err != nil {
 		return res, err
 	}
@@ -122,9 +125,14 @@ func (p *PostgresStorage) GetPlayerByID(playerID int) (players.PlayerResponse, e
 }
 
 func (p *PostgresStorage) GetPlayerCards(filter players.PlayerCardsFilter) ([]players.PlayerCardResponse, error) {
+	return selectPlayerCards(p.db, filter)
+}
+
+// selectPlayerCards выполняет GetPlayerCards через q, чтобы его можно было вызвать и внутри транзакции
+func selectPlayerCards(q sqlx.Queryer, filter players.PlayerCardsFilter) ([]players.PlayerCardResponse, error) {
 	var res []players.PlayerCardResponse
 
-	query := "SELECT pc.id, pc.profile_id, pc.player_id, pc.rarity, pc.multiply, pc.bonus_metric, pc.unpacked, p.position, p.name, p.team_id, p.sweater_number, p.photo_link, p.league, t.team_name, t.team_logo FROM player_cards pc INNER JOIN players p ON pc.player_id = p.id INNER JOIN teams t ON p.team_id = t.team_id WHERE 1=1"
+	query := "SELECT pc.id, pc.profile_id, pc.player_id, pc.rarity, pc.multiply, pc.bonus_metric, pc.unpacked, pc.pack_opening_id, p.position, p.name, p.team_id, p.sweater_number, p.photo_link, p.league, t.team_name, t.team_logo FROM player_cards pc INNER JOIN players p ON pc.player_id = p.id INNER JOIN teams t ON p.team_id = t.team_id WHERE pc.burned_at IS NULL"
 
 	if filter.League != 0 {
 		query += fmt.Sprintf(" AND p.league = %d", filter.League)
@@ -148,7 +156,7 @@ func (p *PostgresStorage) GetPlayerCards(filter players.PlayerCardsFilter) ([]pl
 
 	query += " ORDER BY pc.id"
 
-	err := p.db.Select(&res, query)
+	err := sqlx.Select(q, &res, query)
 	if err != nil {
 		return res, err
 	}
@@ -167,33 +175,37 @@ func (p *PostgresStorage) GetPlayerCards(filter players.PlayerCardsFilter) ([]pl
 	return res, nil
 }
 
+// AddPlayerCards открывает набор. Сид берется первым: его строка блокируется до конца транзакции,
+// поэтому параллельные покупки того же пользователя видят карточки, полученные предыдущей
 func (p *PostgresStorage) AddPlayerCards(tx *sqlx.Tx, buy store.BuyProductModel) error {
-	allPlayers, err := p.GetPlayers(players.PlayersFilter{League: buy.League})
+	seed, err := p.nextPackSeed(tx, buy.ProfileID)
 	if err != nil {
 		return err
 	}
 
-	userCards, err := p.GetPlayerCards(players.PlayerCardsFilter{ProfileID: buy.ProfileID, League: buy.League, Rarity: buy.Rarity})
+	allPlayers, err := selectPlayers(tx, players.PlayersFilter{League: buy.League})
 	if err != nil {
 		return err
 	}
 
-	allPlayerIDs := getUniquePlayers(allPlayers, userCards)
+	userCards, err := selectPlayerCards(tx, players.PlayerCardsFilter{ProfileID: buy.ProfileID, League: buy.League})
+	if err != nil {
+		return err
+	}
 
-	if len(allPlayerIDs) == 0 {
+	seedHex := packSeed(seed.ServerSeed, seed.ClientSeed, seed.Nonce)
+	inputs := newPackInputs(buy.PlayerCardsCount, allPlayers, ownedPlayersByRarity(userCards))
+	cards := inputs.draw(seedHex, buy.DropTable)
+	if len(cards) == 0 {
 		return GetAllCardsError
 	}
 
-	selectedPlayerIDs := generateCards(allPlayerIDs)
-
-	cardsCount := buy.PlayerCardsCount
-	if len(selectedPlayerIDs) < buy.PlayerCardsCount {
-		cardsCount = len(selectedPlayerIDs)
+	openingID, err := p.createPackOpening(tx, buy, seed, seedHex, inputs, cards)
+	if err != nil {
+		return err
 	}
 
-	selectedPlayerIDs = selectedPlayerIDs[:cardsCount]
-
-	err = p.InsertPlayerCards(tx, buy, selectedPlayerIDs)
+	err = p.insertPlayerCards(tx, buy, openingID, cards)
 	if err != nil {
 		return err
 	}
@@ -201,51 +213,26 @@ func (p *PostgresStorage) AddPlayerCards(tx *sqlx.Tx, buy store.BuyProductModel)
 	return nil
 }
 
-func getUniquePlayers(allPlayers []players.PlayerResponse, userCards []players.PlayerCardResponse) map[int]struct{} {
-	allPlayerIDs := make(map[int]struct{})
-	for _, player := range allPlayers {
-		allPlayerIDs[player.ID] = struct{}{}
-	}
-
-	userCardPlayerIDs := make(map[int]struct{})
+func ownedPlayersByRarity(userCards []players.PlayerCardResponse) map[store.CardRarity]map[int]struct{} {
+	owned := make(map[store.CardRarity]map[int]struct{})
 	for _, card := range userCards {
-		userCardPlayerIDs[card.PlayerID] = struct{}{}
-	}
-
-	for playerID := range userCardPlayerIDs {
-		delete(allPlayerIDs, playerID)
-	}
-
-	return allPlayerIDs
-}
-
-func generateCards(allPlayers map[int]struct{}) []int {
-	var selectedPlayerIDs []int
-
-	rand.Seed(time.Now().UnixNano())
-	for playerID := range allPlayers {
-		selectedPlayerIDs = append(selectedPlayerIDs, playerID)
+		if owned[card.Rarity] == nil {
+			owned[card.Rarity] = make(map[int]struct{})
+		}
+		owned[card.Rarity][card.PlayerID] = struct{}{}
 	}
 
-	rand.Shuffle(len(selectedPlayerIDs), func(i, j int) {
-		selectedPlayerIDs[i], selectedPlayerIDs[j] = selectedPlayerIDs[j], selectedPlayerIDs[i]
-	})
-
-	return selectedPlayerIDs
+	return owned
 }
 
-func (p *PostgresStorage) InsertPlayerCards(tx *sqlx.Tx, buy store.BuyProductModel, selectedPlayerIDs []int) error {
-	query := `INSERT INTO player_cards (profile_id, player_id, rarity, multiply, bonus_metric, unpacked) VALUES `
+func (p *PostgresStorage) insertPlayerCards(tx *sqlx.Tx, buy store.BuyProductModel, openingID int64, cards store.PackCards) error {
+	query := `INSERT INTO player_cards (profile_id, player_id, rarity, multiply, bonus_metric, unpacked, pack_opening_id) VALUES `
 	var valueStrings []string
 	var valueArgs []interface{}
 
-	for idx, playerID := range selectedPlayerIDs {
-		playerData, err := p.GetPlayerByID(playerID)
-		if err != nil {
-			return err
-		}
-		valueStrings = append(valueStrings, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d)", idx*6+1, idx*6+2, idx*6+3, idx*6+4, idx*6+5, idx*6+6))
-		valueArgs = append(valueArgs, buy.ProfileID, playerID, buy.Rarity, store.CardMultiply[buy.Rarity], playerData.Position, false)
+	for idx, card := range cards {
+		valueStrings = append(valueStrings, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d, $%d)", idx*7+1, idx*7+2, idx*7+3, idx*7+4, idx*7+5, idx*7+6, idx*7+7))
+		valueArgs = append(valueArgs, buy.ProfileID, card.PlayerID, card.Rarity, store.CardMultiply[card.Rarity], card.Position, false, openingID)
 	}
 
 	query += strings.Join(valueStrings, ", ")
@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"database/sql"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/google/uuid"
+)
+
+// DefaultNotificationPreferences сохраняются при регистрации. Для старых профилей без строки настроек
+// они же действуют, пока пользователь ни разу не сохранял настройки
+var DefaultNotificationPreferences = user.NotificationPreferences{SecurityAlerts: true, TradeOffers: true}
+
+func (p *PostgresStorage) GetNotificationPreferences(profileID uuid.UUID) (user.NotificationPreferences, error) {
+	var prefs user.NotificationPreferences
+
+	query := `SELECT tournament_reminders, tournament_results, store_offers, security_alerts, trade_offers
+			FROM notification_preferences WHERE profile_id = $1`
+	err := p.db.Get(&prefs, query, profileID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return DefaultNotificationPreferences, nil
+		}
+		return prefs, err
+	}
+
+	return prefs, nil
+}
+
+// UpdateNotificationPreferences сохраняет настройки и поддерживает старый флаг email_subscription
+func (p *PostgresStorage) UpdateNotificationPreferences(profileID uuid.UUID, prefs user.NotificationPreferences) error {
+	tx, err := p.db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`INSERT INTO notification_preferences 
+    		(profile_id, tournament_reminders, tournament_results, store_offers, security_alerts, trade_offers, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, now())
+			ON CONFLICT (profile_id) DO UPDATE SET tournament_reminders = EXCLUDED.tournament_reminders,
+			                                       tournament_results = EXCLUDED.tournament_results,
+			                                       store_offers = EXCLUDED.store_offers,
+			                                       security_alerts = EXCLUDED.security_alerts,
+			                                       trade_offers = EXCLUDED.trade_offers,
+			                                       updated_at = now();`,
+		profileID,
+		prefs.TournamentReminders,
+		prefs.TournamentResults,
+		prefs.StoreOffers,
+		prefs.SecurityAlerts,
+		prefs.TradeOffers,
+	)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	_, err = tx.Exec(`UPDATE user_contacts SET email_subscription = $1 WHERE profile_id = $2;`,
+		prefs.TournamentReminders || prefs.TournamentResults || prefs.StoreOffers,
+		profileID,
+	)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"context"
+	"github.com/redis/go-redis/v9"
+	"strconv"
+	"time"
+)
+
+const rateLimitPrefix = "rate_limit_"
+
+// Счетчик и время его жизни изменяются атомарно, чтобы окно не продлевалось при каждом запросе
+var incrementCounterScript = redis.NewScript(`
+local count = redis.call('INCR', KEYS[1])
+if count == 1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return {count, redis.call('PTTL', KEYS[1])}
+`)
+
+func (r *RedisStorage) IncrementCounter(key string, window time.Duration) (int64, time.Duration, error) {
+	res, err := incrementCounterScript.Run(context.Background(), r.client,
+		[]string{rateLimitPrefix + key}, window.Milliseconds()).Int64Slice()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return res[0], time.Duration(res[1]) * time.Millisecond, nil
+}
+
+func (r *RedisStorage) GetCounter(key string) (int64, time.Duration, error) {
+	ctx := context.Background()
+
+	val, err := r.client.Get(ctx, rateLimitPrefix+key).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return 0, 0, nil
+		}
+		return 0, 0, err
+	}
+
+	count, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	ttl, err := r.client.PTTL(ctx, rateLimitPrefix+key).Result()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return count, ttl, nil
+}
+
+func (r *RedisStorage) DeleteCounter(key string) error {
+	return r.client.Del(context.Background(), rateLimitPrefix+key).Err()
+}
+
+// SetCooldown возвращает false и оставшееся время, если ключ уже установлен
+func (r *RedisStorage) SetCooldown(key string, ttl time.Duration) (bool, time.Duration, error) {
+	ctx := context.Background()
+
+	ok, err := r.client.SetNX(ctx, rateLimitPrefix+key, 1, ttl).Result()
+	if err != nil {
+		return false, 0, err
+	}
+	if ok {
+		return true, 0, nil
+	}
+
+	remaining, err := r.client.PTTL(ctx, rateLimitPrefix+key).Result()
+	if err != nil {
+		return false, 0, err
+	}
+
+	return false, remaining, nil
+}
@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/jmoiron/sqlx"
+)
+
+var ReconciliationRunNotFoundError = errors.New("отчет сверки не найден")
+
+// ReconcileBalances сверяет балансы с главной книгой на одном снимке данных и сохраняет отчет.
+// Баланс пользователя должен равняться сумме проводок его счета: стартовый бонус тоже записан проводкой
+func (p *PostgresStorage) ReconcileBalances() (user.ReconciliationRun, error) {
+	var run user.ReconciliationRun
+
+	err := p.withTx(func(tx *sqlx.Tx) error {
+		_, err := tx.Exec(`SET TRANSACTION ISOLATION LEVEL REPEATABLE READ`)
+		if err != nil {
+			return err
+		}
+
+		err = tx.Get(&run.ID, `INSERT INTO reconciliation_runs DEFAULT VALUES RETURNING id`)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(`INSERT INTO reconciliation_discrepancies (run_id, kind, profile_id, expected, actual)
+			SELECT $1, $2, up.id, COALESCE(SUM(e.amount), 0), up.coins
+			FROM user_profile up
+				LEFT JOIN ledger_accounts a ON a.profile_id = up.id
+				LEFT JOIN ledger_entries e ON e.account_id = a.id
+			GROUP BY up.id, up.coins
+			HAVING COALESCE(SUM(e.amount), 0) <> up.coins`, run.ID, user.BalanceDiscrepancy)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(`INSERT INTO reconciliation_discrepancies (run_id, kind, transaction_id, expected, actual)
+			SELECT $1, $2, transaction_id, 0, SUM(amount)
+			FROM ledger_entries
+			GROUP BY transaction_id
+			HAVING SUM(amount) <> 0`, run.ID, user.UnbalancedTransactionDiscrepancy)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(`INSERT INTO reconciliation_discrepancies (run_id, kind, tournament_id, expected, actual)
+			SELECT $1, $2, a.tournament_id, 0, SUM(e.amount)
+			FROM ledger_accounts a
+				JOIN ledger_entries e ON e.account_id = a.id
+			WHERE a.kind = 'prize_pool'
+			GROUP BY a.id, a.tournament_id
+			HAVING SUM(e.amount) < 0`, run.ID, user.NegativePrizePoolDiscrepancy)
+		if err != nil {
+			return err
+		}
+
+		return tx.Get(&run, `UPDATE reconciliation_runs SET finished_at = now(),
+				checked_profiles = (SELECT COUNT(*) FROM user_profile),
+				discrepancies = (SELECT COUNT(*) FROM reconciliation_discrepancies WHERE run_id = $1)
+			WHERE id = $1
+			RETURNING id, started_at, finished_at, checked_profiles, discrepancies`, run.ID)
+	})
+	if err != nil {
+		return run, err
+	}
+
+	return run, nil
+}
+
+func (p *PostgresStorage) GetReconciliationRuns(limit int) ([]user.ReconciliationRun, error) {
+	var runs []user.ReconciliationRun
+
+	err := p.db.Select(&runs, `SELECT id, started_at, finished_at, checked_profiles, discrepancies 
+		FROM reconciliation_runs ORDER BY id DESC LIMIT $1`, limit)
+	if err != nil {
+		return runs, err
+	}
+	if runs == nil {
+		runs = []user.ReconciliationRun{}
+	}
+
+	return runs, nil
+}
+
+func (p *PostgresStorage) GetReconciliationReport(runID int64) (user.ReconciliationReport, error) {
+	var report user.ReconciliationReport
+
+	err := p.db.Get(&report.ReconciliationRun, `SELECT id, started_at, finished_at, checked_profiles, discrepancies 
+		FROM reconciliation_runs WHERE id = $1`, runID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return report, ReconciliationRunNotFoundError
+		}
+		return report, err
+	}
+
+	err = p.db.Select(&report.Items, `SELECT id, kind, profile_id, tournament_id, transaction_id, expected, actual
+		FROM reconciliation_discrepancies WHERE run_id = $1 ORDER BY id`, runID)
+	if err != nil {
+		return report, err
+	}
+	if report.Items == nil {
+		report.Items = []user.ReconciliationDiscrepancy{}
+	}
+
+	return report, nil
+}
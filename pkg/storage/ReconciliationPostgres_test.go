@@ -0,0 +1,52 @@
+package storage
+
+import (
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestReconcileBalances(t *testing.T) {
+	p := newTestPostgresStorage(t)
+	consistent := createTestWallet(t, p, 0)
+	tampered := createTestWallet(t, p, 0)
+
+	for _, profileID := range []uuid.UUID{consistent, tampered} {
+		err := p.withTx(func(tx *sqlx.Tx) error {
+			return p.PostLedgerTransfer(tx, user.LedgerTransfer{
+				From:          user.HouseAccount(),
+				To:            user.UserAccount(profileID),
+				Amount:        1000,
+				Reason:        user.GrantReason,
+				ReferenceType: user.SignupReference,
+			})
+		})
+		require.NoError(t, err)
+	}
+	// Изменение баланса в обход главной книги - то, что должна находить сверка
+	_, err := p.db.Exec(`UPDATE user_profile SET coins = coins + 500 WHERE id = $1`, tampered)
+	require.NoError(t, err)
+
+	run, err := p.ReconcileBalances()
+	require.NoError(t, err)
+	t.Cleanup(func() { p.db.Exec(`DELETE FROM reconciliation_runs WHERE id = $1`, run.ID) })
+	assert.NotNil(t, run.FinishedAt)
+
+	report, err := p.GetReconciliationReport(run.ID)
+	require.NoError(t, err)
+
+	var found []user.ReconciliationDiscrepancy
+	for _, item := range report.Items {
+		if item.ProfileID != nil && (*item.ProfileID == consistent || *item.ProfileID == tampered) {
+			found = append(found, item)
+		}
+	}
+	require.Len(t, found, 1)
+	assert.Equal(t, tampered, *found[0].ProfileID)
+	assert.Equal(t, user.BalanceDiscrepancy, found[0].Kind)
+	assert.Equal(t, int64(1000), found[0].Expected)
+	assert.Equal(t, int64(1500), found[0].Actual)
+}
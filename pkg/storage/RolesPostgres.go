@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"database/sql"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+func (p *PostgresStorage) GetProfileRoles(profileID uuid.UUID) ([]string, error) {
+	var roles pq.StringArray
+
+	err := p.db.Get(&roles, `SELECT roles FROM user_profile WHERE id = $1`, profileID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, UserDoesNotExistError
+		}
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+func (p *PostgresStorage) AddProfileRole(profileID uuid.UUID, role string) error {
+	result, err := p.db.Exec(`UPDATE user_profile
+		SET roles = CASE WHEN $2 = ANY(roles) THEN roles ELSE array_append(roles, $2) END
+		WHERE id = $1`, profileID, role)
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return UserDoesNotExistError
+	}
+
+	return nil
+}
+
+func (p *PostgresStorage) RemoveProfileRole(profileID uuid.UUID, role string) error {
+	result, err := p.db.Exec(`UPDATE user_profile SET roles = array_remove(roles, $2) WHERE id = $1`, profileID, role)
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return UserDoesNotExistError
+	}
+
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 
 var (
 	RefreshTokenNotFoundError = errors.New("refresh токен не найден")
+	SessionNotFoundError      = errors.New("сессия не найдена")
 )
 
 func (p *PostgresStorage) CreateSession(session user.RefreshSession) error {
@@ -18,10 +19,15 @@ func (p *PostgresStorage) CreateSession(session user.RefreshSession) error {
 		return err
 	}
 
-	_, err = tx.Exec(`INSERT INTO refresh_sessions (profile_id, refresh_token_id, expires_in) VALUES ($1, $2, $3);`,
+	_, err = tx.Exec(`INSERT INTO refresh_sessions (profile_id, family_id, refresh_token_id, expires_in, user_agent, ip, device) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7);`,
 		session.ProfileID.String(),
+		session.FamilyID,
 		session.RefreshToken,
 		session.ExpiresAt,
+		session.UserAgent,
+		session.IP,
+		session.Device,
 	)
 	if err != nil {
 		tx.Rollback()
@@ -34,8 +40,8 @@ func (p *PostgresStorage) CreateSession(session user.RefreshSession) error {
 func (p *PostgresStorage) GetSessionByRefreshToken(refreshTokenID string) (user.RefreshSession, error) {
 	var session user.RefreshSession
 
-	err := p.db.Get(&session, `SELECT profile_id, refresh_token_id, issued_at, expires_in FROM refresh_sessions 
-                                                           WHERE refresh_token_id = $1;`, refreshTokenID)
+	err := p.db.Get(&session, `SELECT profile_id, family_id, refresh_token_id, issued_at, expires_in, rotated_at, 
+       user_agent, ip, device FROM refresh_sessions WHERE refresh_token_id = $1;`, refreshTokenID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return session, RefreshTokenNotFoundError
@@ -73,3 +79,116 @@ func (p *PostgresStorage) DeleteAllSessionsByProfileID(tx *sqlx.Tx, profileID uu
 
 	return nil
 }
+
+func (p *PostgresStorage) RotateSession(refreshTokenID string) (bool, error) {
+	result, err := p.db.Exec(`UPDATE refresh_sessions SET rotated_at = now() 
+                        WHERE refresh_token_id = $1 AND rotated_at IS NULL;`, refreshTokenID)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
+
+func (p *PostgresStorage) DeleteSessionsByFamilyID(familyID uuid.UUID) error {
+	_, err := p.db.Exec(`DELETE FROM refresh_sessions WHERE family_id = $1;`, familyID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteExpiredSessionFamilies удаляет семьи сессий, в которых истек срок у всех refresh токенов.
+// Использованные токены хранятся только для обнаружения повторного использования, пока семья жива
+func (p *PostgresStorage) DeleteExpiredSessionFamilies(limit int) (int, error) {
+	result, err := p.db.Exec(`DELETE FROM refresh_sessions WHERE family_id IN (
+		SELECT family_id FROM refresh_sessions GROUP BY family_id HAVING MAX(expires_in) <= now() LIMIT $1);`, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
+
+func (p *PostgresStorage) CreateSecurityEvent(event user.SecurityEvent) error {
+	_, err := p.db.Exec(`INSERT INTO security_events (profile_id, event_type, details) VALUES ($1, $2, $3);`,
+		event.ProfileID,
+		event.EventType,
+		event.Details,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *PostgresStorage) GetActiveSessionsByProfileID(profileID uuid.UUID) ([]user.SessionInfo, error) {
+	var sessions []user.SessionInfo
+
+	err := p.db.Select(&sessions, `SELECT s.family_id, s.user_agent, s.ip, s.device, s.issued_at AS last_used_at, s.expires_in,
+       (SELECT MIN(f.issued_at) FROM refresh_sessions f WHERE f.family_id = s.family_id) AS created_at
+		FROM refresh_sessions s WHERE s.profile_id = $1 AND s.rotated_at IS NULL AND s.expires_in > now() 
+		ORDER BY s.issued_at DESC;`, profileID)
+	if err != nil {
+		return sessions, err
+	}
+	if sessions == nil {
+		sessions = []user.SessionInfo{}
+	}
+
+	return sessions, nil
+}
+
+func (p *PostgresStorage) DeleteSessionFamily(profileID uuid.UUID, familyID uuid.UUID) error {
+	result, err := p.db.Exec(`DELETE FROM refresh_sessions WHERE profile_id = $1 AND family_id = $2;`, profileID, familyID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return SessionNotFoundError
+	}
+
+	return nil
+}
+
+func (p *PostgresStorage) DeleteOtherSessions(profileID uuid.UUID, currentFamilyID uuid.UUID) error {
+	_, err := p.db.Exec(`DELETE FROM refresh_sessions WHERE profile_id = $1 AND family_id <> $2;`, profileID, currentFamilyID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *PostgresStorage) RevokeAllSessions(profileID uuid.UUID) error {
+	tx, err := p.db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	err = p.DeleteAllSessionsByProfileID(tx, profileID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,52 @@
+package storage
+
+import (
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func createTestSession(t *testing.T, p *PostgresStorage, profileID uuid.UUID, familyID uuid.UUID, expiresAt time.Time, rotated bool) string {
+	refreshToken := uuid.NewString()
+	require.NoError(t, p.CreateSession(user.RefreshSession{
+		ProfileID:    profileID,
+		FamilyID:     familyID,
+		RefreshToken: refreshToken,
+		ExpiresAt:    expiresAt,
+	}))
+	if rotated {
+		rotatedNow, err := p.RotateSession(refreshToken)
+		require.NoError(t, err)
+		require.True(t, rotatedNow)
+	}
+
+	return refreshToken
+}
+
+func TestDeleteExpiredSessionFamilies_KeepsRotatedTokensOfLiveFamily(t *testing.T) {
+	p := newTestPostgresStorage(t)
+	profileID := createTestWallet(t, p, 0)
+
+	expiredFamily, liveFamily := uuid.New(), uuid.New()
+	createTestSession(t, p, profileID, expiredFamily, time.Now().Add(-2*time.Hour), true)
+	createTestSession(t, p, profileID, expiredFamily, time.Now().Add(-time.Hour), false)
+	// Использованный токен живой семьи нужен для обнаружения повторного использования, даже если его срок истек
+	reused := createTestSession(t, p, profileID, liveFamily, time.Now().Add(-time.Hour), true)
+	current := createTestSession(t, p, profileID, liveFamily, time.Now().Add(time.Hour), false)
+
+	deleted, err := p.DeleteExpiredSessionFamilies(1000)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, deleted, 2)
+
+	var families []uuid.UUID
+	require.NoError(t, p.db.Select(&families, `SELECT DISTINCT family_id FROM refresh_sessions WHERE profile_id = $1`, profileID))
+	assert.Equal(t, []uuid.UUID{liveFamily}, families)
+
+	_, err = p.GetSessionByRefreshToken(reused)
+	assert.NoError(t, err)
+	_, err = p.GetSessionByRefreshToken(current)
+	assert.NoError(t, err)
+}
@@ -5,6 +5,9 @@ import (
 	"errors"
 	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/store"
 	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"strconv"
 )
 
 var (
@@ -12,11 +15,19 @@ var (
 	GetAllCardsError   = errors.New("Вы получили все карточки этого набора")
 )
 
+// productOnSaleCondition отбирает товары, которые сейчас можно купить
+const productOnSaleCondition = `active AND archived_at IS NULL AND (available_from IS NULL OR available_from <= now()) 
+		AND (available_until IS NULL OR available_until > now())`
+
+const adminProductColumns = `id, product_name, price, league, rarity, player_cards_count, COALESCE(photo_link, '') AS photo_link, 
+		active, available_from, available_until, sort_order, archived_at, drop_table`
+
 func (p *PostgresStorage) GetAllProducts() ([]store.Product, error) {
 	var products []store.Product
 
-	err := p.db.Select(&products, `SELECT id, product_name, price, league, rarity, player_cards_count, photo_link
-														FROM fantasy_store`)
+	err := p.db.Select(&products, `SELECT id, product_name, price, league, rarity, player_cards_count, 
+       		COALESCE(photo_link, '') AS photo_link
+		FROM fantasy_store WHERE `+productOnSaleCondition+` ORDER BY sort_order, id`)
 	if err != nil {
 		return products, err
 	}
@@ -36,8 +47,9 @@ func (p *PostgresStorage) GetAllProducts() ([]store.Product, error) {
 func (p *PostgresStorage) GetProductByID(id int) (store.Product, error) {
 	var product store.Product
 
-	err := p.db.Get(&product, `SELECT id, product_name, price, league, rarity, player_cards_count, photo_link
-														FROM fantasy_store WHERE id = $1`, id)
+	err := p.db.Get(&product, `SELECT id, product_name, price, league, rarity, player_cards_count, 
+       		COALESCE(photo_link, '') AS photo_link
+		FROM fantasy_store WHERE id = $1 AND `+productOnSaleCondition, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return product, IncorrectProductID
@@ -51,31 +63,177 @@ func (p *PostgresStorage) GetProductByID(id int) (store.Product, error) {
 	return product, nil
 }
 
-func (p *PostgresStorage) BuyProduct(buy store.BuyProductModel) error {
-	coinTr := user.CoinTransactionsModel{
-		ProfileID:          buy.ProfileID,
-		TransactionDetails: buy.Details,
-		Amount:             buy.Coins,
-		Status:             user.SuccessTransaction,
+// GetProductDropTable возвращает правила выпадения товара в продаже. Для товара без настроек
+// все карточки получают редкость товара
+func (p *PostgresStorage) GetProductDropTable(id int) (store.DropTable, error) {
+	var row struct {
+		Rarity    store.CardRarity `db:"rarity"`
+		DropTable *store.DropTable `db:"drop_table"`
 	}
 
-	tx, err := p.db.Beginx()
+	err := p.db.Get(&row, `SELECT rarity, drop_table FROM fantasy_store WHERE id = $1 AND `+productOnSaleCondition, id)
 	if err != nil {
-		return err
+		if err == sql.ErrNoRows {
+			return store.DropTable{}, IncorrectProductID
+		}
+		return store.DropTable{}, err
 	}
 
-	err = p.UpdateBalance(tx, buy.ProfileID, buy.Coins)
+	if row.DropTable == nil {
+		return store.DefaultDropTable(row.Rarity), nil
+	}
+	return *row.DropTable, nil
+}
+
+func (p *PostgresStorage) UpdateProductDropTable(id int, table store.DropTable) error {
+	result, err := p.db.Exec(`UPDATE fantasy_store SET drop_table = $2 WHERE id = $1 AND archived_at IS NULL`, id, table)
 	if err != nil {
 		return err
 	}
-	err = p.CreateCoinTransaction(tx, coinTr)
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return IncorrectProductID
+	}
+
+	return nil
+}
+
+func (p *PostgresStorage) BuyProduct(buy store.BuyProductModel) error {
+	purchase := user.LedgerTransfer{
+		From:          user.UserAccount(buy.ProfileID),
+		To:            user.HouseAccount(),
+		Amount:        -buy.Coins,
+		Reason:        user.PurchaseReason,
+		ReferenceType: user.ProductReference,
+		ReferenceID:   strconv.Itoa(buy.ID),
+	}
+
+	return p.withTx(func(tx *sqlx.Tx) error {
+		err := p.PostLedgerTransfer(tx, purchase)
+		if err != nil {
+			return err
+		}
+
+		return p.AddPlayerCards(tx, buy)
+	})
+}
+
+func (p *PostgresStorage) GetAdminProducts(filter store.AdminProductsFilter) ([]store.AdminProduct, error) {
+	var products []store.AdminProduct
+
+	query := `SELECT ` + adminProductColumns + ` FROM fantasy_store`
+	if !filter.IncludeArchived {
+		query += ` WHERE archived_at IS NULL`
+	}
+	query += ` ORDER BY sort_order, id`
+
+	err := p.db.Select(&products, query)
+	if err != nil {
+		return products, err
+	}
+	if products == nil {
+		products = []store.AdminProduct{}
+	}
+
+	return products, nil
+}
+
+// GetAdminProductByID возвращает товар независимо от того, в продаже ли он
+func (p *PostgresStorage) GetAdminProductByID(id int) (store.AdminProduct, error) {
+	var product store.AdminProduct
+
+	err := p.db.Get(&product, `SELECT `+adminProductColumns+` FROM fantasy_store WHERE id = $1`, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return product, IncorrectProductID
+		}
+		return product, err
+	}
+
+	return product, nil
+}
+
+// CreateProduct добавляет товар в конец витрины, если порядок не указан
+func (p *PostgresStorage) CreateProduct(inp store.ProductInput) (store.AdminProduct, error) {
+	var product store.AdminProduct
+
+	err := p.db.Get(&product, `INSERT INTO fantasy_store (product_name, price, league, rarity, player_cards_count, 
+			active, available_from, available_until, sort_order) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, 
+			CASE WHEN $9 = 0 THEN (SELECT COALESCE(MAX(sort_order), 0) + 1 FROM fantasy_store) ELSE $9 END) 
+		RETURNING `+adminProductColumns,
+		inp.ProductName, inp.Price, inp.League, inp.Rarity, inp.PlayerCardsCount,
+		*inp.Active, inp.AvailableFrom, inp.AvailableUntil, inp.SortOrder)
+	if err != nil {
+		return product, err
+	}
+
+	return product, nil
+}
+
+// UpdateProduct меняет товар, кроме архивного. Если порядок не указан, товар остается на своем месте
+func (p *PostgresStorage) UpdateProduct(id int, inp store.ProductInput) (store.AdminProduct, error) {
+	var product store.AdminProduct
+
+	err := p.db.Get(&product, `UPDATE fantasy_store SET product_name = $2, price = $3, league = $4, rarity = $5, 
+			player_cards_count = $6, active = $7, available_from = $8, available_until = $9, 
+			sort_order = CASE WHEN $10 = 0 THEN sort_order ELSE $10 END
+		WHERE id = $1 AND archived_at IS NULL
+		RETURNING `+adminProductColumns,
+		id, inp.ProductName, inp.Price, inp.League, inp.Rarity, inp.PlayerCardsCount,
+		*inp.Active, inp.AvailableFrom, inp.AvailableUntil, inp.SortOrder)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return product, IncorrectProductID
+		}
+		return product, err
+	}
+
+	return product, nil
+}
+
+// ArchiveProduct снимает товар с продажи навсегда. Строка остается, чтобы не терять историю покупок
+func (p *PostgresStorage) ArchiveProduct(id int) error {
+	result, err := p.db.Exec(`UPDATE fantasy_store SET active = FALSE, archived_at = now() 
+		WHERE id = $1 AND archived_at IS NULL`, id)
 	if err != nil {
 		return err
 	}
-	err = p.AddPlayerCards(tx, buy)
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return IncorrectProductID
+	}
+
+	return nil
+}
+
+// ReorderProducts выставляет порядок показа по позиции id в списке. Неизвестный id отменяет всю операцию
+func (p *PostgresStorage) ReorderProducts(ids []int) error {
+	return p.withTx(func(tx *sqlx.Tx) error {
+		result, err := tx.Exec(`UPDATE fantasy_store SET sort_order = u.position 
+			FROM unnest($1::INTEGER[]) WITH ORDINALITY AS u(id, position) 
+			WHERE fantasy_store.id = u.id AND fantasy_store.archived_at IS NULL`, pq.Array(ids))
+		if err != nil {
+			return err
+		}
+
+		if rowsAffected, _ := result.RowsAffected(); rowsAffected != int64(len(ids)) {
+			return IncorrectProductID
+		}
+
+		return nil
+	})
+}
+
+func (p *PostgresStorage) UpdateProductPhotoLink(id int, photoLink string) error {
+	result, err := p.db.Exec(`UPDATE fantasy_store SET photo_link = $2 WHERE id = $1 AND archived_at IS NULL`, id, photoLink)
 	if err != nil {
 		return err
 	}
 
-	return tx.Commit()
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return IncorrectProductID
+	}
+
+	return nil
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/tournaments"
 	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
 	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
 	"strconv"
 	"strings"
@@ -103,50 +104,53 @@ func (p *PostgresStorage) GetTournamentDataByID(tournamentID int) (tournaments.T
 }
 
 func (p *PostgresStorage) CreateTournamentTeam(teamInput tournaments.TournamentTeamModel) error {
-	tx, err := p.db.Beginx()
-	if err != nil {
-		return err
-	}
-
-	if teamInput.Deposit > 0 {
-		coinTr := user.CoinTransactionsModel{
-			ProfileID:          teamInput.ProfileID,
-			TransactionDetails: "Участие в турнире №" + strconv.Itoa(teamInput.TournamentID),
-			Amount:             -teamInput.Deposit,
-			Status:             user.SuccessTransaction,
-		}
-		err = p.UpdateBalance(tx, coinTr.ProfileID, coinTr.Amount)
-		if err != nil {
-			return err
+	return p.withTx(func(tx *sqlx.Tx) error {
+		if teamInput.Deposit > 0 {
+			err := p.PostLedgerTransfer(tx, user.LedgerTransfer{
+				From:          user.UserAccount(teamInput.ProfileID),
+				To:            user.PrizePoolAccount(teamInput.TournamentID),
+				Amount:        teamInput.Deposit,
+				Reason:        user.EntryFeeReason,
+				ReferenceType: user.TournamentReference,
+				ReferenceID:   strconv.Itoa(teamInput.TournamentID),
+			})
+			if err != nil {
+				return err
+			}
+			// Призовой фонд растет в полтора раза быстрее взносов, разницу добавляет house
+			prizeFond := int(float64(teamInput.Deposit) * 1.5)
+			err = p.PostLedgerTransfer(tx, user.LedgerTransfer{
+				From:          user.HouseAccount(),
+				To:            user.PrizePoolAccount(teamInput.TournamentID),
+				Amount:        prizeFond - teamInput.Deposit,
+				Reason:        user.GrantReason,
+				ReferenceType: user.TournamentReference,
+				ReferenceID:   strconv.Itoa(teamInput.TournamentID),
+			})
+			if err != nil {
+				return err
+			}
+			prizeFondQuery := `UPDATE tournaments SET prize_fond = prize_fond + $1 WHERE id = $2`
+			_, err = tx.Exec(prizeFondQuery, prizeFond, teamInput.TournamentID)
+			if err != nil {
+				return err
+			}
 		}
-		err = p.CreateCoinTransaction(tx, coinTr)
+
+		teamArray := pq.Array(teamInput.UserTeam)
+		rosterQuery := `INSERT INTO user_roster (tournament_id, user_id, roster, current_balance) 
+              VALUES ($1, $2, $3, $4)`
+
+		_, err := tx.Exec(rosterQuery, teamInput.TournamentID, teamInput.ProfileID, teamArray, 100-teamInput.TeamCost)
 		if err != nil {
 			return err
 		}
-		prizeFondQuery := `UPDATE tournaments SET prize_fond = prize_fond + $1 WHERE id = $2`
-		_, err = tx.Exec(prizeFondQuery, int(float64(teamInput.Deposit)*1.5), teamInput.TournamentID)
-		if err != nil {
-			tx.Rollback()
-		}
-	}
 
-	teamArray := pq.Array(teamInput.UserTeam)
-	rosterQuery := `INSERT INTO user_roster (tournament_id, user_id, roster, current_balance) 
-              VALUES ($1, $2, $3, $4)`
+		playersAmountQuery := `UPDATE tournaments SET players_amount = players_amount + 1 WHERE id = $1`
+		_, err = tx.Exec(playersAmountQuery, teamInput.TournamentID)
 
-	_, err = tx.Exec(rosterQuery, teamInput.TournamentID, teamInput.ProfileID, teamArray, 100-teamInput.TeamCost)
-	if err != nil {
-		tx.Rollback()
 		return err
-	}
-
-	playersAmountQuery := `UPDATE tournaments SET players_amount = players_amount + 1 WHERE id = $1`
-	_, err = tx.Exec(playersAmountQuery, teamInput.TournamentID)
-	if err != nil {
-		tx.Rollback()
-	}
-
-	return tx.Commit()
+	})
 }
 
 func (p *PostgresStorage) GetTournamentTeam(userID uuid.UUID, tournamentID int) (players.UserTeam, error) {
@@ -296,47 +300,66 @@ func (p *PostgresStorage) GetStatisticByPlayerIDAndMatchID(playerID int, matchID
 }
 
 func (p *PostgresStorage) UpdateRosterResults(results []players.TournamentTeamsResults, tournamentID int) error {
+	return p.withTx(func(tx *sqlx.Tx) error {
+		for _, result := range results {
+			query := fmt.Sprintf("UPDATE user_roster SET points = %f, coins = %d, place = %d WHERE tournament_id "+
+				"= %d AND user_id = '%s'", result.FantasyPoints, result.Coins, result.Place, tournamentID, result.ProfileID)
 
-	tx, err := p.db.Beginx()
-	if err != nil {
-		return err
-	}
+			_, err := tx.Exec(query)
+			if err != nil {
+				return err
+			}
 
-	for _, result := range results {
-		query := fmt.Sprintf("UPDATE user_roster SET points = %f, coins = %d, place = %d WHERE tournament_id "+
-			"= %d AND user_id = '%s'", result.FantasyPoints, result.Coins, result.Place, tournamentID, result.ProfileID)
+			// Приз окончательно удаленного пользователя остается у площадки, иначе кошелек не найдется
+			// и откатится выплата всем участникам. Помеченный удаленным профиль получает приз как обычно:
+			// после восстановления аккаунт должен вернуться без потерь
+			to := user.UserAccount(result.ProfileID)
+			exists, err := lockProfile(tx, result.ProfileID)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				to = user.HouseAccount()
+			}
 
-		coinTr := user.CoinTransactionsModel{
-			ProfileID:          result.ProfileID,
-			TransactionDetails: "Награда за участие в турнире №" + strconv.Itoa(tournamentID),
-			Amount:             result.Coins,
-			Status:             user.SuccessTransaction,
-		}
-		_, err = tx.Exec(query)
-		if err != nil {
-			tx.Rollback()
-			return err
+			err = p.PostLedgerTransfer(tx, user.LedgerTransfer{
+				From:          user.PrizePoolAccount(tournamentID),
+				To:            to,
+				Amount:        result.Coins,
+				Reason:        user.PrizeReason,
+				ReferenceType: user.TournamentReference,
+				ReferenceID:   strconv.Itoa(tournamentID),
+			})
+			if err != nil {
+				return err
+			}
 		}
 
-		err = p.UpdateBalance(tx, result.ProfileID, result.Coins)
-		if err != nil {
-			return err
-		}
-		err = p.CreateCoinTransaction(tx, coinTr)
-		if err != nil {
-			return err
+		return nil
+	})
+}
+
+// lockProfile блокирует строку профиля и сообщает, что профиль еще не удален окончательно
+func lockProfile(tx *sqlx.Tx, profileID uuid.UUID) (bool, error) {
+	var exists bool
+
+	err := tx.Get(&exists, `SELECT TRUE FROM user_profile WHERE id = $1 FOR UPDATE`, profileID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
 		}
+		return false, err
 	}
 
-	return tx.Commit()
+	return exists, nil
 }
 
 func (p *PostgresStorage) GetAllUserRosterInfo(userID uuid.UUID, tournamentID int) (players.UserRosterInfo, error) {
 	var res players.UserRosterInfo
-	query := "SELECT tournament_id, user_id, roster, current_balance, points, coins, place FROM user_roster WHERE tournament_id = $1 AND user_id = $2"
+	query := "SELECT tournament_id, user_id, roster, current_balance, points, coins, place, COALESCE(nickname, '') FROM user_roster WHERE tournament_id = $1 AND user_id = $2"
 
 	var rosterStr string
-	err := p.db.QueryRow(query, tournamentID, userID).Scan(&res.TournamentID, &res.ProfileID, &rosterStr, &res.TournamentBalance, &res.FantasyPoints, &res.Coins, &res.Place)
+	err := p.db.QueryRow(query, tournamentID, userID).Scan(&res.TournamentID, &res.ProfileID, &rosterStr, &res.TournamentBalance, &res.FantasyPoints, &res.Coins, &res.Place, &res.Nickname)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return res, nil
@@ -357,3 +380,14 @@ func (p *PostgresStorage) GetAllUserRosterInfo(userID uuid.UUID, tournamentID in
 
 	return res, nil
 }
+
+func (p *PostgresStorage) GetUserTournamentIDs(userID uuid.UUID) ([]int, error) {
+	ids := []int{}
+
+	err := p.db.Select(&ids, `SELECT tournament_id FROM user_roster WHERE user_id = $1 ORDER BY tournament_id`, userID)
+	if err != nil {
+		return ids, err
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/players"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"math/rand"
+	"testing"
+)
+
+// createTestTournament создает турнир с призовым фондом prize, пополненным со счета house
+func createTestTournament(t *testing.T, p *PostgresStorage, prize int) int {
+	tournamentID := 1_000_000_000 + rand.Intn(1_000_000_000)
+	_, err := p.db.Exec(`INSERT INTO tournaments (id, title, prize_fond, status_tournament) VALUES ($1, 'test', $2, 'finished')`,
+		tournamentID, prize)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		var transactionIDs []int64
+		err := p.db.Select(&transactionIDs, `SELECT e.transaction_id FROM ledger_entries e 
+			JOIN ledger_accounts a ON a.id = e.account_id WHERE a.tournament_id = $1`, tournamentID)
+		assert.NoError(t, err)
+
+		_, err = p.db.Exec(`DELETE FROM ledger_entries WHERE transaction_id = ANY ($1)`, pq.Array(transactionIDs))
+		assert.NoError(t, err)
+		_, err = p.db.Exec(`DELETE FROM ledger_transactions WHERE id = ANY ($1)`, pq.Array(transactionIDs))
+		assert.NoError(t, err)
+		_, err = p.db.Exec(`DELETE FROM ledger_accounts WHERE tournament_id = $1`, tournamentID)
+		assert.NoError(t, err)
+		_, err = p.db.Exec(`DELETE FROM tournaments WHERE id = $1`, tournamentID)
+		assert.NoError(t, err)
+	})
+
+	err = p.withTx(func(tx *sqlx.Tx) error {
+		return p.PostLedgerTransfer(tx, user.LedgerTransfer{
+			From:          user.HouseAccount(),
+			To:            user.PrizePoolAccount(tournamentID),
+			Amount:        prize,
+			Reason:        user.GrantReason,
+			ReferenceType: user.TournamentReference,
+		})
+	})
+	require.NoError(t, err)
+
+	return tournamentID
+}
+
+func TestUpdateRosterResults_SoftDeletedProfileKeepsPrize(t *testing.T) {
+	p := newTestPostgresStorage(t)
+	tournamentID := createTestTournament(t, p, 500)
+	profileID := createTestWallet(t, p, 0)
+
+	_, err := p.db.Exec(`INSERT INTO user_roster (tournament_id, user_id, roster) VALUES ($1, $2, '{}')`,
+		tournamentID, profileID)
+	require.NoError(t, err)
+	require.NoError(t, p.DeleteProfile(profileID))
+
+	err = p.UpdateRosterResults([]players.TournamentTeamsResults{
+		{ProfileID: profileID, Coins: 500, Place: 1},
+	}, tournamentID)
+	require.NoError(t, err)
+
+	require.NoError(t, p.RestoreProfile(profileID))
+	assert.Equal(t, 500, getTestBalance(t, p, profileID))
+}
@@ -0,0 +1,389 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/players"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"sort"
+	"strconv"
+	"time"
+)
+
+var (
+	TradeOfferNotFoundError = errors.New("предложение обмена не найдено или уже закрыто")
+	OwnTradeOfferError      = errors.New("нельзя предложить обмен самому себе")
+	EmptyTradeOfferError    = errors.New("в предложении обмена должна быть хотя бы одна карточка")
+	TradeCardConflictError  = errors.New("карточка не может быть одновременно предложена и запрошена")
+	TradeProposerFundsError = errors.New("у отправителя предложения не хватает монет для обмена")
+)
+
+const tradeOfferColumns = `o.id, o.proposer_id, pp.nickname AS proposer_nickname, o.recipient_id,
+		rp.nickname AS recipient_nickname, o.offered_cards, o.requested_cards, o.coins, o.status, o.parent_id,
+		o.created_at, o.expires_at, o.closed_at`
+
+const tradeOfferFrom = ` FROM trade_offers o
+		JOIN user_profile pp ON pp.id = o.proposer_id
+		JOIN user_profile rp ON rp.id = o.recipient_id`
+
+// tradeOfferRow - строка trade_offers. Списки карточек хранятся массивами INTEGER[]
+type tradeOfferRow struct {
+	ID                int64         `db:"id"`
+	ProposerID        uuid.UUID     `db:"proposer_id"`
+	ProposerNickname  string        `db:"proposer_nickname"`
+	RecipientID       uuid.UUID     `db:"recipient_id"`
+	RecipientNickname string        `db:"recipient_nickname"`
+	OfferedCards      pq.Int64Array `db:"offered_cards"`
+	RequestedCards    pq.Int64Array `db:"requested_cards"`
+	Coins             int           `db:"coins"`
+	Status            string        `db:"status"`
+	ParentID          *int64        `db:"parent_id"`
+	CreatedAt         time.Time     `db:"created_at"`
+	ExpiresAt         time.Time     `db:"expires_at"`
+	ClosedAt          *time.Time    `db:"closed_at"`
+}
+
+func (r tradeOfferRow) toModel() players.TradeOffer {
+	toInts := func(ids pq.Int64Array) []int {
+		res := make([]int, len(ids))
+		for i, id := range ids {
+			res[i] = int(id)
+		}
+		return res
+	}
+
+	return players.TradeOffer{
+		ID:                r.ID,
+		ProposerID:        r.ProposerID,
+		ProposerNickname:  r.ProposerNickname,
+		RecipientID:       r.RecipientID,
+		RecipientNickname: r.RecipientNickname,
+		OfferedCards:      toInts(r.OfferedCards),
+		RequestedCards:    toInts(r.RequestedCards),
+		Coins:             r.Coins,
+		Status:            r.Status,
+		ParentID:          r.ParentID,
+		CreatedAt:         r.CreatedAt,
+		ExpiresAt:         r.ExpiresAt,
+		ClosedAt:          r.ClosedAt,
+	}
+}
+
+func getTradeOffer(q sqlx.Queryer, id int64) (players.TradeOffer, error) {
+	var row tradeOfferRow
+
+	err := sqlx.Get(q, &row, `SELECT `+tradeOfferColumns+tradeOfferFrom+` WHERE o.id = $1`, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return players.TradeOffer{}, TradeOfferNotFoundError
+		}
+		return players.TradeOffer{}, err
+	}
+
+	return row.toModel(), nil
+}
+
+// lockTradeCards блокирует все карточки обмена в порядке id, чтобы встречные обмены не взаимоблокировались,
+// и проверяет, что каждой из них владелец может распорядиться
+func (p *PostgresStorage) lockTradeCards(tx *sqlx.Tx, offer players.TradeOffer) error {
+	owners := make(map[int]uuid.UUID, len(offer.OfferedCards)+len(offer.RequestedCards))
+	for _, id := range offer.OfferedCards {
+		owners[id] = offer.ProposerID
+	}
+	for _, id := range offer.RequestedCards {
+		if _, ok := owners[id]; ok {
+			return TradeCardConflictError
+		}
+		owners[id] = offer.RecipientID
+	}
+
+	ids := make([]int, 0, len(owners))
+	for id := range owners {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	for _, id := range ids {
+		if err := p.lockOwnedCard(tx, owners[id], id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// insertTradeOffer проверяет карточки обеих сторон и создает предложение в статусе pending
+func (p *PostgresStorage) insertTradeOffer(tx *sqlx.Tx, offer players.TradeOffer) (int64, error) {
+	if offer.ProposerID == offer.RecipientID {
+		return 0, OwnTradeOfferError
+	}
+	if len(offer.OfferedCards) == 0 && len(offer.RequestedCards) == 0 {
+		return 0, EmptyTradeOfferError
+	}
+
+	err := p.lockTradeCards(tx, offer)
+	if err != nil {
+		return 0, err
+	}
+
+	if offer.Coins > 0 {
+		balance, err := p.lockWallet(tx, offer.ProposerID)
+		if err != nil {
+			return 0, err
+		}
+		if balance < offer.Coins {
+			return 0, &InsufficientFundsError{ProfileID: offer.ProposerID, Balance: balance, Required: offer.Coins}
+		}
+	}
+
+	var id int64
+	err = tx.Get(&id, `INSERT INTO trade_offers (proposer_id, recipient_id, offered_cards, requested_cards, coins,
+			parent_id, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`,
+		offer.ProposerID, offer.RecipientID, pq.Array(offer.OfferedCards), pq.Array(offer.RequestedCards),
+		offer.Coins, offer.ParentID, offer.ExpiresAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" {
+			return 0, UserDoesNotExistError
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (p *PostgresStorage) CreateTradeOffer(offer players.TradeOffer) (players.TradeOffer, error) {
+	err := p.withTx(func(tx *sqlx.Tx) error {
+		id, err := p.insertTradeOffer(tx, offer)
+		if err != nil {
+			return err
+		}
+
+		offer, err = getTradeOffer(tx, id)
+		return err
+	})
+	if err != nil {
+		return offer, err
+	}
+
+	return offer, nil
+}
+
+// lockPendingTradeOffer блокирует действующее предложение, адресованное recipientID
+func lockPendingTradeOffer(tx *sqlx.Tx, recipientID uuid.UUID, id int64) (players.TradeOffer, error) {
+	var row tradeOfferRow
+
+	err := tx.Get(&row, `SELECT `+tradeOfferColumns+tradeOfferFrom+`
+		WHERE o.id = $1 AND o.recipient_id = $2 AND o.status = $3 AND o.expires_at > now() FOR UPDATE OF o`,
+		id, recipientID, players.PendingTrade)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return players.TradeOffer{}, TradeOfferNotFoundError
+		}
+		return players.TradeOffer{}, err
+	}
+
+	return row.toModel(), nil
+}
+
+func closeTradeOffer(tx *sqlx.Tx, id int64, status string) (players.TradeOffer, error) {
+	_, err := tx.Exec(`UPDATE trade_offers SET status = $2, closed_at = now() WHERE id = $1`, id, status)
+	if err != nil {
+		return players.TradeOffer{}, err
+	}
+
+	return getTradeOffer(tx, id)
+}
+
+// CounterTradeOffer закрывает предложение статусом countered и создает встречное от его получателя.
+// Возвращает закрытое исходное и новое предложения
+func (p *PostgresStorage) CounterTradeOffer(counter players.TradeOffer) (players.TradeOffer, players.TradeOffer, error) {
+	var original players.TradeOffer
+
+	err := p.withTx(func(tx *sqlx.Tx) error {
+		var err error
+		original, err = lockPendingTradeOffer(tx, counter.ProposerID, *counter.ParentID)
+		if err != nil {
+			return err
+		}
+
+		original, err = closeTradeOffer(tx, original.ID, players.CounteredTrade)
+		if err != nil {
+			return err
+		}
+
+		counter.RecipientID = original.ProposerID
+		id, err := p.insertTradeOffer(tx, counter)
+		if err != nil {
+			return err
+		}
+
+		counter, err = getTradeOffer(tx, id)
+		return err
+	})
+	if err != nil {
+		return original, counter, err
+	}
+
+	return original, counter, nil
+}
+
+func (p *PostgresStorage) DeclineTradeOffer(recipientID uuid.UUID, id int64) (players.TradeOffer, error) {
+	var offer players.TradeOffer
+
+	err := p.withTx(func(tx *sqlx.Tx) error {
+		_, err := lockPendingTradeOffer(tx, recipientID, id)
+		if err != nil {
+			return err
+		}
+
+		offer, err = closeTradeOffer(tx, id, players.DeclinedTrade)
+		return err
+	})
+	if err != nil {
+		return offer, err
+	}
+
+	return offer, nil
+}
+
+// AcceptTradeOffer проводит обмен в одной транзакции: заново проверяет владельцев всех карточек,
+// переводит монеты, меняет владельцев карточек и снимает их с продажи на рынке
+func (p *PostgresStorage) AcceptTradeOffer(recipientID uuid.UUID, id int64) (players.TradeOffer, error) {
+	var offer players.TradeOffer
+
+	err := p.withTx(func(tx *sqlx.Tx) error {
+		var err error
+		offer, err = lockPendingTradeOffer(tx, recipientID, id)
+		if err != nil {
+			return err
+		}
+
+		err = p.lockTradeCards(tx, offer)
+		if err != nil {
+			return err
+		}
+
+		if offer.Coins > 0 {
+			err = p.PostLedgerTransfer(tx, user.LedgerTransfer{
+				From:          user.UserAccount(offer.ProposerID),
+				To:            user.UserAccount(offer.RecipientID),
+				Amount:        offer.Coins,
+				Reason:        user.TradeReason,
+				ReferenceType: user.TradeOfferReference,
+				ReferenceID:   strconv.FormatInt(offer.ID, 10),
+			})
+			// Баланс отправителя получателю не раскрывается
+			var fundsErr *InsufficientFundsError
+			if errors.As(err, &fundsErr) {
+				return TradeProposerFundsError
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = tx.Exec(`UPDATE player_cards SET profile_id = $2 WHERE id = ANY ($1)`,
+			pq.Array(offer.OfferedCards), offer.RecipientID)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(`UPDATE player_cards SET profile_id = $2 WHERE id = ANY ($1)`,
+			pq.Array(offer.RequestedCards), offer.ProposerID)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(`UPDATE card_listings SET status = $2, closed_at = now()
+			WHERE card_id = ANY ($1) AND status = $3`,
+			pq.Array(append(append([]int{}, offer.OfferedCards...), offer.RequestedCards...)),
+			players.CancelledListing, players.ActiveListing)
+		if err != nil {
+			return err
+		}
+
+		offer, err = closeTradeOffer(tx, offer.ID, players.AcceptedTrade)
+		return err
+	})
+	if err != nil {
+		return offer, err
+	}
+
+	return offer, nil
+}
+
+// GetTradeOffers возвращает предложения, где пользователь отправитель или получатель, новые первыми
+func (p *PostgresStorage) GetTradeOffers(profileID uuid.UUID, filter players.TradeOffersFilter) ([]players.TradeOffer, error) {
+	var rows []tradeOfferRow
+
+	query := `SELECT ` + tradeOfferColumns + tradeOfferFrom
+	switch filter.Box {
+	case players.IncomingTrades:
+		query += ` WHERE o.recipient_id = $1`
+	case players.OutgoingTrades:
+		query += ` WHERE o.proposer_id = $1`
+	default:
+		query += ` WHERE (o.proposer_id = $1 OR o.recipient_id = $1)`
+	}
+	query += ` AND ($2 = '' OR o.status = $2) ORDER BY o.id DESC LIMIT $3`
+
+	err := p.db.Select(&rows, query, profileID, filter.Status, filter.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	offers := make([]players.TradeOffer, len(rows))
+	for i, row := range rows {
+		offers[i] = row.toModel()
+	}
+
+	return offers, nil
+}
+
+// GetTradeOffer возвращает предложение, только если profileID его участник
+func (p *PostgresStorage) GetTradeOffer(profileID uuid.UUID, id int64) (players.TradeOffer, error) {
+	offer, err := getTradeOffer(p.db, id)
+	if err != nil {
+		return offer, err
+	}
+
+	if offer.ProposerID != profileID && offer.RecipientID != profileID {
+		return players.TradeOffer{}, TradeOfferNotFoundError
+	}
+
+	return offer, nil
+}
+
+// ExpireTradeOffers закрывает просроченные предложения и возвращает их для уведомления участников
+func (p *PostgresStorage) ExpireTradeOffers(limit int) ([]players.TradeOffer, error) {
+	var offers []players.TradeOffer
+
+	err := p.withTx(func(tx *sqlx.Tx) error {
+		var ids []int64
+		err := tx.Select(&ids, `UPDATE trade_offers SET status = $1, closed_at = now()
+			WHERE id IN (SELECT id FROM trade_offers WHERE status = $2 AND expires_at <= now()
+				ORDER BY expires_at LIMIT $3 FOR UPDATE SKIP LOCKED)
+			RETURNING id`, players.ExpiredTrade, players.PendingTrade, limit)
+		if err != nil {
+			return err
+		}
+
+		for _, id := range ids {
+			offer, err := getTradeOffer(tx, id)
+			if err != nil {
+				return err
+			}
+			offers = append(offers, offer)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return offers, nil
+}
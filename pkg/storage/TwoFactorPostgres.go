@@ -0,0 +1,142 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	TwoFactorNotFoundError = errors.New("двухфакторная аутентификация не настроена")
+)
+
+func (p *PostgresStorage) GetTwoFactor(profileID uuid.UUID) (user.TwoFactor, error) {
+	var twoFactor user.TwoFactor
+
+	err := p.db.Get(&twoFactor, `SELECT profile_id, secret, enabled, last_used_step, created_at, confirmed_at 
+		FROM user_two_factor WHERE profile_id = $1;`, profileID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return twoFactor, TwoFactorNotFoundError
+		}
+		return twoFactor, err
+	}
+
+	return twoFactor, nil
+}
+
+// SaveTwoFactorSecret сохраняет новый секрет для неподтвержденной 2FA. Включенная 2FA не перезаписывается
+func (p *PostgresStorage) SaveTwoFactorSecret(profileID uuid.UUID, secret string) error {
+	_, err := p.db.Exec(`INSERT INTO user_two_factor (profile_id, secret) VALUES ($1, $2)
+		ON CONFLICT (profile_id) DO UPDATE SET secret = EXCLUDED.secret, created_at = now(), last_used_step = 0
+		WHERE user_two_factor.enabled = false;`, profileID, secret)
+
+	return err
+}
+
+// UseTwoFactorStep запоминает последний использованный шаг TOTP, чтобы один код нельзя было применить дважды
+func (p *PostgresStorage) UseTwoFactorStep(profileID uuid.UUID, step int64) (bool, error) {
+	result, err := p.db.Exec(`UPDATE user_two_factor SET last_used_step = $2 
+		WHERE profile_id = $1 AND last_used_step < $2;`, profileID, step)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
+
+func (p *PostgresStorage) EnableTwoFactor(profileID uuid.UUID, recoveryCodeHashes []string) error {
+	tx, err := p.db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`UPDATE user_two_factor SET enabled = true, confirmed_at = now() WHERE profile_id = $1;`, profileID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = p.replaceRecoveryCodes(tx, profileID, recoveryCodeHashes)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (p *PostgresStorage) DisableTwoFactor(profileID uuid.UUID) error {
+	tx, err := p.db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`DELETE FROM two_factor_recovery_codes WHERE profile_id = $1;`, profileID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	_, err = tx.Exec(`DELETE FROM user_two_factor WHERE profile_id = $1;`, profileID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (p *PostgresStorage) ReplaceRecoveryCodes(profileID uuid.UUID, recoveryCodeHashes []string) error {
+	tx, err := p.db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	err = p.replaceRecoveryCodes(tx, profileID, recoveryCodeHashes)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (p *PostgresStorage) replaceRecoveryCodes(tx *sqlx.Tx, profileID uuid.UUID, recoveryCodeHashes []string) error {
+	_, err := tx.Exec(`DELETE FROM two_factor_recovery_codes WHERE profile_id = $1;`, profileID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for _, hash := range recoveryCodeHashes {
+		_, err = tx.Exec(`INSERT INTO two_factor_recovery_codes (profile_id, code_hash) VALUES ($1, $2);`, profileID, hash)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UseRecoveryCode помечает код восстановления использованным. Возвращает false, если код не найден или уже использован
+func (p *PostgresStorage) UseRecoveryCode(profileID uuid.UUID, codeHash string) (bool, error) {
+	result, err := p.db.Exec(`UPDATE two_factor_recovery_codes SET used_at = now() 
+		WHERE profile_id = $1 AND code_hash = $2 AND used_at IS NULL;`, profileID, codeHash)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+var (
+	TwoFactorChallengeError = errors.New("запрос на вход не найден или истек")
+	twoFactorChallengeTTL   = 5 * time.Minute
+)
+
+func (r *RedisStorage) CreateTwoFactorChallenge(data user.TwoFactorChallengeData) (user.TwoFactorChallenge, error) {
+	var challenge user.TwoFactorChallenge
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return challenge, err
+	}
+	challenge.Challenge = fmt.Sprintf("%x", b)
+	challenge.ExpiresIn = time.Now().Add(twoFactorChallengeTTL).Unix()
+
+	value, err := json.Marshal(data)
+	if err != nil {
+		return challenge, err
+	}
+
+	err = r.client.Set(context.Background(), "two_factor_challenge_"+challenge.Challenge, value, twoFactorChallengeTTL).Err()
+	if err != nil {
+		return challenge, err
+	}
+
+	return challenge, nil
+}
+
+func (r *RedisStorage) GetTwoFactorChallenge(challenge string) (user.TwoFactorChallengeData, error) {
+	var data user.TwoFactorChallengeData
+
+	val, err := r.client.Get(context.Background(), "two_factor_challenge_"+challenge).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return data, TwoFactorChallengeError
+		}
+		return data, err
+	}
+
+	err = json.Unmarshal(val, &data)
+	if err != nil {
+		return data, err
+	}
+
+	return data, nil
+}
+
+func (r *RedisStorage) DeleteTwoFactorChallenge(challenge string) error {
+	return r.client.Del(context.Background(), "two_factor_challenge_"+challenge).Err()
+}
@@ -6,12 +6,16 @@ import (
 	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 var (
 	UserDoesNotExistError = errors.New("пользователь не найден")
+	EmailTakenError       = errors.New("email уже занят")
+	EmailChangedError     = errors.New("email пользователя уже был изменен")
 )
 
+// CreateUserContacts сохраняет контакты и настройки рассылок нового пользователя в транзакции регистрации
 func (p *PostgresStorage) CreateUserContacts(tx *sqlx.Tx, u user.SignUpModel) error {
 	_, err := tx.Exec(`INSERT INTO user_contacts (profile_id, email, email_subscription) VALUES ($1, LOWER($2), $3);`,
 		u.ID,
@@ -23,6 +27,22 @@ func (p *PostgresStorage) CreateUserContacts(tx *sqlx.Tx, u user.SignUpModel) er
 		return err
 	}
 
+	prefs := DefaultNotificationPreferences
+	_, err = tx.Exec(`INSERT INTO notification_preferences 
+    		(profile_id, tournament_reminders, tournament_results, store_offers, security_alerts, trade_offers)
+			VALUES ($1, $2, $3, $4, $5, $6);`,
+		u.ID,
+		prefs.TournamentReminders,
+		prefs.TournamentResults,
+		prefs.StoreOffers,
+		prefs.SecurityAlerts,
+		prefs.TradeOffers,
+	)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	return nil
 }
 
@@ -58,3 +78,58 @@ func (p *PostgresStorage) GetProfileIDByEmail(email string) (uuid.UUID, error) {
 
 	return profileID, err
 }
+
+func (p *PostgresStorage) GetEmailByProfileID(profileID uuid.UUID) (string, error) {
+	var email string
+
+	query := `SELECT email FROM user_contacts WHERE profile_id = $1`
+	err := p.db.QueryRow(query, profileID).Scan(&email)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", UserDoesNotExistError
+		} else {
+			return "", err
+		}
+	}
+
+	return email, nil
+}
+
+// ChangeEmail меняет email, только если текущий адрес все еще oldEmail, и завершает все сессии пользователя
+func (p *PostgresStorage) ChangeEmail(profileID uuid.UUID, oldEmail string, newEmail string) error {
+	tx, err := p.db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	res, err := tx.Exec(`UPDATE user_contacts SET email = LOWER($1) WHERE profile_id = $2 AND LOWER(email) = LOWER($3);`,
+		newEmail,
+		profileID,
+		oldEmail,
+	)
+	if err != nil {
+		tx.Rollback()
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return EmailTakenError
+		}
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if affected == 0 {
+		tx.Rollback()
+		return EmailChangedError
+	}
+
+	err = p.DeleteAllSessionsByProfileID(tx, profileID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
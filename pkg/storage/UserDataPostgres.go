@@ -70,3 +70,16 @@ func (p *PostgresStorage) UpdatePassword(tx *sqlx.Tx, inp user.ChangePasswordMod
 
 	return nil
 }
+
+func (p *PostgresStorage) UpdatePasswordHash(inp user.ChangePasswordModel) error {
+	_, err := p.db.Exec(`UPDATE user_data SET password_encoded = $1, password_salt = $2 WHERE profile_id = $3;`,
+		inp.NewPassword,
+		inp.PasswordSalt,
+		inp.ProfileID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -1,18 +1,28 @@
 package storage
 
 import (
+	"database/sql"
 	"errors"
 	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"time"
 )
 
 var (
-	NotEnoughCoinsError = errors.New("на балансе не достаточно монет")
-	defaultPhoto        = "https://cdn1.iconfinder.com/data/icons/sport-avatar-6/64/15-hockey_player-sport-hockey-avatar-people-256.png"
+	defaultPhoto = "https://cdn1.iconfinder.com/data/icons/sport-avatar-6/64/15-hockey_player-sport-hockey-avatar-people-256.png"
 )
 
+var NicknameTakenError = errors.New("никнейм уже занят")
+
+// isNicknameTaken сообщает, что запись нарушила уникальность никнейма без учета регистра.
+// Проверка перед записью не защищает от одновременных запросов, поэтому решает уникальный индекс
+func isNicknameTaken(err error) bool {
+	pqErr, ok := err.(*pq.Error)
+	return ok && pqErr.Code == "23505" && pqErr.Constraint == "user_profile_nickname_lower_idx"
+}
+
 func (p *PostgresStorage) CreateUserProfile(tx *sqlx.Tx, u user.SignUpModel) error {
 	_, err := tx.Exec(`INSERT INTO user_profile (id, nickname, date_registration, photo_link, coins) 
 		VALUES ($1, $2, $3, $4, $5);`,
@@ -24,6 +34,9 @@ func (p *PostgresStorage) CreateUserProfile(tx *sqlx.Tx, u user.SignUpModel) err
 	)
 	if err != nil {
 		tx.Rollback()
+		if isNicknameTaken(err) {
+			return NicknameTakenError
+		}
 		return err
 	}
 
@@ -42,37 +55,147 @@ func (p *PostgresStorage) CheckNicknameExists(nickname string) (bool, error) {
 	return exists, nil
 }
 
+// DeleteProfile помечает профиль удаленным и завершает все сессии. Данные остаются до PurgeProfile,
+// пока пользователь может восстановить аккаунт входом
 func (p *PostgresStorage) DeleteProfile(profileID uuid.UUID) error {
+	tx, err := p.db.Beginx()
+	if err != nil {
+		return err
+	}
 
-	query := "DELETE FROM user_profile WHERE id = $1"
-	result, err := p.db.Exec(query, profileID)
+	result, err := tx.Exec(`UPDATE user_profile SET deleted_at = now() WHERE id = $1 AND deleted_at IS NULL;`, profileID)
 	if err != nil {
+		tx.Rollback()
 		return err
 	}
 
 	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		tx.Rollback()
 		return UserDoesNotExistError
 	}
 
-	return nil
+	err = p.DeleteAllSessionsByProfileID(tx, profileID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
 }
 
-func (p *PostgresStorage) UpdateBalance(tx *sqlx.Tx, profileID uuid.UUID, coins int) error {
-	u, err := p.GetUserInfo(profileID)
+func (p *PostgresStorage) GetProfileDeletedAt(profileID uuid.UUID) (*time.Time, error) {
+	var deletedAt sql.NullTime
+
+	query := `SELECT deleted_at FROM user_profile WHERE id = $1`
+	err := p.db.QueryRow(query, profileID).Scan(&deletedAt)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, UserDoesNotExistError
+		} else {
+			return nil, err
+		}
+	}
+
+	if !deletedAt.Valid {
+		return nil, nil
+	}
+	return &deletedAt.Time, nil
+}
+
+func (p *PostgresStorage) RestoreProfile(profileID uuid.UUID) error {
+	_, err := p.db.Exec(`UPDATE user_profile SET deleted_at = NULL WHERE id = $1;`, profileID)
+
+	return err
+}
+
+func (p *PostgresStorage) GetProfilesToPurge(deletedBefore time.Time, limit int) ([]user.UserInfoModel, error) {
+	profiles := []user.UserInfoModel{}
+
+	err := p.db.Select(&profiles, `SELECT id, nickname, date_registration, photo_link, coins FROM user_profile 
+		WHERE deleted_at IS NOT NULL AND deleted_at < $1 ORDER BY deleted_at LIMIT $2`, deletedBefore, limit)
+	if err != nil {
+		return profiles, err
+	}
+
+	return profiles, nil
+}
+
+// PurgeProfile окончательно удаляет профиль вместе с карточками, транзакциями и сессиями.
+// Составы в незавершенных турнирах удаляются, в завершенных остаются, чтобы не ломать таблицы результатов,
+// никнейм в них заменяется на anonymousNickname
+func (p *PostgresStorage) PurgeProfile(profileID uuid.UUID, anonymousNickname string) error {
+	tx, err := p.db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`DELETE FROM user_roster WHERE user_id = $1 AND tournament_id IN
+		(SELECT id FROM tournaments WHERE status_tournament <> 'finished');`, profileID)
+	if err != nil {
+		tx.Rollback()
 		return err
 	}
 
-	if coins < 0 && u.Coins+coins < 0 {
-		return NotEnoughCoinsError
+	_, err = tx.Exec(`UPDATE user_roster SET nickname = $2 WHERE user_id = $1;`, profileID, anonymousNickname)
+	if err != nil {
+		tx.Rollback()
+		return err
 	}
-	newBalance := u.Coins + coins
 
-	_, err = tx.Exec(`UPDATE user_profile SET coins = $1 WHERE id = $2;`, newBalance, profileID)
+	result, err := tx.Exec(`DELETE FROM user_profile WHERE id = $1 AND deleted_at IS NOT NULL;`, profileID)
 	if err != nil {
 		tx.Rollback()
 		return err
 	}
 
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		tx.Rollback()
+		return UserDoesNotExistError
+	}
+
+	return tx.Commit()
+}
+
+func (p *PostgresStorage) GetNicknameChangedAt(profileID uuid.UUID) (*time.Time, error) {
+	var changedAt *time.Time
+
+	err := p.db.Get(&changedAt, `SELECT nickname_changed_at FROM user_profile WHERE id = $1;`, profileID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, UserDoesNotExistError
+		}
+		return nil, err
+	}
+
+	return changedAt, nil
+}
+
+func (p *PostgresStorage) UpdateNickname(profileID uuid.UUID, nickname string) error {
+	result, err := p.db.Exec(`UPDATE user_profile SET nickname = $2, nickname_changed_at = now() WHERE id = $1;`,
+		profileID, nickname)
+	if err != nil {
+		if isNicknameTaken(err) {
+			return NicknameTakenError
+		}
+		return err
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return UserDoesNotExistError
+	}
+
+	return nil
+}
+
+func (p *PostgresStorage) UpdatePhotoLink(profileID uuid.UUID, photoLink string) error {
+	result, err := p.db.Exec(`UPDATE user_profile SET photo_link = $2 WHERE id = $1;`, profileID, photoLink)
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return UserDoesNotExistError
+	}
+
 	return nil
 }
@@ -0,0 +1,20 @@
+package storage
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestUpdateNickname_TakenIgnoringCase(t *testing.T) {
+	p := newTestPostgresStorage(t)
+	first := createTestWallet(t, p, 0)
+	second := createTestWallet(t, p, 0)
+
+	var nickname string
+	require.NoError(t, p.db.Get(&nickname, `SELECT nickname FROM user_profile WHERE id = $1`, first))
+
+	err := p.UpdateNickname(second, strings.ToUpper(nickname))
+	assert.Equal(t, NicknameTakenError, err)
+}
@@ -0,0 +1,128 @@
+package storage
+
+import (
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/players"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/store"
+	"math/rand"
+	"sort"
+)
+
+// drawPack раскладывает набор по правилам table. owned - игроки, карточки которых у пользователя уже есть,
+// по редкостям: такие игроки не выпадают повторно в той же редкости. Внутри одного набора игроки не повторяются.
+// Если подходящих игроков не хватает, набор получается меньше count.
+// Результат зависит только от rng и входных данных, поэтому кандидаты сортируются по id
+func drawPack(rng *rand.Rand, table store.DropTable, count int, candidates []players.PlayerResponse,
+	owned map[store.CardRarity]map[int]struct{}) store.PackCards {
+	sorted := make([]players.PlayerResponse, len(candidates))
+	copy(sorted, candidates)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	rarities := slotRarities(rng, table, count)
+	positions := slotPositions(table, count)
+
+	picked := make(map[int]struct{})
+	var cards store.PackCards
+	for slot := 0; slot < count; slot++ {
+		pool := packPool(sorted, picked, owned[rarities[slot]], positions[slot])
+		if len(pool) == 0 && positions[slot] != players.ErrPlayerPosition {
+			pool = packPool(sorted, picked, owned[rarities[slot]], players.ErrPlayerPosition)
+		}
+		if len(pool) == 0 {
+			continue
+		}
+
+		player := pickPackPlayer(rng, table, pool)
+		picked[player.ID] = struct{}{}
+		cards = append(cards, store.PackCard{PlayerID: player.ID, Rarity: rarities[slot], Position: int8(player.Position)})
+	}
+
+	return cards
+}
+
+// slotRarities отдает гарантированные редкости, добирает остальные по весам и перемешивает слоты
+func slotRarities(rng *rand.Rand, table store.DropTable, count int) []store.CardRarity {
+	rarities := make([]store.CardRarity, 0, count)
+	for _, g := range table.Guarantees {
+		for i := 0; i < g.Count && len(rarities) < count; i++ {
+			rarities = append(rarities, g.Rarity)
+		}
+	}
+
+	var total int
+	for _, w := range table.RarityWeights {
+		total += w.Weight
+	}
+	for len(rarities) < count {
+		roll := rng.Intn(total)
+		for _, w := range table.RarityWeights {
+			if roll < w.Weight {
+				rarities = append(rarities, w.Rarity)
+				break
+			}
+			roll -= w.Weight
+		}
+	}
+
+	rng.Shuffle(len(rarities), func(i, j int) {
+		rarities[i], rarities[j] = rarities[j], rarities[i]
+	})
+
+	return rarities
+}
+
+// slotPositions закрепляет позиции из квот за первыми слотами, остальные слоты без ограничений
+func slotPositions(table store.DropTable, count int) []players.Position {
+	positions := make([]players.Position, count)
+	slot := 0
+	for _, q := range table.PositionQuotas {
+		for i := 0; i < q.Count && slot < count; i++ {
+			positions[slot] = players.Position(q.Position)
+			slot++
+		}
+	}
+
+	return positions
+}
+
+func packPool(candidates []players.PlayerResponse, picked, owned map[int]struct{}, position players.Position) []players.PlayerResponse {
+	var pool []players.PlayerResponse
+	for _, player := range candidates {
+		if _, ok := picked[player.ID]; ok {
+			continue
+		}
+		if _, ok := owned[player.ID]; ok {
+			continue
+		}
+		if position != players.ErrPlayerPosition && player.Position != position {
+			continue
+		}
+		pool = append(pool, player)
+	}
+
+	return pool
+}
+
+// pickPackPlayer с вероятностью StarChance берет звездного игрока, иначе обычного.
+// Если одной из групп нет, игрок берется из другой
+func pickPackPlayer(rng *rand.Rand, table store.DropTable, pool []players.PlayerResponse) players.PlayerResponse {
+	if table.StarChance > 0 {
+		var stars, regular []players.PlayerResponse
+		for _, player := range pool {
+			if player.PlayerCost >= table.StarCost {
+				stars = append(stars, player)
+			} else {
+				regular = append(regular, player)
+			}
+		}
+
+		if len(stars) > 0 && len(regular) > 0 {
+			if rng.Intn(100) < table.StarChance {
+				pool = stars
+			} else {
+				pool = regular
+			}
+		}
+	}
+
+	return pool[rng.Intn(len(pool))]
+}
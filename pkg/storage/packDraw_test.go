@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/players"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/store"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func testPackCandidates() []players.PlayerResponse {
+	var candidates []players.PlayerResponse
+	for id := 1; id <= 30; id++ {
+		position := players.Forward
+		switch {
+		case id <= 3:
+			position = players.Goalie
+		case id <= 12:
+			position = players.Defensemen
+		}
+		candidates = append(candidates, players.PlayerResponse{ID: id, Position: position, PlayerCost: float32(id%10 + 1)})
+	}
+	return candidates
+}
+
+func TestDrawPack_defaultTable(t *testing.T) {
+	owned := map[store.CardRarity]map[int]struct{}{store.Silver: {1: {}, 2: {}}}
+
+	cards := drawPack(rand.New(rand.NewSource(1)), store.DefaultDropTable(store.Silver), 5, testPackCandidates(), owned)
+
+	assert.Len(t, cards, 5)
+	seen := make(map[int]bool)
+	for _, card := range cards {
+		assert.Equal(t, store.Silver, card.Rarity)
+		assert.NotContains(t, []int{1, 2}, card.PlayerID)
+		assert.False(t, seen[card.PlayerID])
+		seen[card.PlayerID] = true
+	}
+}
+
+func TestDrawPack_guaranteesAndQuotas(t *testing.T) {
+	table := store.DropTable{
+		RarityWeights:  []store.RarityWeight{{Rarity: store.Silver, Weight: 1}},
+		Guarantees:     []store.RarityGuarantee{{Rarity: store.Gold, Count: 1}},
+		PositionQuotas: []store.PositionQuota{{Position: int8(players.Goalie), Count: 1}},
+	}
+
+	for seed := int64(0); seed < 50; seed++ {
+		cards := drawPack(rand.New(rand.NewSource(seed)), table, 4, testPackCandidates(), nil)
+
+		var gold, goalies int
+		for _, card := range cards {
+			if card.Rarity == store.Gold {
+				gold++
+			}
+			if card.Position == int8(players.Goalie) {
+				goalies++
+			}
+		}
+		assert.Len(t, cards, 4)
+		assert.Equal(t, 1, gold)
+		assert.GreaterOrEqual(t, goalies, 1)
+	}
+}
+
+func TestDrawPack_starChance(t *testing.T) {
+	table := store.DropTable{
+		RarityWeights: []store.RarityWeight{{Rarity: store.Silver, Weight: 1}},
+		StarCost:      10,
+		StarChance:    100,
+	}
+
+	cards := drawPack(rand.New(rand.NewSource(7)), table, 3, testPackCandidates(), nil)
+
+	assert.Len(t, cards, 3)
+	for _, card := range cards {
+		assert.Equal(t, 9, card.PlayerID%10)
+	}
+}
+
+func TestDrawPack_sameSeedSameCards(t *testing.T) {
+	table := store.DropTable{RarityWeights: []store.RarityWeight{{Rarity: store.Silver, Weight: 9}, {Rarity: store.Gold, Weight: 1}}}
+	candidates := testPackCandidates()
+	reversed := make([]players.PlayerResponse, len(candidates))
+	for i := range candidates {
+		reversed[len(candidates)-1-i] = candidates[i]
+	}
+
+	first := drawPack(rand.New(rand.NewSource(42)), table, 5, candidates, nil)
+	second := drawPack(rand.New(rand.NewSource(42)), table, 5, reversed, nil)
+
+	assert.Equal(t, first, second)
+}
+
+func TestDrawPack_nothingLeft(t *testing.T) {
+	candidates := testPackCandidates()[:2]
+	owned := map[store.CardRarity]map[int]struct{}{store.Gold: {1: {}, 2: {}}}
+
+	cards := drawPack(rand.New(rand.NewSource(1)), store.DefaultDropTable(store.Gold), 3, candidates, owned)
+
+	assert.Empty(t, cards)
+}
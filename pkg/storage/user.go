@@ -4,39 +4,37 @@ import (
 	"database/sql"
 	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
 	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
 )
 
 func (p *PostgresStorage) SignUp(u user.SignUpModel) error {
 	u.ID = uuid.New()
-	coinTr := user.CoinTransactionsModel{
-		ProfileID:          u.ID,
-		TransactionDetails: "Бонус за создание аккаунта",
-		Amount:             u.Coins,
-		Status:             user.SuccessTransaction,
+	// Стартовый баланс начисляется переводом от house, профиль создается с нулевым балансом
+	bonus := user.LedgerTransfer{
+		From:          user.HouseAccount(),
+		To:            user.UserAccount(u.ID),
+		Amount:        u.Coins,
+		Reason:        user.GrantReason,
+		ReferenceType: user.SignupReference,
 	}
+	u.Coins = 0
 
-	tx, err := p.db.Beginx()
-	if err != nil {
-		return err
-	}
-	err = p.CreateUserProfile(tx, u)
-	if err != nil {
-		return err
-	}
-	err = p.CreateUserData(tx, u)
-	if err != nil {
-		return err
-	}
-	err = p.CreateUserContacts(tx, u)
-	if err != nil {
-		return err
-	}
-	err = p.CreateCoinTransaction(tx, coinTr)
-	if err != nil {
-		return err
-	}
+	return p.withTx(func(tx *sqlx.Tx) error {
+		err := p.CreateUserProfile(tx, u)
+		if err != nil {
+			return err
+		}
+		err = p.CreateUserData(tx, u)
+		if err != nil {
+			return err
+		}
+		err = p.CreateUserContacts(tx, u)
+		if err != nil {
+			return err
+		}
 
-	return tx.Commit()
+		return p.PostLedgerTransfer(tx, bonus)
+	})
 }
 
 func (p *PostgresStorage) GetUserInfo(userID uuid.UUID) (user.UserInfoModel, error) {
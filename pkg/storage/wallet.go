@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// InsufficientFundsError - списание больше баланса пользователя
+type InsufficientFundsError struct {
+	ProfileID uuid.UUID
+	Balance   int
+	Required  int
+}
+
+func (e *InsufficientFundsError) Error() string {
+	return fmt.Sprintf("на балансе не достаточно монет: доступно %d, требуется %d", e.Balance, e.Required)
+}
+
+// withTx выполняет fn в транзакции. Транзакция коммитится, только если fn завершилась без ошибки,
+// иначе, в том числе при панике, откатывается
+func (p *PostgresStorage) withTx(fn func(tx *sqlx.Tx) error) (err error) {
+	tx, err := p.db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			panic(r)
+		}
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err = fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// lockWallet блокирует строку профиля до конца tx и возвращает баланс.
+// Параллельные списания с того же кошелька ждут завершения tx и видят уже измененный баланс
+func (p *PostgresStorage) lockWallet(tx *sqlx.Tx, profileID uuid.UUID) (int, error) {
+	var balance int
+
+	err := tx.Get(&balance, `SELECT coins FROM user_profile WHERE id = $1 FOR UPDATE`, profileID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, UserDoesNotExistError
+		}
+		return 0, err
+	}
+
+	return balance, nil
+}
+
+// changeWalletBalance меняет баланс пользователя на delta под блокировкой строки и возвращает новый баланс.
+// Если после списания баланс стал бы отрицательным, возвращает InsufficientFundsError
+func (p *PostgresStorage) changeWalletBalance(tx *sqlx.Tx, profileID uuid.UUID, delta int) (int, error) {
+	balance, err := p.lockWallet(tx, profileID)
+	if err != nil {
+		return 0, err
+	}
+
+	if balance+delta < 0 {
+		return 0, &InsufficientFundsError{ProfileID: profileID, Balance: balance, Required: -delta}
+	}
+
+	_, err = tx.Exec(`UPDATE user_profile SET coins = $1 WHERE id = $2`, balance+delta, profileID)
+	if err != nil {
+		return 0, err
+	}
+
+	return balance + delta, nil
+}
@@ -0,0 +1,184 @@
+package storage
+
+import (
+	"errors"
+	"github.com/Frozen-Fantasy/fantasy-backend.git/pkg/models/user"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"sync"
+	"testing"
+)
+
+// Тесты кошелька работают с настоящей базой: TEST_POSTGRES_DSN должен указывать на БД с примененными миграциями
+func newTestPostgresStorage(t *testing.T) *PostgresStorage {
+	dsn := os.Getenv("TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("TEST_POSTGRES_DSN не задан")
+	}
+
+	db, err := sqlx.Connect("postgres", dsn)
+	require.NoError(t, err)
+	db.SetMaxOpenConns(40)
+	t.Cleanup(func() { db.Close() })
+
+	return &PostgresStorage{db: db}
+}
+
+func createTestWallet(t *testing.T, p *PostgresStorage, coins int) uuid.UUID {
+	profileID := uuid.New()
+	_, err := p.db.Exec(`INSERT INTO user_profile (id, nickname, date_registration, coins) VALUES ($1, $2, NOW(), $3)`,
+		profileID, "wallet_"+profileID.String()[:8], coins)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		// Проводки удаляются первыми: на них ссылаются и транзакции, и счета
+		var transactionIDs []int64
+		err := p.db.Select(&transactionIDs, `SELECT e.transaction_id FROM ledger_entries e 
+			JOIN ledger_accounts a ON a.id = e.account_id WHERE a.profile_id = $1`, profileID)
+		assert.NoError(t, err)
+
+		_, err = p.db.Exec(`DELETE FROM ledger_entries WHERE transaction_id = ANY ($1)`, pq.Array(transactionIDs))
+		assert.NoError(t, err)
+		_, err = p.db.Exec(`DELETE FROM ledger_transactions WHERE id = ANY ($1)`, pq.Array(transactionIDs))
+		assert.NoError(t, err)
+		_, err = p.db.Exec(`DELETE FROM ledger_accounts WHERE profile_id = $1`, profileID)
+		assert.NoError(t, err)
+		_, err = p.db.Exec(`DELETE FROM user_profile WHERE id = $1`, profileID)
+		assert.NoError(t, err)
+	})
+
+	return profileID
+}
+
+func getTestBalance(t *testing.T, p *PostgresStorage, profileID uuid.UUID) int {
+	var balance int
+	require.NoError(t, p.db.Get(&balance, `SELECT coins FROM user_profile WHERE id = $1`, profileID))
+	return balance
+}
+
+func TestWallet_ConcurrentDebitsDoNotOverdraft(t *testing.T) {
+	p := newTestPostgresStorage(t)
+	profileID := createTestWallet(t, p, 1000)
+
+	const workers = 50
+	const price = 100
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	succeeded, insufficient := 0, 0
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := p.withTx(func(tx *sqlx.Tx) error {
+				return p.PostLedgerTransfer(tx, user.LedgerTransfer{
+					From:          user.UserAccount(profileID),
+					To:            user.HouseAccount(),
+					Amount:        price,
+					Reason:        user.PurchaseReason,
+					ReferenceType: user.ProductReference,
+				})
+			})
+
+			mu.Lock()
+			defer mu.Unlock()
+			var fundsErr *InsufficientFundsError
+			switch {
+			case err == nil:
+				succeeded++
+			case errors.As(err, &fundsErr):
+				insufficient++
+			default:
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 10, succeeded)
+	assert.Equal(t, workers-10, insufficient)
+	assert.Equal(t, 0, getTestBalance(t, p, profileID))
+
+	transactions, err := p.GetCoinTransactionsByProfileID(profileID)
+	require.NoError(t, err)
+	assert.Len(t, transactions, 10)
+	for _, tr := range transactions {
+		assert.GreaterOrEqual(t, tr.BalanceAfter, 0)
+	}
+}
+
+func TestWallet_ConcurrentTransfersKeepTotal(t *testing.T) {
+	p := newTestPostgresStorage(t)
+	first := createTestWallet(t, p, 500)
+	second := createTestWallet(t, p, 500)
+
+	// Встречные переводы проверяют, что блокировки берутся в одном порядке и не приводят к deadlock
+	var wg sync.WaitGroup
+	for i := 0; i < 40; i++ {
+		from, to := first, second
+		if i%2 == 1 {
+			from, to = second, first
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := p.withTx(func(tx *sqlx.Tx) error {
+				return p.PostLedgerTransfer(tx, user.LedgerTransfer{
+					From:   user.UserAccount(from),
+					To:     user.UserAccount(to),
+					Amount: 50,
+					Reason: user.GrantReason,
+				})
+			})
+			var fundsErr *InsufficientFundsError
+			if err != nil && !errors.As(err, &fundsErr) {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	firstBalance, secondBalance := getTestBalance(t, p, first), getTestBalance(t, p, second)
+	assert.GreaterOrEqual(t, firstBalance, 0)
+	assert.GreaterOrEqual(t, secondBalance, 0)
+	assert.Equal(t, 1000, firstBalance+secondBalance)
+}
+
+func TestWallet_RollbackOnError(t *testing.T) {
+	p := newTestPostgresStorage(t)
+	profileID := createTestWallet(t, p, 300)
+
+	failure := errors.New("something went wrong")
+	err := p.withTx(func(tx *sqlx.Tx) error {
+		_, err := p.changeWalletBalance(tx, profileID, -200)
+		require.NoError(t, err)
+		return failure
+	})
+	assert.Equal(t, failure, err)
+	assert.Equal(t, 300, getTestBalance(t, p, profileID))
+
+	assert.Panics(t, func() {
+		p.withTx(func(tx *sqlx.Tx) error {
+			_, err := p.changeWalletBalance(tx, profileID, -200)
+			require.NoError(t, err)
+			panic("boom")
+		})
+	})
+	assert.Equal(t, 300, getTestBalance(t, p, profileID))
+
+	err = p.withTx(func(tx *sqlx.Tx) error {
+		_, err := p.changeWalletBalance(tx, profileID, -301)
+		return err
+	})
+	var fundsErr *InsufficientFundsError
+	require.ErrorAs(t, err, &fundsErr)
+	assert.Equal(t, 300, fundsErr.Balance)
+	assert.Equal(t, 301, fundsErr.Required)
+	assert.Equal(t, 300, getTestBalance(t, p, profileID))
+}